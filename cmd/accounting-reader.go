@@ -38,6 +38,7 @@ type accounter struct {
 	currentValue int64
 	finishOnce   sync.Once
 	isFinished   chan struct{}
+	limits       bandwidthLimits
 }
 
 // Instantiate a new accounter.
@@ -84,6 +85,13 @@ type accountStat struct {
 	Total       int64   `json:"total"`
 	Transferred int64   `json:"transferred"`
 	Speed       float64 `json:"speed"`
+
+	// Measured rates of the limited directions in bytes per second,
+	// along with their configured limits.
+	UploadRate    int64 `json:"uploadRate,omitempty"`
+	UploadLimit   int64 `json:"uploadLimit,omitempty"`
+	DownloadRate  int64 `json:"downloadRate,omitempty"`
+	DownloadLimit int64 `json:"downloadLimit,omitempty"`
 }
 
 func (c accountStat) JSON() string {
@@ -103,6 +111,14 @@ func (c accountStat) String() string {
 	}
 	message := fmt.Sprintf("Total: %s, Transferred: %s, Speed: %s", pb.Format(c.Total).To(pb.U_BYTES),
 		pb.Format(c.Transferred).To(pb.U_BYTES), speedBox)
+	if c.UploadLimit > 0 {
+		message += fmt.Sprintf(", Upload: %s/s (limit: %s/s)", pb.Format(c.UploadRate).To(pb.U_BYTES),
+			pb.Format(c.UploadLimit).To(pb.U_BYTES))
+	}
+	if c.DownloadLimit > 0 {
+		message += fmt.Sprintf(", Download: %s/s (limit: %s/s)", pb.Format(c.DownloadRate).To(pb.U_BYTES),
+			pb.Format(c.DownloadLimit).To(pb.U_BYTES))
+	}
 	return message
 }
 
@@ -114,6 +130,14 @@ func (a *accounter) Stat() accountStat {
 		acntStat.Total = a.Total
 		acntStat.Transferred = atomic.LoadInt64(&a.current)
 		acntStat.Speed = a.write(atomic.LoadInt64(&a.current))
+		if a.limits.upload != nil {
+			acntStat.UploadRate = a.limits.upload.measuredRate()
+			acntStat.UploadLimit = a.limits.upload.rate
+		}
+		if a.limits.download != nil {
+			acntStat.DownloadRate = a.limits.download.measuredRate()
+			acntStat.DownloadLimit = a.limits.download.rate
+		}
 	})
 	return acntStat
 }
@@ -133,6 +157,13 @@ func (a *accounter) Set(n int64) *accounter {
 	return a
 }
 
+// SetLimits records the bandwidth limits whose rates are reported along
+// with the speed.
+func (a *accounter) SetLimits(limits bandwidthLimits) *accounter {
+	a.limits = limits
+	return a
+}
+
 // Get gets current value atomically
 func (a *accounter) Get() int64 {
 	return atomic.LoadInt64(&a.current)
@@ -0,0 +1,180 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminIAMApplyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "plan",
+		Usage: "only print the changes, do not apply them",
+	},
+}
+
+var adminIAMApplyCmd = cli.Command{
+	Name:   "apply",
+	Usage:  "apply users, groups, policies and their attachments from a file",
+	Action: mainAdminIAMApply,
+	Before: setGlobalsFromContext,
+	Flags:  append(adminIAMApplyFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET FILE
+
+FILE:
+  A YAML file as written by 'admin iam export', JSON is expected when the file name ends with '.json'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Compute the changes turning the users, groups, canned policies and policy attachments of the
+  server into the ones of FILE, print them and apply them. Policies are created before the users
+  and groups attached to them, and users before the groups they are members of. Users, groups
+  and policies missing in FILE are removed. Secret keys are only used to create new users, they
+  may refer to environment variables such as '${USER_SECRET}'.
+
+EXAMPLES:
+  1. Print the changes needed to apply 'iam.yaml' to 'myminio'.
+     {{.Prompt}} {{.HelpName}} --plan myminio iam.yaml
+
+  2. Apply 'iam.yaml' to 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio iam.yaml
+`,
+}
+
+// iamPlanMessage container for the changes of an IAM plan.
+type iamPlanMessage struct {
+	Status  string      `json:"status"`
+	Target  string      `json:"target"`
+	Changes []iamChange `json:"changes"`
+}
+
+func (i iamPlanMessage) String() string {
+	if len(i.Changes) == 0 {
+		return console.Colorize("IAMMessage", "IAM configuration of `"+i.Target+"` is up to date.")
+	}
+	var lines []string
+	for _, change := range i.Changes {
+		var line string
+		switch change.Op {
+		case "add":
+			line = console.Colorize("IAMAdd", "+ ")
+		case "update":
+			line = console.Colorize("IAMUpdate", "~ ")
+		case "remove":
+			line = console.Colorize("IAMRemove", "- ")
+		}
+		line += change.Kind + " `" + change.Name + "`"
+		if change.Detail != "" {
+			line += " (" + change.Detail + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (i iamPlanMessage) JSON() string {
+	i.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// iamApplyMessage container for the result of an IAM apply.
+type iamApplyMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Applied int    `json:"applied"`
+}
+
+func (i iamApplyMessage) String() string {
+	return console.Colorize("IAMMessage", fmt.Sprintf("Applied %d change(s) to `%s` successfully.", i.Applied, i.Target))
+}
+
+func (i iamApplyMessage) JSON() string {
+	i.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminIAMApplySyntax - validate all the passed arguments
+func checkAdminIAMApplySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "apply", 1) // last argument is exit code
+	}
+}
+
+// mainAdminIAMApply is the handle for "mc admin iam apply" command.
+func mainAdminIAMApply(cliCtx *cli.Context) error {
+	ctx, cancelIAMApply := context.WithCancel(globalContext)
+	defer cancelIAMApply()
+
+	checkAdminIAMApplySyntax(cliCtx)
+	setIAMDisplayColorScheme()
+
+	// Get the alias parameter from cli
+	args := cliCtx.Args()
+	aliasedURL := args.Get(0)
+
+	desired, err := loadIAMSpec(args.Get(1))
+	fatalIf(err.Trace(args...), "Unable to load the IAM configuration file.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	current, err := getIAMSpec(ctx, client)
+	fatalIf(err.Trace(args...), "Unable to get the IAM configuration.")
+
+	changes, err := planIAMChanges(current, desired)
+	fatalIf(err.Trace(args...), "Unable to compute the IAM changes.")
+
+	printMsg(iamPlanMessage{
+		Target:  aliasedURL,
+		Changes: changes,
+	})
+	if cliCtx.Bool("plan") || len(changes) == 0 {
+		return nil
+	}
+
+	for i, change := range changes {
+		e := change.apply(ctx, client)
+		fatalIf(probe.NewError(e).Trace(args...),
+			fmt.Sprintf("Unable to %s %s `%s`, %d of %d change(s) applied.", change.Op, change.Kind, change.Name, i, len(changes)))
+	}
+
+	printMsg(iamApplyMessage{
+		Target:  aliasedURL,
+		Applied: len(changes),
+	})
+	return nil
+}
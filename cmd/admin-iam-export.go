@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var adminIAMExportCmd = cli.Command{
+	Name:   "export",
+	Usage:  "export users, groups, policies and their attachments",
+	Action: mainAdminIAMExport,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Export the users, groups, canned policies and policy attachments of a MinIO server in YAML
+  format. Secret keys of users cannot be exported, builtin policies are not exported.
+
+EXAMPLES:
+  1. Export the IAM configuration of 'myminio' to 'iam.yaml'.
+     {{.Prompt}} {{.HelpName}} myminio > iam.yaml
+`,
+}
+
+// iamExportMessage container for an exported IAM configuration.
+type iamExportMessage struct {
+	Status string   `json:"status"`
+	Target string   `json:"target"`
+	Spec   *iamSpec `json:"iam"`
+}
+
+func (i iamExportMessage) String() string {
+	specBytes, e := yaml.Marshal(i.Spec)
+	fatalIf(probe.NewError(e), "Unable to marshal into YAML.")
+
+	return string(specBytes)
+}
+
+func (i iamExportMessage) JSON() string {
+	i.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminIAMExportSyntax - validate all the passed arguments
+func checkAdminIAMExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+}
+
+// mainAdminIAMExport is the handle for "mc admin iam export" command.
+func mainAdminIAMExport(cliCtx *cli.Context) error {
+	ctx, cancelIAMExport := context.WithCancel(globalContext)
+	defer cancelIAMExport()
+
+	checkAdminIAMExportSyntax(cliCtx)
+
+	// Get the alias parameter from cli
+	args := cliCtx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	spec, err := getIAMSpec(ctx, client)
+	fatalIf(err.Trace(args...), "Unable to get the IAM configuration.")
+
+	printMsg(iamExportMessage{
+		Target: aliasedURL,
+		Spec:   spec,
+	})
+	return nil
+}
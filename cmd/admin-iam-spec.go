@@ -0,0 +1,583 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// iamBuiltinPolicies are the canned policies of the server, they can
+// be attached but are not managed by IAM specs.
+var iamBuiltinPolicies = map[string]struct{}{
+	"readonly":     {},
+	"readwrite":    {},
+	"writeonly":    {},
+	"diagnostics":  {},
+	"consoleAdmin": {},
+}
+
+// iamPolicySpec describes a canned policy of an IAM spec.
+type iamPolicySpec struct {
+	Name string `yaml:"name" json:"name"`
+	// Policy document in JSON format.
+	Policy string `yaml:"policy" json:"policy"`
+}
+
+// iamUserSpec describes a user of an IAM spec.
+type iamUserSpec struct {
+	AccessKey string `yaml:"accessKey" json:"accessKey"`
+	// Secret key of a new user, environment variables such as
+	// '${USER_SECRET}' are expanded. It is never exported.
+	SecretKey string `yaml:"secretKey,omitempty" json:"secretKey,omitempty"`
+	Status    string `yaml:"status,omitempty" json:"status,omitempty"`
+	Policy    string `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// iamGroupSpec describes a group of an IAM spec.
+type iamGroupSpec struct {
+	Name    string   `yaml:"name" json:"name"`
+	Status  string   `yaml:"status,omitempty" json:"status,omitempty"`
+	Members []string `yaml:"members,omitempty" json:"members,omitempty"`
+	Policy  string   `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// iamSpec is the content of an IAM spec file.
+type iamSpec struct {
+	Policies []iamPolicySpec `yaml:"policies,omitempty" json:"policies,omitempty"`
+	Users    []iamUserSpec   `yaml:"users,omitempty" json:"users,omitempty"`
+	Groups   []iamGroupSpec  `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+// validIAMStatus verifies a user or group status, empty means enabled.
+func validIAMStatus(status string) bool {
+	switch status {
+	case "", string(madmin.AccountEnabled), string(madmin.AccountDisabled):
+		return true
+	}
+	return false
+}
+
+// iamStatus returns the status of a user or group, enabled by default.
+func iamStatus(status string) string {
+	if status == "" {
+		return string(madmin.AccountEnabled)
+	}
+	return status
+}
+
+// canonicalIAMPolicy returns a policy document in a canonical JSON
+// form, used to compare policies regardless of their formatting.
+func canonicalIAMPolicy(policy string) (string, *probe.Error) {
+	p, e := iampolicy.ParseConfig(strings.NewReader(policy))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	policyBytes, e := json.Marshal(p)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	var doc interface{}
+	if e = json.Unmarshal(policyBytes, &doc); e != nil {
+		return "", probe.NewError(e)
+	}
+	policyBytes, e = json.Marshal(sortJSONArrays(doc))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(policyBytes), nil
+}
+
+// sortJSONArrays sorts the string arrays of a decoded JSON document,
+// policy actions and resources are sets.
+func sortJSONArrays(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = sortJSONArrays(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = sortJSONArrays(v[i])
+		}
+		sort.SliceStable(v, func(i, j int) bool {
+			si, iok := v[i].(string)
+			sj, jok := v[j].(string)
+			return iok && jok && si < sj
+		})
+	}
+	return doc
+}
+
+// splitIAMPolicies returns the policy names of a comma separated list.
+func splitIAMPolicies(policies string) (names []string) {
+	for _, name := range strings.Split(policies, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validate verifies the spec is complete and consistent.
+func (s *iamSpec) validate() *probe.Error {
+	policies := make(map[string]struct{}, len(s.Policies))
+	for i, policy := range s.Policies {
+		if policy.Name == "" {
+			return probe.NewError(fmt.Errorf("policy %d has no name", i+1))
+		}
+		if _, ok := iamBuiltinPolicies[policy.Name]; ok {
+			return probe.NewError(fmt.Errorf("policy `%s` is a builtin policy", policy.Name))
+		}
+		if _, ok := policies[policy.Name]; ok {
+			return probe.NewError(fmt.Errorf("policy name `%s` is not unique", policy.Name))
+		}
+		policies[policy.Name] = struct{}{}
+		if _, err := canonicalIAMPolicy(policy.Policy); err != nil {
+			return err.Trace(policy.Name)
+		}
+	}
+	checkPolicies := func(kind, name, names string) *probe.Error {
+		for _, policy := range splitIAMPolicies(names) {
+			_, isBuiltin := iamBuiltinPolicies[policy]
+			if _, ok := policies[policy]; !ok && !isBuiltin {
+				return probe.NewError(fmt.Errorf("%s `%s` has an unknown policy `%s`", kind, name, policy))
+			}
+		}
+		return nil
+	}
+
+	users := make(map[string]struct{}, len(s.Users))
+	for i, user := range s.Users {
+		if user.AccessKey == "" {
+			return probe.NewError(fmt.Errorf("user %d has no access key", i+1))
+		}
+		if _, ok := users[user.AccessKey]; ok {
+			return probe.NewError(fmt.Errorf("user `%s` is not unique", user.AccessKey))
+		}
+		users[user.AccessKey] = struct{}{}
+		if !validIAMStatus(user.Status) {
+			return probe.NewError(fmt.Errorf("user `%s` has an invalid status `%s`", user.AccessKey, user.Status))
+		}
+		if err := checkPolicies("user", user.AccessKey, user.Policy); err != nil {
+			return err
+		}
+	}
+
+	groups := make(map[string]struct{}, len(s.Groups))
+	for i, group := range s.Groups {
+		if group.Name == "" {
+			return probe.NewError(fmt.Errorf("group %d has no name", i+1))
+		}
+		if _, ok := groups[group.Name]; ok {
+			return probe.NewError(fmt.Errorf("group name `%s` is not unique", group.Name))
+		}
+		groups[group.Name] = struct{}{}
+		if !validIAMStatus(group.Status) {
+			return probe.NewError(fmt.Errorf("group `%s` has an invalid status `%s`", group.Name, group.Status))
+		}
+		for _, member := range group.Members {
+			if _, ok := users[member]; !ok {
+				return probe.NewError(fmt.Errorf("group `%s` has an unknown member `%s`", group.Name, member))
+			}
+		}
+		if err := checkPolicies("group", group.Name, group.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseIAMSpec parses a YAML or JSON spec, JSON is expected when
+// the file name ends with '.json'.
+func parseIAMSpec(fileName string, data []byte) (*iamSpec, *probe.Error) {
+	spec := &iamSpec{}
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		// The output of `admin iam export --json` holds the spec in `iam`.
+		var exported struct {
+			Status string          `json:"status"`
+			Spec   json.RawMessage `json:"iam"`
+		}
+		if json.Unmarshal(data, &exported) == nil && exported.Status != "" && len(exported.Spec) > 0 {
+			data = exported.Spec
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if e := decoder.Decode(spec); e != nil {
+			return nil, probe.NewError(e).Trace(fileName)
+		}
+	} else if e := yaml.UnmarshalStrict(data, spec); e != nil {
+		return nil, probe.NewError(e).Trace(fileName)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err.Trace(fileName)
+	}
+	for i := range spec.Users {
+		spec.Users[i].SecretKey = os.ExpandEnv(spec.Users[i].SecretKey)
+	}
+	return spec, nil
+}
+
+// loadIAMSpec reads and parses the spec file.
+func loadIAMSpec(fileName string) (*iamSpec, *probe.Error) {
+	data, e := ioutil.ReadFile(fileName)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(fileName)
+	}
+	return parseIAMSpec(fileName, data)
+}
+
+// getIAMSpec fetches the current users, groups, canned policies and
+// their attachments from the server.
+func getIAMSpec(ctx context.Context, client *madmin.AdminClient) (*iamSpec, *probe.Error) {
+	spec := &iamSpec{}
+
+	policies, e := client.ListCannedPolicies(ctx)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for name, policy := range policies {
+		if _, ok := iamBuiltinPolicies[name]; ok {
+			continue
+		}
+		policyBytes, e := json.Marshal(policy)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(name)
+		}
+		spec.Policies = append(spec.Policies, iamPolicySpec{Name: name, Policy: string(policyBytes)})
+	}
+
+	users, e := client.ListUsers(ctx)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for accessKey, user := range users {
+		spec.Users = append(spec.Users, iamUserSpec{
+			AccessKey: accessKey,
+			Status:    string(user.Status),
+			Policy:    user.PolicyName,
+		})
+	}
+
+	groups, e := client.ListGroups(ctx)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for _, group := range groups {
+		groupDesc, e := client.GetGroupDescription(ctx, group)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(group)
+		}
+		members := append([]string{}, groupDesc.Members...)
+		sort.Strings(members)
+		spec.Groups = append(spec.Groups, iamGroupSpec{
+			Name:    group,
+			Status:  groupDesc.Status,
+			Members: members,
+			Policy:  groupDesc.Policy,
+		})
+	}
+
+	sort.Slice(spec.Policies, func(i, j int) bool { return spec.Policies[i].Name < spec.Policies[j].Name })
+	sort.Slice(spec.Users, func(i, j int) bool { return spec.Users[i].AccessKey < spec.Users[j].AccessKey })
+	sort.Slice(spec.Groups, func(i, j int) bool { return spec.Groups[i].Name < spec.Groups[j].Name })
+	return spec, nil
+}
+
+// iamChange is one step of an IAM plan.
+type iamChange struct {
+	Op     string `json:"op"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+
+	apply func(ctx context.Context, client *madmin.AdminClient) error
+}
+
+// diffIAMMembers returns the members to add to and remove from a group.
+func diffIAMMembers(current, desired []string) (added, removed []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, member := range current {
+		currentSet[member] = struct{}{}
+	}
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, member := range desired {
+		desiredSet[member] = struct{}{}
+		if _, ok := currentSet[member]; !ok {
+			added = append(added, member)
+		}
+	}
+	for _, member := range current {
+		if _, ok := desiredSet[member]; !ok {
+			removed = append(removed, member)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// planIAMChanges computes the changes turning the current IAM state into
+// the desired one, in dependency order: policies are created before the
+// users and groups attached to them, users before the groups they are
+// members of, and removals happen last in reverse order.
+func planIAMChanges(current, desired *iamSpec) ([]iamChange, *probe.Error) {
+	var changes, removals []iamChange
+
+	currentPolicies := make(map[string]string, len(current.Policies))
+	for _, policy := range current.Policies {
+		currentPolicies[policy.Name] = policy.Policy
+	}
+	desiredPolicies := make(map[string]struct{}, len(desired.Policies))
+	for _, policy := range desired.Policies {
+		policy := policy
+		desiredPolicies[policy.Name] = struct{}{}
+		op := "add"
+		if currentPolicy, ok := currentPolicies[policy.Name]; ok {
+			currentCanonical, err := canonicalIAMPolicy(currentPolicy)
+			if err != nil {
+				return nil, err.Trace(policy.Name)
+			}
+			desiredCanonical, err := canonicalIAMPolicy(policy.Policy)
+			if err != nil {
+				return nil, err.Trace(policy.Name)
+			}
+			if currentCanonical == desiredCanonical {
+				continue
+			}
+			op = "update"
+		}
+		changes = append(changes, iamChange{Op: op, Kind: "policy", Name: policy.Name,
+			apply: func(ctx context.Context, client *madmin.AdminClient) error {
+				p, e := iampolicy.ParseConfig(strings.NewReader(policy.Policy))
+				if e != nil {
+					return e
+				}
+				return client.AddCannedPolicy(ctx, policy.Name, p)
+			}})
+	}
+	for _, policy := range current.Policies {
+		if _, ok := desiredPolicies[policy.Name]; !ok {
+			name := policy.Name
+			removals = append(removals, iamChange{Op: "remove", Kind: "policy", Name: name,
+				apply: func(ctx context.Context, client *madmin.AdminClient) error {
+					return client.RemoveCannedPolicy(ctx, name)
+				}})
+		}
+	}
+
+	currentUsers := make(map[string]iamUserSpec, len(current.Users))
+	for _, user := range current.Users {
+		currentUsers[user.AccessKey] = user
+	}
+	desiredUsers := make(map[string]struct{}, len(desired.Users))
+	for _, user := range desired.Users {
+		user := user
+		desiredUsers[user.AccessKey] = struct{}{}
+		currentUser, ok := currentUsers[user.AccessKey]
+		if !ok {
+			if user.SecretKey == "" {
+				return nil, probe.NewError(fmt.Errorf("new user `%s` has no secret key", user.AccessKey))
+			}
+			changes = append(changes, iamChange{Op: "add", Kind: "user", Name: user.AccessKey,
+				Detail: iamChangeDetail("", iamStatus(user.Status), "", user.Policy),
+				apply: func(ctx context.Context, client *madmin.AdminClient) error {
+					if e := client.AddUser(ctx, user.AccessKey, user.SecretKey); e != nil {
+						return e
+					}
+					if iamStatus(user.Status) != string(madmin.AccountEnabled) {
+						if e := client.SetUserStatus(ctx, user.AccessKey, madmin.AccountStatus(user.Status)); e != nil {
+							return e
+						}
+					}
+					if user.Policy != "" {
+						return client.SetPolicy(ctx, user.Policy, user.AccessKey, false)
+					}
+					return nil
+				}})
+			continue
+		}
+		statusChanged := iamStatus(currentUser.Status) != iamStatus(user.Status)
+		policyChanged := currentUser.Policy != user.Policy
+		if !statusChanged && !policyChanged {
+			continue
+		}
+		changes = append(changes, iamChange{Op: "update", Kind: "user", Name: user.AccessKey,
+			Detail: iamChangeDetail(iamStatus(currentUser.Status), iamStatus(user.Status), currentUser.Policy, user.Policy),
+			apply: func(ctx context.Context, client *madmin.AdminClient) error {
+				if statusChanged {
+					if e := client.SetUserStatus(ctx, user.AccessKey, madmin.AccountStatus(iamStatus(user.Status))); e != nil {
+						return e
+					}
+				}
+				if policyChanged {
+					return client.SetPolicy(ctx, user.Policy, user.AccessKey, false)
+				}
+				return nil
+			}})
+	}
+	var userRemovals []iamChange
+	for _, user := range current.Users {
+		if _, ok := desiredUsers[user.AccessKey]; !ok {
+			accessKey := user.AccessKey
+			userRemovals = append(userRemovals, iamChange{Op: "remove", Kind: "user", Name: accessKey,
+				apply: func(ctx context.Context, client *madmin.AdminClient) error {
+					return client.RemoveUser(ctx, accessKey)
+				}})
+		}
+	}
+
+	currentGroups := make(map[string]iamGroupSpec, len(current.Groups))
+	for _, group := range current.Groups {
+		currentGroups[group.Name] = group
+	}
+	desiredGroups := make(map[string]struct{}, len(desired.Groups))
+	for _, group := range desired.Groups {
+		group := group
+		desiredGroups[group.Name] = struct{}{}
+		op, currentStatus := "update", ""
+		currentGroup, ok := currentGroups[group.Name]
+		if ok {
+			currentStatus = iamStatus(currentGroup.Status)
+		} else {
+			// New groups are enabled.
+			op = "add"
+			currentGroup = iamGroupSpec{Name: group.Name, Status: string(madmin.GroupEnabled)}
+		}
+		added, removed := diffIAMMembers(currentGroup.Members, group.Members)
+		statusChanged := iamStatus(currentGroup.Status) != iamStatus(group.Status)
+		policyChanged := currentGroup.Policy != group.Policy
+		if ok && len(added) == 0 && len(removed) == 0 && !statusChanged && !policyChanged {
+			continue
+		}
+		detail := iamChangeDetail(currentStatus, iamStatus(group.Status), currentGroup.Policy, group.Policy)
+		if members := iamMembersDetail(added, removed); members != "" && detail != "" {
+			detail += ", " + members
+		} else if members != "" {
+			detail = members
+		}
+		changes = append(changes, iamChange{Op: op, Kind: "group", Name: group.Name, Detail: detail,
+			apply: func(ctx context.Context, client *madmin.AdminClient) error {
+				if len(added) > 0 || op == "add" {
+					if e := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+						Group:   group.Name,
+						Members: added,
+					}); e != nil {
+						return e
+					}
+				}
+				if len(removed) > 0 {
+					if e := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+						Group:    group.Name,
+						Members:  removed,
+						IsRemove: true,
+					}); e != nil {
+						return e
+					}
+				}
+				if statusChanged {
+					if e := client.SetGroupStatus(ctx, group.Name, madmin.GroupStatus(iamStatus(group.Status))); e != nil {
+						return e
+					}
+				}
+				if policyChanged {
+					return client.SetPolicy(ctx, group.Policy, group.Name, true)
+				}
+				return nil
+			}})
+	}
+	var groupRemovals []iamChange
+	for _, group := range current.Groups {
+		if _, ok := desiredGroups[group.Name]; !ok {
+			group := group
+			groupRemovals = append(groupRemovals, iamChange{Op: "remove", Kind: "group", Name: group.Name,
+				apply: func(ctx context.Context, client *madmin.AdminClient) error {
+					// Only empty groups can be removed.
+					if len(group.Members) > 0 {
+						if e := client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+							Group:    group.Name,
+							Members:  group.Members,
+							IsRemove: true,
+						}); e != nil {
+							return e
+						}
+					}
+					return client.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+						Group:    group.Name,
+						IsRemove: true,
+					})
+				}})
+		}
+	}
+
+	// Groups are removed before their members, policies once nothing
+	// is attached to them anymore.
+	changes = append(changes, groupRemovals...)
+	changes = append(changes, userRemovals...)
+	return append(changes, removals...), nil
+}
+
+// iamChangeDetail describes the status and policy of a change, only the
+// values which change are described.
+func iamChangeDetail(currentStatus, status, currentPolicy, policy string) string {
+	var details []string
+	if currentStatus != status {
+		if currentStatus == "" {
+			details = append(details, "status: "+status)
+		} else {
+			details = append(details, "status: "+currentStatus+" -> "+status)
+		}
+	}
+	if currentPolicy != policy {
+		switch {
+		case currentPolicy == "":
+			details = append(details, "policy: "+policy)
+		case policy == "":
+			details = append(details, "policy: "+currentPolicy+" -> (none)")
+		default:
+			details = append(details, "policy: "+currentPolicy+" -> "+policy)
+		}
+	}
+	return strings.Join(details, ", ")
+}
+
+// iamMembersDetail describes the members added to and removed from a group.
+func iamMembersDetail(added, removed []string) string {
+	var members []string
+	for _, member := range added {
+		members = append(members, "+"+member)
+	}
+	for _, member := range removed {
+		members = append(members, "-"+member)
+	}
+	if len(members) == 0 {
+		return ""
+	}
+	return "members: " + strings.Join(members, " ")
+}
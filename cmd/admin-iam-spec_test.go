@@ -0,0 +1,167 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const testIAMPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::docs/*"]}]}`
+
+func TestParseIAMSpec(t *testing.T) {
+	testCases := []struct {
+		spec       string
+		shouldPass bool
+	}{
+		{`
+policies:
+  - name: docs
+    policy: '` + testIAMPolicy + `'
+users:
+  - accessKey: alice
+    secretKey: alice12345
+    policy: docs
+groups:
+  - name: readers
+    members: [alice]
+    policy: readonly
+`, true},
+		// Unknown policy.
+		{`
+users:
+  - accessKey: alice
+    policy: docs
+`, false},
+		// Unknown group member.
+		{`
+groups:
+  - name: readers
+    members: [bob]
+`, false},
+		// Invalid status.
+		{`
+users:
+  - accessKey: alice
+    status: paused
+`, false},
+		// Builtin policies are not managed.
+		{`
+policies:
+  - name: readonly
+    policy: '` + testIAMPolicy + `'
+`, false},
+		// Unknown field.
+		{`
+users:
+  - name: alice
+`, false},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseIAMSpec("iam.yaml", []byte(testCase.spec))
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestParseIAMSpecExported(t *testing.T) {
+	spec := &iamSpec{
+		Users:  []iamUserSpec{{AccessKey: "alice", Policy: "readonly"}},
+		Groups: []iamGroupSpec{{Name: "readers", Members: []string{"alice"}}},
+	}
+	exported := iamExportMessage{Target: "myminio", Spec: spec}.JSON()
+
+	// The output of `admin iam export --json` can be applied as is.
+	parsed, err := parseIAMSpec("iam.json", []byte(exported))
+	if err != nil {
+		t.Fatalf("Unable to parse exported spec: %s", err)
+	}
+	if len(parsed.Users) != 1 || parsed.Users[0].AccessKey != "alice" || len(parsed.Groups) != 1 {
+		t.Fatalf("Unexpected parsed spec %+v", parsed)
+	}
+
+	// Unknown fields of the exported spec are still rejected.
+	invalid := strings.Replace(exported, `"accessKey"`, `"name"`, 1)
+	if _, err = parseIAMSpec("iam.json", []byte(invalid)); err == nil {
+		t.Fatalf("Expected an error for an unknown field")
+	}
+}
+
+func TestPlanIAMChanges(t *testing.T) {
+	current := &iamSpec{
+		Policies: []iamPolicySpec{
+			// Same policy, formatted differently.
+			{Name: "docs", Policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::docs/*"}]}`},
+			{Name: "old", Policy: testIAMPolicy},
+		},
+		Users: []iamUserSpec{
+			{AccessKey: "alice", Status: "enabled", Policy: "readonly"},
+			{AccessKey: "carol", Status: "enabled"},
+		},
+		Groups: []iamGroupSpec{
+			{Name: "readers", Status: "enabled", Members: []string{"alice", "carol"}},
+			{Name: "legacy", Status: "enabled", Members: []string{"carol"}},
+		},
+	}
+	desired := &iamSpec{
+		Policies: []iamPolicySpec{
+			{Name: "docs", Policy: `{"Statement":[{"Resource":["arn:aws:s3:::docs/*"],"Action":["s3:*"],"Effect":"Allow"}],"Version":"2012-10-17"}`},
+			{Name: "new", Policy: testIAMPolicy},
+		},
+		Users: []iamUserSpec{
+			{AccessKey: "alice", Policy: "new"},
+			{AccessKey: "bob", SecretKey: "bob12345", Status: "disabled"},
+		},
+		Groups: []iamGroupSpec{
+			{Name: "readers", Members: []string{"alice", "bob"}},
+		},
+	}
+
+	changes, err := planIAMChanges(current, desired)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []iamChange{
+		{Op: "add", Kind: "policy", Name: "new"},
+		{Op: "update", Kind: "user", Name: "alice", Detail: "policy: readonly -> new"},
+		{Op: "add", Kind: "user", Name: "bob", Detail: "status: disabled"},
+		{Op: "update", Kind: "group", Name: "readers", Detail: "members: +bob -carol"},
+		{Op: "remove", Kind: "group", Name: "legacy"},
+		{Op: "remove", Kind: "user", Name: "carol"},
+		{Op: "remove", Kind: "policy", Name: "old"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, change := range changes {
+		if change.Op != expected[i].Op || change.Kind != expected[i].Kind ||
+			change.Name != expected[i].Name || change.Detail != expected[i].Detail {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, expected[i], change)
+		}
+	}
+
+	// A new user requires a secret key.
+	desired.Users[1].SecretKey = ""
+	if _, err = planIAMChanges(current, desired); err == nil {
+		t.Fatalf("Expected an error for a new user without secret key")
+	}
+}
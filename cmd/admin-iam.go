@@ -0,0 +1,51 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminIAMCmd = cli.Command{
+	Name:   "iam",
+	Usage:  "export and apply users, groups and policies declaratively",
+	Action: mainAdminIAM,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		adminIAMExportCmd,
+		adminIAMApplyCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainAdminIAM is the handle for "mc admin iam" command.
+func mainAdminIAM(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "export", "apply" have their own main.
+}
+
+// setIAMDisplayColorScheme sets the colors of the iam commands.
+func setIAMDisplayColorScheme() {
+	console.SetColor("IAMMessage", color.New(color.FgGreen))
+	console.SetColor("IAMAdd", color.New(color.FgGreen, color.Bold))
+	console.SetColor("IAMUpdate", color.New(color.FgYellow, color.Bold))
+	console.SetColor("IAMRemove", color.New(color.FgRed, color.Bold))
+}
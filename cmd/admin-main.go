@@ -43,6 +43,7 @@ var adminCmd = cli.Command{
 		adminUserCmd,
 		adminGroupCmd,
 		adminPolicyCmd,
+		adminIAMCmd,
 		adminConfigCmd,
 		adminHealCmd,
 		adminProfileCmd,
@@ -0,0 +1,157 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// Minimum lengths of the keys accepted by the server.
+const (
+	svcAcctAccessKeyMinLen = 3
+	svcAcctSecretKeyMinLen = 8
+)
+
+var adminUserSvcAcctAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "access-key",
+		Usage: "set an access key for the service account, generated if empty",
+	},
+	cli.StringFlag{
+		Name:  "secret-key",
+		Usage: "set a secret key for the service account, generated if empty",
+	},
+	cli.StringFlag{
+		Name:  "policy",
+		Usage: "path to a JSON policy file restricting the permissions of the parent user",
+	},
+}
+
+var adminUserSvcAcctAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "add a new service account",
+	Action: mainAdminUserSvcAcctAdd,
+	Before: setGlobalsFromContext,
+	Flags:  append(adminUserSvcAcctAddFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET PARENTUSER
+
+PARENTUSER:
+  User owning the service account, the service account inherits its policies.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The generated keys are only displayed once, save them when the service account is created.
+
+EXAMPLES:
+  1. Add a new service account for user 'foobar' with generated keys.
+     {{.Prompt}} {{.HelpName}} myminio foobar
+
+  2. Add a new service account for user 'foobar' restricted to the session policy in 'readonly.json'.
+     {{.Prompt}} {{.HelpName}} --policy /tmp/readonly.json myminio foobar
+
+  3. Add a new service account for user 'foobar' and save its keys in JSON format.
+     {{.Prompt}} {{.HelpName}} --json myminio foobar > svcacct.json
+`,
+}
+
+// checkAdminUserSvcAcctAddSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+	}
+}
+
+// newSvcAcctAddReq validates the keys and the policy file of a new
+// service account of parentUser, empty keys are generated by the server.
+func newSvcAcctAddReq(parentUser, accessKey, secretKey, policyFile string) (svcAcctAddReq, *probe.Error) {
+	if parentUser == "" {
+		return svcAcctAddReq{}, probe.NewError(errors.New("parent user cannot be empty"))
+	}
+	if accessKey != "" && len(accessKey) < svcAcctAccessKeyMinLen {
+		return svcAcctAddReq{}, probe.NewError(errors.New("access key must be at least 3 characters long"))
+	}
+	if secretKey != "" && len(secretKey) < svcAcctSecretKeyMinLen {
+		return svcAcctAddReq{}, probe.NewError(errors.New("secret key must be at least 8 characters long"))
+	}
+
+	req := svcAcctAddReq{
+		TargetUser: parentUser,
+		AccessKey:  accessKey,
+		SecretKey:  secretKey,
+	}
+	if policyFile != "" {
+		policyBytes, e := ioutil.ReadFile(policyFile)
+		if e != nil {
+			return svcAcctAddReq{}, probe.NewError(e).Trace(policyFile)
+		}
+		policy, e := iampolicy.ParseConfig(bytes.NewReader(policyBytes))
+		if e != nil {
+			return svcAcctAddReq{}, probe.NewError(e).Trace(policyFile)
+		}
+		if req.Policy, e = json.Marshal(policy); e != nil {
+			return svcAcctAddReq{}, probe.NewError(e).Trace(policyFile)
+		}
+	}
+	return req, nil
+}
+
+// mainAdminUserSvcAcctAdd is the handle for "mc admin user svcacct add" command.
+func mainAdminUserSvcAcctAdd(ctx *cli.Context) error {
+	checkAdminUserSvcAcctAddSyntax(ctx)
+
+	console.SetColor("SvcAcctMessage", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	parentUser := args.Get(1)
+
+	req, err := newSvcAcctAddReq(parentUser, ctx.String("access-key"), ctx.String("secret-key"), ctx.String("policy"))
+	fatalIf(err, "Invalid service account.")
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	resp, err := client.AddServiceAccount(globalContext, req)
+	fatalIf(err.Trace(args...), "Cannot add new service account")
+
+	printMsg(svcAcctMessage{
+		op:            "add",
+		AccessKey:     resp.Credentials.AccessKey,
+		SecretKey:     resp.Credentials.SecretKey,
+		ParentUser:    parentUser,
+		AccountStatus: "enabled",
+	})
+
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctDisableCmd = cli.Command{
+	Name:   "disable",
+	Usage:  "disable a service account",
+	Action: mainAdminUserSvcAcctDisable,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET SERVICEACCOUNT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Disable the service account 'J123C4ZXEQN8RK6ND35I'.
+     {{.Prompt}} {{.HelpName}} myminio J123C4ZXEQN8RK6ND35I
+`,
+}
+
+// checkAdminUserSvcAcctDisableSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctDisableSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "disable", 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserSvcAcctDisable is the handle for "mc admin user svcacct disable" command.
+func mainAdminUserSvcAcctDisable(ctx *cli.Context) error {
+	checkAdminUserSvcAcctDisableSyntax(ctx)
+
+	console.SetColor("SvcAcctMessage", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	err = client.UpdateServiceAccount(globalContext, args.Get(1), svcAcctUpdateReq{
+		NewStatus: "off",
+	})
+	fatalIf(err.Trace(args...), "Cannot disable service account")
+
+	printMsg(svcAcctMessage{
+		op:        "disable",
+		AccessKey: args.Get(1),
+	})
+
+	return nil
+}
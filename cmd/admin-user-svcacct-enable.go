@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctEnableCmd = cli.Command{
+	Name:   "enable",
+	Usage:  "enable a service account",
+	Action: mainAdminUserSvcAcctEnable,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET SERVICEACCOUNT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Enable a disabled service account 'J123C4ZXEQN8RK6ND35I'.
+     {{.Prompt}} {{.HelpName}} myminio J123C4ZXEQN8RK6ND35I
+`,
+}
+
+// checkAdminUserSvcAcctEnableSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctEnableSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "enable", 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserSvcAcctEnable is the handle for "mc admin user svcacct enable" command.
+func mainAdminUserSvcAcctEnable(ctx *cli.Context) error {
+	checkAdminUserSvcAcctEnableSyntax(ctx)
+
+	console.SetColor("SvcAcctMessage", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	err = client.UpdateServiceAccount(globalContext, args.Get(1), svcAcctUpdateReq{
+		NewStatus: "on",
+	})
+	fatalIf(err.Trace(args...), "Cannot enable service account")
+
+	printMsg(svcAcctMessage{
+		op:        "enable",
+		AccessKey: args.Get(1),
+	})
+
+	return nil
+}
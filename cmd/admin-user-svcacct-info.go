@@ -0,0 +1,81 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "display info of a service account",
+	Action: mainAdminUserSvcAcctInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET SERVICEACCOUNT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Display the info of service account 'J123C4ZXEQN8RK6ND35I'.
+     {{.Prompt}} {{.HelpName}} myminio J123C4ZXEQN8RK6ND35I
+`,
+}
+
+// checkAdminUserSvcAcctInfoSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserSvcAcctInfo is the handle for "mc admin user svcacct info" command.
+func mainAdminUserSvcAcctInfo(ctx *cli.Context) error {
+	checkAdminUserSvcAcctInfoSyntax(ctx)
+
+	console.SetColor("SvcAcctMessage", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	svcInfo, err := client.InfoServiceAccount(globalContext, args.Get(1))
+	fatalIf(err.Trace(args...), "Cannot get service account info")
+
+	printMsg(svcAcctMessage{
+		op:            "info",
+		AccessKey:     args.Get(1),
+		ParentUser:    svcInfo.ParentUser,
+		AccountStatus: svcInfo.AccountStatus,
+		ImpliedPolicy: svcInfo.ImpliedPolicy,
+		Policy:        json.RawMessage(svcInfo.Policy),
+	})
+
+	return nil
+}
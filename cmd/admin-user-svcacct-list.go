@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list service accounts of a user",
+	Action: mainAdminUserSvcAcctList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET PARENTUSER
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the service accounts of user 'foobar'.
+     {{.Prompt}} {{.HelpName}} myminio foobar
+`,
+}
+
+// checkAdminUserSvcAcctListSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserSvcAcctList is the handle for "mc admin user svcacct list" command.
+func mainAdminUserSvcAcctList(ctx *cli.Context) error {
+	checkAdminUserSvcAcctListSyntax(ctx)
+
+	console.SetColor("AccessKey", color.New(color.FgBlue))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	svcAccts, err := client.ListServiceAccounts(globalContext, args.Get(1))
+	fatalIf(err.Trace(args...), "Cannot list service accounts")
+
+	for _, accessKey := range svcAccts.Accounts {
+		printMsg(svcAcctMessage{
+			op:         "list",
+			AccessKey:  accessKey,
+			ParentUser: args.Get(1),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove a service account",
+	Action: mainAdminUserSvcAcctRemove,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET SERVICEACCOUNT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the service account 'J123C4ZXEQN8RK6ND35I'.
+     {{.Prompt}} {{.HelpName}} myminio J123C4ZXEQN8RK6ND35I
+`,
+}
+
+// checkAdminUserSvcAcctRemoveSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserSvcAcctRemove is the handle for "mc admin user svcacct remove" command.
+func mainAdminUserSvcAcctRemove(ctx *cli.Context) error {
+	checkAdminUserSvcAcctRemoveSyntax(ctx)
+
+	console.SetColor("SvcAcctMessage", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newSvcAcctClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	err = client.DeleteServiceAccount(globalContext, args.Get(1))
+	fatalIf(err.Trace(args...), "Cannot remove service account %s", args.Get(1))
+
+	printMsg(svcAcctMessage{
+		op:        "remove",
+		AccessKey: args.Get(1),
+	})
+
+	return nil
+}
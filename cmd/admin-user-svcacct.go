@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctCmd = cli.Command{
+	Name:   "svcacct",
+	Usage:  "manage service accounts",
+	Action: mainAdminUserSvcAcct,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		adminUserSvcAcctAddCmd,
+		adminUserSvcAcctDisableCmd,
+		adminUserSvcAcctEnableCmd,
+		adminUserSvcAcctRemoveCmd,
+		adminUserSvcAcctListCmd,
+		adminUserSvcAcctInfoCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainAdminUserSvcAcct is the handle for "mc admin user svcacct" command.
+func mainAdminUserSvcAcct(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "add", "list" have their own main.
+}
+
+// svcAcctMessage container for service account content message structure,
+// the secret key is only set when a service account is created.
+type svcAcctMessage struct {
+	op            string
+	Status        string          `json:"status"`
+	AccessKey     string          `json:"accessKey,omitempty"`
+	SecretKey     string          `json:"secretKey,omitempty"`
+	ParentUser    string          `json:"parentUser,omitempty"`
+	AccountStatus string          `json:"accountStatus,omitempty"`
+	ImpliedPolicy bool            `json:"impliedPolicy,omitempty"`
+	Policy        json.RawMessage `json:"policy,omitempty"`
+}
+
+func (s svcAcctMessage) String() string {
+	switch s.op {
+	case "list":
+		return console.Colorize("AccessKey", s.AccessKey)
+	case "info":
+		policyStr := "implied from the parent user"
+		if !s.ImpliedPolicy {
+			policyStr = string(s.Policy)
+		}
+		return console.Colorize("SvcAcctMessage", strings.Join(
+			[]string{
+				fmt.Sprintf("AccessKey: %s", s.AccessKey),
+				fmt.Sprintf("ParentUser: %s", s.ParentUser),
+				fmt.Sprintf("Status: %s", s.AccountStatus),
+				fmt.Sprintf("Policy: %s", policyStr),
+			}, "\n"))
+	case "add":
+		return console.Colorize("SvcAcctMessage", strings.Join(
+			[]string{
+				fmt.Sprintf("AccessKey: %s", s.AccessKey),
+				fmt.Sprintf("SecretKey: %s", s.SecretKey),
+				"The secret key cannot be displayed again, store it safely.",
+			}, "\n"))
+	case "remove":
+		return console.Colorize("SvcAcctMessage", "Removed service account `"+s.AccessKey+"` successfully.")
+	case "disable":
+		return console.Colorize("SvcAcctMessage", "Disabled service account `"+s.AccessKey+"` successfully.")
+	case "enable":
+		return console.Colorize("SvcAcctMessage", "Enabled service account `"+s.AccessKey+"` successfully.")
+	}
+	return ""
+}
+
+func (s svcAcctMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,203 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestNewSvcAcctAddReq(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-svcacct-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	validPolicy := filepath.Join(dir, "valid.json")
+	if e = ioutil.WriteFile(validPolicy, []byte(testIAMPolicy), 0600); e != nil {
+		t.Fatal(e)
+	}
+	invalidPolicy := filepath.Join(dir, "invalid.json")
+	if e = ioutil.WriteFile(invalidPolicy, []byte(`{"Statement":`), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		parentUser, accessKey, secretKey, policyFile string
+		shouldPass                                   bool
+	}{
+		{"foobar", "", "", "", true},
+		{"foobar", "svcacct1", "svcacct12345", "", true},
+		{"foobar", "", "", validPolicy, true},
+		// Missing parent user.
+		{"", "", "", "", false},
+		// Access key too short.
+		{"foobar", "ab", "", "", false},
+		// Secret key too short.
+		{"foobar", "", "secret", "", false},
+		// Unparsable policy.
+		{"foobar", "", "", invalidPolicy, false},
+		// Missing policy file.
+		{"foobar", "", "", filepath.Join(dir, "missing.json"), false},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newSvcAcctAddReq(testCase.parentUser, testCase.accessKey, testCase.secretKey, testCase.policyFile)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if req.TargetUser != testCase.parentUser || req.AccessKey != testCase.accessKey || req.SecretKey != testCase.secretKey {
+			t.Fatalf("Test %d: unexpected request %+v", i+1, req)
+		}
+		if (testCase.policyFile != "") != (len(req.Policy) > 0) {
+			t.Fatalf("Test %d: unexpected policy %s", i+1, req.Policy)
+		}
+	}
+}
+
+func TestSvcAcctMessage(t *testing.T) {
+	testCases := []struct {
+		msg      svcAcctMessage
+		contains []string
+	}{
+		{svcAcctMessage{op: "add", AccessKey: "svcacct1", SecretKey: "svcacct12345"},
+			[]string{"AccessKey: svcacct1", "SecretKey: svcacct12345"}},
+		{svcAcctMessage{op: "info", AccessKey: "svcacct1", ParentUser: "foobar", AccountStatus: "on", ImpliedPolicy: true},
+			[]string{"ParentUser: foobar", "Status: on", "Policy: implied from the parent user"}},
+		{svcAcctMessage{op: "info", AccessKey: "svcacct1", Policy: json.RawMessage(testIAMPolicy)},
+			[]string{"Policy: " + testIAMPolicy}},
+		{svcAcctMessage{op: "list", AccessKey: "svcacct1"}, []string{"svcacct1"}},
+		{svcAcctMessage{op: "remove", AccessKey: "svcacct1"}, []string{"Removed service account `svcacct1`"}},
+		{svcAcctMessage{op: "disable", AccessKey: "svcacct1"}, []string{"Disabled service account `svcacct1`"}},
+		{svcAcctMessage{op: "enable", AccessKey: "svcacct1"}, []string{"Enabled service account `svcacct1`"}},
+	}
+
+	for i, testCase := range testCases {
+		str := testCase.msg.String()
+		for _, s := range testCase.contains {
+			if !strings.Contains(str, s) {
+				t.Fatalf("Test %d: expected %q in %q", i+1, s, str)
+			}
+		}
+	}
+
+	var msg map[string]interface{}
+	jsonStr := svcAcctMessage{op: "add", AccessKey: "svcacct1", SecretKey: "svcacct12345", ParentUser: "foobar"}.JSON()
+	if e := json.Unmarshal([]byte(jsonStr), &msg); e != nil {
+		t.Fatal(e)
+	}
+	if msg["status"] != "success" || msg["accessKey"] != "svcacct1" || msg["secretKey"] != "svcacct12345" || msg["parentUser"] != "foobar" {
+		t.Fatalf("unexpected JSON message %s", jsonStr)
+	}
+	if _, ok := msg["policy"]; ok {
+		t.Fatalf("unexpected policy in JSON message %s", jsonStr)
+	}
+}
+
+func TestSvcAcctClient(t *testing.T) {
+	const accessKey, secretKey = "minio", "minio123"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+accessKey+"/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var resp interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /minio/admin/v3/add-service-account":
+			data, e := madmin.DecryptData(secretKey, r.Body)
+			if e != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var req svcAcctAddReq
+			if e = json.Unmarshal(data, &req); e != nil || req.TargetUser != "foobar" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var addResp svcAcctAddResp
+			addResp.Credentials.AccessKey = "svcacct1"
+			addResp.Credentials.SecretKey = "svcacct12345"
+			resp = addResp
+		case "GET /minio/admin/v3/list-service-accounts":
+			resp = svcAcctListResp{Accounts: []string{"svcacct1", "svcacct2"}}
+		case "DELETE /minio/admin/v3/delete-service-account":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioAdminServiceAccountNotFound","Message":"The specified service account is not found"}`))
+			return
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		data, _ := json.Marshal(resp)
+		data, _ = madmin.EncryptData(secretKey, data)
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	targetURL, e := url.Parse(server.URL)
+	if e != nil {
+		t.Fatal(e)
+	}
+	client := &svcAcctClient{
+		targetURL: targetURL,
+		creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+		transport: http.DefaultTransport,
+	}
+
+	addResp, err := client.AddServiceAccount(context.Background(), svcAcctAddReq{TargetUser: "foobar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addResp.Credentials.AccessKey != "svcacct1" || addResp.Credentials.SecretKey != "svcacct12345" {
+		t.Fatalf("unexpected credentials %+v", addResp.Credentials)
+	}
+
+	listResp, err := client.ListServiceAccounts(context.Background(), "foobar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(listResp.Accounts, ",") != "svcacct1,svcacct2" {
+		t.Fatalf("unexpected accounts %v", listResp.Accounts)
+	}
+
+	err = client.DeleteServiceAccount(context.Background(), "svcacct3")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if errResp, ok := err.ToGoError().(madmin.ErrorResponse); !ok || errResp.Code != "XMinioAdminServiceAccountNotFound" {
+		t.Fatalf("unexpected error %v", err.ToGoError())
+	}
+}
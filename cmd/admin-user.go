@@ -31,6 +31,7 @@ var adminUserCmd = cli.Command{
 		adminUserRemoveCmd,
 		adminUserListCmd,
 		adminUserInfoCmd,
+		adminUserSvcAcctCmd,
 	},
 	HideHelpCommand: true,
 }
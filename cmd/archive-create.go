@@ -0,0 +1,368 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var archiveCreateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "manifest",
+		Usage: "add an entry with this name listing the name, size and ETag of each archived object",
+	},
+}
+
+var archiveCreateCmd = cli.Command{
+	Name:   "create",
+	Usage:  "create an archive of the objects under a prefix",
+	Action: mainArchiveCreate,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(append(archiveCreateFlags, filterFlags...), ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+
+TARGET:
+  The archive to create, its format is given by the extension: '.tar', '.tar.gz' or '.tgz',
+  '.tar.zst' or '.tzst', and '.zip'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  OSS_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+DESCRIPTION:
+  Stream the objects under SOURCE into a single archive written to TARGET, nothing is staged
+  on the local disk. Entries are named after the path of the objects relative to SOURCE.
+
+EXAMPLES:
+  1. Archive the objects under 'logs/2020-06/' of mybucket into a tar.gz archive on the same bucket.
+     {{.Prompt}} {{.HelpName}} s3/mybucket/logs/2020-06/ s3/mybucket/archives/logs-2020-06.tar.gz
+
+  2. Archive a local folder into a zip archive on MinIO cloud storage, skipping temporary files.
+     {{.Prompt}} {{.HelpName}} --exclude "*.tmp" ~/reports/ play/mybucket/reports.zip
+
+  3. Archive the objects of mybucket into a tar.zst archive with a manifest of their sizes and ETags.
+     {{.Prompt}} {{.HelpName}} --manifest MANIFEST.json s3/mybucket/ s3/backups/mybucket.tar.zst
+`,
+}
+
+// archiveEntryMessage container for an object added to an archive.
+type archiveEntryMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+}
+
+func (a archiveEntryMessage) String() string {
+	return console.Colorize(archiveThemeEntry, fmt.Sprintf("Added `%s` (%s)", a.Name, humanize.IBytes(uint64(a.Size))))
+}
+
+func (a archiveEntryMessage) JSON() string {
+	a.Status = "success"
+	msgBytes, e := jsoncolor.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// archiveCreateMessage container for the result of archive create.
+type archiveCreateMessage struct {
+	Status  string `json:"status"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Objects int    `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+func (a archiveCreateMessage) String() string {
+	return console.Colorize(archiveThemeResultSuccess, fmt.Sprintf("Archived %d object(s), %s, from `%s` to `%s` successfully.",
+		a.Objects, humanize.IBytes(uint64(a.Size)), a.Source, a.Target))
+}
+
+func (a archiveCreateMessage) JSON() string {
+	a.Status = "success"
+	msgBytes, e := jsoncolor.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// archiveManifestEntry describes an archived object in the manifest.
+type archiveManifestEntry struct {
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// archiveManifest is the optional entry listing the archived objects.
+type archiveManifest struct {
+	Source  string                 `json:"source"`
+	Created time.Time              `json:"created"`
+	Entries []archiveManifestEntry `json:"entries"`
+}
+
+// archiveWriter writes the entries of an archive in order.
+type archiveWriter interface {
+	writeEntry(name string, size int64, modTime time.Time, r io.Reader) error
+	io.Closer
+}
+
+// tarArchiveWriter writes a tar stream, optionally compressed.
+type tarArchiveWriter struct {
+	tw      *tar.Writer
+	closers []io.Closer
+}
+
+func (t *tarArchiveWriter) writeEntry(name string, size int64, modTime time.Time, r io.Reader) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+	}
+	if e := t.tw.WriteHeader(hdr); e != nil {
+		return e
+	}
+	n, e := io.CopyN(t.tw, r, size)
+	if e == io.EOF {
+		return UnexpectedEOF{TotalSize: size, TotalWritten: n}
+	}
+	return e
+}
+
+func (t *tarArchiveWriter) Close() error {
+	if e := t.tw.Close(); e != nil {
+		return e
+	}
+	for _, closer := range t.closers {
+		if e := closer.Close(); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// zipArchiveWriter writes a zip file, entries are deflated.
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (z *zipArchiveWriter) writeEntry(name string, size int64, modTime time.Time, r io.Reader) error {
+	w, e := z.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+	if e != nil {
+		return e
+	}
+	_, e = io.Copy(w, r)
+	return e
+}
+
+func (z *zipArchiveWriter) Close() error {
+	return z.zw.Close()
+}
+
+// newArchiveWriter returns a writer of an archive in format to w.
+func newArchiveWriter(format string, w io.Writer) (archiveWriter, error) {
+	switch format {
+	case archiveFormatTar:
+		return &tarArchiveWriter{tw: tar.NewWriter(w)}, nil
+	case archiveFormatTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarArchiveWriter{tw: tar.NewWriter(gz), closers: []io.Closer{gz}}, nil
+	case archiveFormatTarZst:
+		zw, e := zstd.NewWriter(w)
+		if e != nil {
+			return nil, e
+		}
+		return &tarArchiveWriter{tw: tar.NewWriter(zw), closers: []io.Closer{zw}}, nil
+	case archiveFormatZip:
+		return &zipArchiveWriter{zw: zip.NewWriter(w)}, nil
+	}
+	return nil, errors.New("unsupported archive format `" + format + "`")
+}
+
+// writeArchive writes the objects listed from sourceClnt and kept by
+// filter to w as an archive in format, the object at targetURL is
+// skipped. It returns the number of archived objects and their total size.
+func writeArchive(ctx context.Context, w io.Writer, format string, sourceClnt Client, sourceAlias string,
+	targetURL ClientURL, filter *listFilter, manifestName string, encKeyDB map[string][]prefixSSEPair) (int, int64, *probe.Error) {
+	aw, e := newArchiveWriter(format, w)
+	if e != nil {
+		return 0, 0, probe.NewError(e)
+	}
+
+	sourceURL := sourceClnt.GetURL()
+	manifest := archiveManifest{
+		Source:  sourceURL.String(),
+		Created: UTCNow(),
+	}
+
+	var objects int
+	var size int64
+	for content := range filterListing(ctx, filter, sourceURL, sourceClnt.List(ctx, true, false, false, DirNone)) {
+		if content.Err != nil {
+			return objects, size, content.Err.Trace(sourceURL.String())
+		}
+		if !content.Type.IsRegular() || content.URL.String() == targetURL.String() {
+			continue
+		}
+
+		name := listRelativePath(sourceURL.Path, content.URL.Path, sourceURL.Separator)
+		sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, content.URL.Path))
+		sse := getSSE(sourcePath, encKeyDB[sourceAlias])
+		reader, _, err := getSourceStream(ctx, sourceAlias, content.URL.String(), "", false, sse, false)
+		if err != nil {
+			return objects, size, err.Trace(content.URL.String())
+		}
+		e = aw.writeEntry(name, content.Size, content.Time, reader)
+		reader.Close()
+		if e != nil {
+			return objects, size, probe.NewError(e).Trace(content.URL.String())
+		}
+
+		printMsg(archiveEntryMessage{
+			Source: content.URL.String(),
+			Name:   name,
+			Size:   content.Size,
+		})
+		objects++
+		size += content.Size
+		manifest.Entries = append(manifest.Entries, archiveManifestEntry{
+			Name:         name,
+			Size:         content.Size,
+			ETag:         content.ETag,
+			LastModified: content.Time,
+		})
+	}
+
+	if manifestName != "" {
+		manifestBytes, e := json.MarshalIndent(manifest, "", " ")
+		if e != nil {
+			return objects, size, probe.NewError(e)
+		}
+		if e = aw.writeEntry(manifestName, int64(len(manifestBytes)), manifest.Created, bytes.NewReader(manifestBytes)); e != nil {
+			return objects, size, probe.NewError(e).Trace(manifestName)
+		}
+	}
+
+	if e = aw.Close(); e != nil {
+		return objects, size, probe.NewError(e)
+	}
+	return objects, size, nil
+}
+
+// checkArchiveCreateSyntax - validate arguments passed by user
+func checkArchiveCreateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "create", globalErrorExitStatus)
+	}
+	if archiveFormat(ctx.Args().Get(1)) == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Get(1)),
+			"Unsupported archive format, TARGET must end with .tar, .tar.gz, .tgz, .tar.zst, .tzst or .zip.")
+	}
+	if isArchiveURL(ctx.Args().Get(1)) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Get(1)), "TARGET cannot be an entry of an archive.")
+	}
+}
+
+func mainArchiveCreate(cliCtx *cli.Context) error {
+	ctx, cancelArchiveCreate := context.WithCancel(globalContext)
+	defer cancelArchiveCreate()
+
+	checkArchiveCreateSyntax(cliCtx)
+	setArchiveDisplayColorScheme()
+
+	args := cliCtx.Args()
+	sourceURL, targetURL := args.Get(0), args.Get(1)
+
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	filter, err := getListFilter(cliCtx)
+	fatalIf(err, "Unable to parse the filter flags.")
+
+	sourceClnt, err := newClient(sourceURL)
+	fatalIf(err.Trace(sourceURL), "Unable to initialize client for "+sourceURL+".")
+
+	targetClnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize client for "+targetURL+".")
+
+	sourceAlias, _, _ := mustExpandAlias(sourceURL)
+	targetAlias, _, _ := mustExpandAlias(targetURL)
+
+	// The archive is streamed to the target while it is written.
+	reader, writer := io.Pipe()
+	type archiveResult struct {
+		objects int
+		size    int64
+		err     *probe.Error
+	}
+	resultCh := make(chan archiveResult, 1)
+	go func() {
+		objects, size, err := writeArchive(ctx, writer, archiveFormat(targetURL),
+			sourceClnt, sourceAlias, targetClnt.GetURL(), filter, cliCtx.String("manifest"), encKeyDB)
+		if err != nil {
+			writer.CloseWithError(err.ToGoError())
+		} else {
+			writer.Close()
+		}
+		resultCh <- archiveResult{objects, size, err}
+	}()
+
+	_, putErr := putTargetStreamWithURL(ctx, targetURL, reader, -1, getSSE(targetURL, encKeyDB[targetAlias]), false, false, nil)
+	if putErr != nil {
+		// Unblock the archive writer.
+		reader.CloseWithError(putErr.ToGoError())
+	}
+	result := <-resultCh
+	fatalIf(result.err.Trace(sourceURL), "Unable to archive `"+sourceURL+"`.")
+	fatalIf(putErr.Trace(targetURL), "Unable to write archive `"+targetURL+"`.")
+
+	printMsg(archiveCreateMessage{
+		Source:  sourceURL,
+		Target:  targetURL,
+		Objects: result.objects,
+		Size:    result.size,
+	})
+	return nil
+}
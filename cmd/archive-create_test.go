@@ -0,0 +1,72 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestArchiveWriter(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "archive-create-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	for _, name := range []string{"batch.tar", "batch.tar.gz", "batch.tar.zst", "batch.zip"} {
+		archive := filepath.Join(root, name)
+		f, e := os.Create(archive)
+		c.Assert(e, IsNil)
+		aw, e := newArchiveWriter(archiveFormat(name), f)
+		c.Assert(e, IsNil)
+		for _, entry := range []string{"c.txt", "dir/a.txt", "dir/sub/b.txt"} {
+			data := testArchiveFiles[entry]
+			c.Assert(aw.writeEntry(entry, int64(len(data)), time.Now(), strings.NewReader(data)), IsNil)
+		}
+		c.Assert(aw.Close(), IsNil)
+		c.Assert(f.Close(), IsNil)
+
+		fsClient, err := fsNew(archive)
+		c.Assert(err, IsNil)
+		var names []string
+		for content := range newArchiveClient(fsClient, "").List(context.Background(), true, false, false, DirNone) {
+			c.Assert(content.Err, IsNil)
+			names = append(names, strings.TrimPrefix(content.URL.Path, archive+"#/"))
+		}
+		c.Assert(names, DeepEquals, []string{"c.txt", "dir/a.txt", "dir/sub/b.txt"})
+
+		reader, err := newArchiveClient(fsClient, "dir/sub/b.txt").Get(context.Background(), nil)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		c.Assert(reader.Close(), IsNil)
+		c.Assert(string(data), Equals, "world")
+	}
+
+	// A short read is reported for tar entries.
+	aw, e := newArchiveWriter(archiveFormatTar, ioutil.Discard)
+	c.Assert(e, IsNil)
+	c.Assert(aw.writeEntry("short.txt", 10, time.Now(), strings.NewReader("abc")), NotNil)
+
+	_, e = newArchiveWriter("rar", ioutil.Discard)
+	c.Assert(e, NotNil)
+}
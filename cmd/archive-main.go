@@ -0,0 +1,51 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var archiveCmd = cli.Command{
+	Name:            "archive",
+	Usage:           "create tar and zip archives of objects",
+	Action:          mainArchive,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		archiveCreateCmd,
+	},
+}
+
+const (
+	archiveThemeEntry         string = "Archive-Entry"
+	archiveThemeResultSuccess string = "Archive-Success"
+)
+
+func mainArchive(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+}
+
+// Color scheme for the archive commands.
+func setArchiveDisplayColorScheme() {
+	console.SetColor(archiveThemeEntry, color.New(color.FgCyan))
+	console.SetColor(archiveThemeResultSuccess, color.New(color.FgGreen, color.Bold))
+}
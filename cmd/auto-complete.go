@@ -256,6 +256,21 @@ var completeCmds = map[string]complete.Predictor{
 	"/tag/remove": s3Completer,
 	"/tag/set":    s3Completer,
 
+	"/replicate/add":    s3Completer,
+	"/replicate/list":   s3Completer,
+	"/replicate/remove": s3Completer,
+	"/replicate/export": s3Completer,
+	"/replicate/import": s3Completer,
+
+	"/bucket/export": s3Completer,
+	"/bucket/import": s3Completer,
+
+	"/archive/create": s3Completer,
+
+	"/session/list":   nil,
+	"/session/resume": nil,
+	"/session/clear":  nil,
+
 	"/share/download": s3Completer,
 	"/share/list":     nil,
 	"/share/upload":   s3Completer,
@@ -299,6 +314,16 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/user/remove":  aliasCompleter,
 	"/admin/user/info":    aliasCompleter,
 
+	"/admin/user/svcacct/add":     aliasCompleter,
+	"/admin/user/svcacct/disable": aliasCompleter,
+	"/admin/user/svcacct/enable":  aliasCompleter,
+	"/admin/user/svcacct/list":    aliasCompleter,
+	"/admin/user/svcacct/remove":  aliasCompleter,
+	"/admin/user/svcacct/info":    aliasCompleter,
+
+	"/admin/iam/export": aliasCompleter,
+	"/admin/iam/apply":  aliasCompleter,
+
 	"/admin/group/add":     aliasCompleter,
 	"/admin/group/disable": aliasCompleter,
 	"/admin/group/enable":  aliasCompleter,
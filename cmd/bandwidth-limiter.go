@@ -0,0 +1,165 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// bandwidthLimiter throttles the number of bytes reported through
+// Read to a fixed rate. A single limiter is shared by all parallel
+// workers so that the rate applies to the whole transfer. It is a
+// token bucket holding at most one second worth of bytes, callers
+// exceeding it go in debt and sleep until the debt is paid back.
+type bandwidthLimiter struct {
+	mu sync.Mutex
+
+	// Rate in bytes per second.
+	rate int64
+
+	tokens int64
+	last   time.Time
+
+	// Bytes allowed since start, to measure the actual rate.
+	start       time.Time
+	transferred int64
+}
+
+// newBandwidthLimiter returns a limiter allowing rate bytes per second.
+func newBandwidthLimiter(rate int64) *bandwidthLimiter {
+	now := time.Now()
+	return &bandwidthLimiter{
+		rate:   rate,
+		tokens: rate,
+		last:   now,
+		start:  now,
+	}
+}
+
+// measuredRate returns the bytes per second allowed since the limiter
+// was created, which is at most about its rate.
+func (l *bandwidthLimiter) measuredRate() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elapsed := time.Since(l.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(float64(l.transferred) / elapsed)
+}
+
+// wait blocks until n bytes can be transferred within the rate.
+func (l *bandwidthLimiter) wait(n int64) {
+	for n > 0 {
+		chunk := n
+		if chunk > l.rate {
+			chunk = l.rate
+		}
+
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += int64(now.Sub(l.last).Seconds() * float64(l.rate))
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+		l.last = now
+		l.tokens -= chunk
+		l.transferred += chunk
+		var delay time.Duration
+		if l.tokens < 0 {
+			delay = time.Duration(float64(-l.tokens) / float64(l.rate) * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		time.Sleep(delay)
+		n -= chunk
+	}
+}
+
+// Read implements io.Reader, it only returns once len(b) bytes are
+// allowed by the rate. Meant to be used as a hook of hookreader.
+func (l *bandwidthLimiter) Read(b []byte) (n int, err error) {
+	l.wait(int64(len(b)))
+	return len(b), nil
+}
+
+// bandwidthLimits holds the limiters of data sent to and received from
+// object storage, nil when no limit is set in that direction.
+type bandwidthLimits struct {
+	upload   *bandwidthLimiter
+	download *bandwidthLimiter
+}
+
+// parseBandwidthLimits parses --limit-upload and --limit-download
+// values such as "50MiB" or "1.5MB", both are in bytes per second.
+func parseBandwidthLimits(upload, download string) (limits bandwidthLimits, err *probe.Error) {
+	if limits.upload, err = parseBandwidthLimit(upload); err != nil {
+		return limits, err.Trace(upload)
+	}
+	if limits.download, err = parseBandwidthLimit(download); err != nil {
+		return limits, err.Trace(download)
+	}
+	return limits, nil
+}
+
+func parseBandwidthLimit(limit string) (*bandwidthLimiter, *probe.Error) {
+	if limit == "" {
+		return nil, nil
+	}
+	rate, e := humanize.ParseBytes(limit)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if rate == 0 {
+		return nil, errInvalidArgument().Trace(limit)
+	}
+	return newBandwidthLimiter(int64(rate)), nil
+}
+
+// clone returns new limiters with the same rates, so that a transfer can
+// be throttled independently of other transfers.
+func (b bandwidthLimits) clone() (limits bandwidthLimits) {
+	if b.upload != nil {
+		limits.upload = newBandwidthLimiter(b.upload.rate)
+	}
+	if b.download != nil {
+		limits.download = newBandwidthLimiter(b.download.rate)
+	}
+	return limits
+}
+
+// progressHook returns progress hooked with the limiters which apply to
+// urls, upload when the target is object storage and download when the
+// source is. Server side copies are never throttled.
+func (b bandwidthLimits) progressHook(urls URLs, progress io.Reader) io.Reader {
+	if urls.SourceContent == nil || urls.TargetContent == nil || isServerSideCopy(urls) {
+		return progress
+	}
+	if b.upload != nil && urls.TargetContent.URL.Type == objectStorage {
+		progress = hookreader.NewHook(b.upload, progress)
+	}
+	if b.download != nil && urls.SourceContent.URL.Type == objectStorage {
+		progress = hookreader.NewHook(b.download, progress)
+	}
+	return progress
+}
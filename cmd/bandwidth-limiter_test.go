@@ -0,0 +1,92 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBandwidthLimits(t *testing.T) {
+	testCases := []struct {
+		upload, download         string
+		uploadRate, downloadRate int64
+		success                  bool
+	}{
+		{"", "", 0, 0, true},
+		{"50MiB", "", 50 << 20, 0, true},
+		{"", "1KB", 0, 1000, true},
+		{"1GiB", "512KiB", 1 << 30, 512 << 10, true},
+		{"0", "", 0, 0, false},
+		{"", "fast", 0, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		limits, err := parseBandwidthLimits(testCase.upload, testCase.download)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, found error `%v`", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		var uploadRate, downloadRate int64
+		if limits.upload != nil {
+			uploadRate = limits.upload.rate
+		}
+		if limits.download != nil {
+			downloadRate = limits.download.rate
+		}
+		if uploadRate != testCase.uploadRate || downloadRate != testCase.downloadRate {
+			t.Fatalf("Test %d: expected rates %d/%d, found %d/%d", i+1,
+				testCase.uploadRate, testCase.downloadRate, uploadRate, downloadRate)
+		}
+
+		// Clones have the same rates but their own limiters.
+		clone := limits.clone()
+		if (clone.upload == nil) != (limits.upload == nil) || (clone.download == nil) != (limits.download == nil) {
+			t.Fatalf("Test %d: clone does not limit the same directions", i+1)
+		}
+		if clone.upload != nil && (clone.upload == limits.upload || clone.upload.rate != uploadRate) {
+			t.Fatalf("Test %d: unexpected upload limiter clone", i+1)
+		}
+		if clone.download != nil && (clone.download == limits.download || clone.download.rate != downloadRate) {
+			t.Fatalf("Test %d: unexpected download limiter clone", i+1)
+		}
+	}
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	limiter := newBandwidthLimiter(1 << 20)
+
+	start := time.Now()
+	// First second worth of bytes is available at once,
+	// the remaining half second has to be waited for.
+	buf := make([]byte, 1<<19)
+	for i := 0; i < 3; i++ {
+		if n, err := limiter.Read(buf); err != nil || n != len(buf) {
+			t.Fatalf("Unexpected read result %d, %v", n, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("Expected reads to be throttled, took only %s", elapsed)
+	}
+	// 1.5MiB allowed in about 0.5s, the measured rate includes the
+	// initial burst.
+	if rate := limiter.measuredRate(); rate <= 0 || rate > 4<<20 {
+		t.Fatalf("Unexpected measured rate %d", rate)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/cmd/ilm"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/tags"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// bucketConfigVersion is the version of exported bucket configurations.
+const bucketConfigVersion = "1"
+
+// bucketObjectLockConfig is the default object retention of a bucket.
+type bucketObjectLockConfig struct {
+	Mode     string `json:"mode"`
+	Validity uint64 `json:"validity"`
+	Unit     string `json:"unit"`
+}
+
+// bucketQuotaConfig is the quota of a bucket.
+type bucketQuotaConfig struct {
+	Quota uint64 `json:"quota"`
+	Type  string `json:"type"`
+}
+
+// bucketConfig bundles all the settings of a bucket, settings which are
+// not set on the bucket are omitted.
+type bucketConfig struct {
+	Version       string                      `json:"version"`
+	Bucket        string                      `json:"bucket"`
+	Versioning    string                      `json:"versioning,omitempty"`
+	ObjectLock    *bucketObjectLockConfig     `json:"objectLock,omitempty"`
+	Policy        json.RawMessage             `json:"policy,omitempty"`
+	Lifecycle     *ilm.LifecycleConfiguration `json:"lifecycle,omitempty"`
+	Notifications []NotificationConfig        `json:"notifications,omitempty"`
+	Tags          map[string]string           `json:"tags,omitempty"`
+	Quota         *bucketQuotaConfig          `json:"quota,omitempty"`
+	Replication   *replication.Config         `json:"replication,omitempty"`
+}
+
+// bucketFromURL returns the bucket name of an aliased URL.
+func bucketFromURL(urlStr string) string {
+	_, path := url2Alias(urlStr)
+	return strings.SplitN(filepath.ToSlash(path), "/", 2)[0]
+}
+
+// isBucketConfigUnsupported reports if a setting is not supported by
+// the server or the client.
+func isBucketConfigUnsupported(err *probe.Error) bool {
+	switch err.ToGoError().(type) {
+	case APINotImplemented:
+		return true
+	}
+	switch minio.ToErrorResponse(err.ToGoError()).Code {
+	case "NotImplemented", "XMinioAdminNotImplemented":
+		return true
+	}
+	return false
+}
+
+// isBucketConfigNotSet reports if a setting is not set on the bucket.
+func isBucketConfigNotSet(err *probe.Error) bool {
+	switch err.ToGoError().(type) {
+	case ObjectLockConfigNotFound:
+		return true
+	}
+	switch minio.ToErrorResponse(err.ToGoError()).Code {
+	case "NoSuchBucketPolicy", "NoSuchLifecycleConfiguration", "NoSuchTagSet",
+		"ObjectLockConfigurationNotFoundError", "XMinioAdminNoSuchQuotaConfiguration":
+		return true
+	}
+	return false
+}
+
+// ignoreBucketConfigErr drops the errors of settings which are not set or
+// not supported, they are not exported.
+func ignoreBucketConfigErr(err *probe.Error) *probe.Error {
+	if err == nil || isBucketConfigNotSet(err) || isBucketConfigUnsupported(err) {
+		return nil
+	}
+	return err
+}
+
+// getBucketConfig fetches all the settings of a bucket.
+func getBucketConfig(ctx context.Context, urlStr string) (*bucketConfig, *probe.Error) {
+	client, err := newClient(urlStr)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	bucket := bucketFromURL(urlStr)
+	cfg := &bucketConfig{Version: bucketConfigVersion, Bucket: bucket}
+
+	if cfg.Versioning, err = client.GetVersioning(ctx); ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+
+	mode, validity, unit, err := client.GetObjectLockConfig(ctx)
+	if ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+	if err == nil && mode != "" {
+		cfg.ObjectLock = &bucketObjectLockConfig{Mode: string(mode), Validity: validity, Unit: string(unit)}
+	}
+
+	_, policyJSON, err := client.GetAccess(ctx)
+	if ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+	if err == nil && policyJSON != "" {
+		cfg.Policy = json.RawMessage(policyJSON)
+	}
+
+	lifecycle, err := client.GetLifecycle(ctx)
+	if ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+	if err == nil && len(lifecycle.Rules) > 0 {
+		cfg.Lifecycle = &lifecycle
+	}
+
+	if s3Client, ok := client.(*S3Client); ok {
+		notifications, err := s3Client.ListNotificationConfigs(ctx, "")
+		if ignoreBucketConfigErr(err) != nil {
+			return nil, err.Trace(urlStr)
+		}
+		cfg.Notifications = notifications
+	}
+
+	bucketTags, err := client.GetTags(ctx)
+	if ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+	if err == nil && len(bucketTags.ToMap()) > 0 {
+		cfg.Tags = bucketTags.ToMap()
+	}
+
+	if adminClient, err := newAdminClient(urlStr); err == nil {
+		quota, e := adminClient.GetBucketQuota(ctx, bucket)
+		if e != nil && ignoreBucketConfigErr(probe.NewError(e)) != nil {
+			return nil, probe.NewError(e).Trace(urlStr)
+		}
+		if e == nil && quota.Quota > 0 {
+			cfg.Quota = &bucketQuotaConfig{Quota: quota.Quota, Type: string(quota.Type)}
+		}
+	}
+
+	rcfg, err := client.GetReplication(ctx)
+	if ignoreBucketConfigErr(err) != nil {
+		return nil, err.Trace(urlStr)
+	}
+	if err == nil && !rcfg.Empty() {
+		cfg.Replication = &rcfg
+	}
+
+	return cfg, nil
+}
+
+// notificationEvents converts the exported event types of a notification
+// into the event names accepted by AddNotificationConfig.
+func notificationEvents(eventTypes []string) ([]string, *probe.Error) {
+	var events []string
+	for _, eventType := range eventTypes {
+		switch minio.NotificationEventType(eventType) {
+		case minio.ObjectCreatedAll:
+			events = append(events, "put")
+		case minio.ObjectRemovedAll:
+			events = append(events, "delete")
+		case minio.ObjectAccessedAll:
+			events = append(events, "get")
+		default:
+			return nil, probe.NewError(APINotImplemented{API: "event " + eventType, APIType: "bucket import"})
+		}
+	}
+	return events, nil
+}
+
+// bucketConfigSetting is one setting of a bucket configuration to import.
+type bucketConfigSetting struct {
+	name  string
+	apply func() *probe.Error
+}
+
+// bucketConfigSettings returns the settings of a bucket configuration in
+// the order they are imported: object lock requires versioning, and
+// replication requires versioning and its remote targets.
+func bucketConfigSettings(ctx context.Context, cfg *bucketConfig, urlStr string, client Client) []bucketConfigSetting {
+	var settings []bucketConfigSetting
+	if cfg.Versioning != "" {
+		settings = append(settings, bucketConfigSetting{"versioning", func() *probe.Error {
+			return client.SetVersioning(ctx, cfg.Versioning)
+		}})
+	}
+	if cfg.ObjectLock != nil {
+		settings = append(settings, bucketConfigSetting{"object lock", func() *probe.Error {
+			return client.SetObjectLockConfig(ctx, minio.RetentionMode(cfg.ObjectLock.Mode),
+				cfg.ObjectLock.Validity, minio.ValidityUnit(cfg.ObjectLock.Unit))
+		}})
+	}
+	if len(cfg.Policy) > 0 {
+		settings = append(settings, bucketConfigSetting{"policy", func() *probe.Error {
+			return client.SetAccess(ctx, string(cfg.Policy), true)
+		}})
+	}
+	if cfg.Lifecycle != nil {
+		settings = append(settings, bucketConfigSetting{"lifecycle", func() *probe.Error {
+			return client.SetLifecycle(ctx, *cfg.Lifecycle)
+		}})
+	}
+	if len(cfg.Notifications) > 0 {
+		settings = append(settings, bucketConfigSetting{"notifications", func() *probe.Error {
+			s3Client, ok := client.(*S3Client)
+			if !ok {
+				return probe.NewError(APINotImplemented{API: "AddNotificationConfig", APIType: "filesystem"})
+			}
+			for _, notification := range cfg.Notifications {
+				events, err := notificationEvents(notification.Events)
+				if err != nil {
+					return err.Trace(notification.Arn)
+				}
+				if err = s3Client.AddNotificationConfig(ctx, notification.Arn, events,
+					notification.Prefix, notification.Suffix, true); err != nil {
+					return err.Trace(notification.Arn)
+				}
+			}
+			return nil
+		}})
+	}
+	if len(cfg.Tags) > 0 {
+		settings = append(settings, bucketConfigSetting{"tags", func() *probe.Error {
+			t, e := tags.NewTags(cfg.Tags, false)
+			if e != nil {
+				return probe.NewError(e)
+			}
+			return client.SetTags(ctx, t.String())
+		}})
+	}
+	if cfg.Quota != nil {
+		settings = append(settings, bucketConfigSetting{"quota", func() *probe.Error {
+			adminClient, err := newAdminClient(urlStr)
+			if err != nil {
+				return probe.NewError(APINotImplemented{API: "SetBucketQuota", APIType: "non MinIO server"})
+			}
+			e := adminClient.SetBucketQuota(ctx, bucketFromURL(urlStr), &madmin.BucketQuota{
+				Quota: cfg.Quota.Quota,
+				Type:  madmin.QuotaType(cfg.Quota.Type),
+			})
+			if e != nil {
+				return probe.NewError(e)
+			}
+			return nil
+		}})
+	}
+	if cfg.Replication != nil {
+		settings = append(settings, bucketConfigSetting{"replication", func() *probe.Error {
+			return client.SetReplication(ctx, *cfg.Replication)
+		}})
+	}
+	return settings
+}
+
+// validate verifies a bucket configuration can be imported.
+func (cfg *bucketConfig) validate() *probe.Error {
+	if cfg.Version != bucketConfigVersion {
+		return probe.NewError(fmt.Errorf("unsupported bucket configuration version `%s`", cfg.Version))
+	}
+	if cfg.Replication != nil {
+		if err := replication.Validate(*cfg.Replication); err != nil {
+			return err.Trace()
+		}
+	}
+	if len(cfg.Policy) > 0 && !json.Valid(cfg.Policy) {
+		return probe.NewError(errors.New("bucket policy is not valid JSON"))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	json "github.com/minio/mc/pkg/colorjson"
+)
+
+func TestNotificationEvents(t *testing.T) {
+	testCases := []struct {
+		eventTypes []string
+		events     []string
+		shouldPass bool
+	}{
+		{[]string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}, []string{"put", "delete"}, true},
+		{[]string{"s3:ObjectAccessed:*"}, []string{"get"}, true},
+		// Single event types cannot be added back.
+		{[]string{"s3:ObjectCreated:Put"}, nil, false},
+	}
+
+	for i, testCase := range testCases {
+		events, err := notificationEvents(testCase.eventTypes)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && (err == nil || !isBucketConfigUnsupported(err)) {
+			t.Fatalf("Test %d: expected an unsupported error, got %v", i+1, err)
+		}
+		if !reflect.DeepEqual(events, testCase.events) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.events, events)
+		}
+	}
+}
+
+func TestBucketConfigValidate(t *testing.T) {
+	testCases := []struct {
+		cfg        bucketConfig
+		shouldPass bool
+	}{
+		{bucketConfig{Version: bucketConfigVersion, Bucket: "mybucket", Versioning: "Enabled"}, true},
+		{bucketConfig{Version: bucketConfigVersion, Policy: json.RawMessage(`{"Version":"2012-10-17"}`)}, true},
+		{bucketConfig{Version: bucketConfigVersion, Policy: json.RawMessage(`{"Version":`)}, false},
+		{bucketConfig{Version: "2"}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.cfg.validate()
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var bucketExportCmd = cli.Command{
+	Name:   "export",
+	Usage:  "export the configuration of a bucket in JSON format",
+	Action: mainBucketExport,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+DESCRIPTION:
+  Export the versioning, object lock, policy, lifecycle, notifications, tags, quota and replication
+  configuration of a bucket to STDOUT, as one versioned JSON document. Settings which are not set
+  or not supported are omitted.
+
+EXAMPLES:
+  1. Export the configuration of 'mybucket' on alias 'myminio' to 'bucket.json'.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket > bucket.json
+`,
+}
+
+type bucketExportMessage struct {
+	Status string        `json:"status"`
+	Target string        `json:"target"`
+	Config *bucketConfig `json:"config"`
+}
+
+func (b bucketExportMessage) String() string {
+	msgBytes, e := json.MarshalIndent(b.Config, "", " ")
+	fatalIf(probe.NewError(e), "Unable to export bucket configuration")
+
+	return string(msgBytes)
+}
+
+func (b bucketExportMessage) JSON() string {
+	b.Status = "success"
+	msgBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(msgBytes)
+}
+
+// checkBucketExportSyntax - validate arguments passed by user
+func checkBucketExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", globalErrorExitStatus)
+	}
+}
+
+func mainBucketExport(cliCtx *cli.Context) error {
+	ctx, cancelBucketExport := context.WithCancel(globalContext)
+	defer cancelBucketExport()
+
+	checkBucketExportSyntax(cliCtx)
+
+	urlStr := cliCtx.Args().Get(0)
+	if bucketFromURL(urlStr) == "" {
+		fatalIf(probe.NewError(BucketNameEmpty{}).Trace(urlStr), "Unable to export bucket configuration")
+	}
+
+	cfg, err := getBucketConfig(ctx, urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to get bucket configuration")
+
+	printMsg(bucketExportMessage{
+		Target: urlStr,
+		Config: cfg,
+	})
+	return nil
+}
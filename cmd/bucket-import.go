@@ -0,0 +1,157 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var bucketImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "only print the settings to import, do not import them",
+	},
+}
+
+var bucketImportCmd = cli.Command{
+	Name:   "import",
+	Usage:  "import the configuration of a bucket from a JSON file",
+	Action: mainBucketImport,
+	Before: setGlobalsFromContext,
+	Flags:  append(bucketImportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Import a configuration written by 'bucket export'. The bucket is created if it does not exist,
+  then its settings are set in order: versioning, object lock, policy, lifecycle, notifications,
+  tags, quota and replication. Settings not supported by the target are reported and skipped.
+
+EXAMPLES:
+  1. Import the configuration in 'bucket.json' to 'mybucket' on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} bucket.json myminio/mybucket
+
+  2. Print the settings 'bucket.json' would import to 'mybucket' on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --dry-run bucket.json myminio/mybucket
+`,
+}
+
+type bucketImportMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Setting string `json:"setting"`
+	// One of "imported", "unsupported" or "dry-run".
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (b bucketImportMessage) String() string {
+	switch b.Result {
+	case "unsupported":
+		return console.Colorize(bucketThemeResultSkipped, "Skipped "+b.Setting+" of `"+b.Target+"`, not supported: "+b.Error)
+	case "dry-run":
+		return console.Colorize(bucketThemeResultSuccess, "Would import "+b.Setting+" to `"+b.Target+"`.")
+	}
+	return console.Colorize(bucketThemeResultSuccess, "Imported "+b.Setting+" to `"+b.Target+"` successfully.")
+}
+
+func (b bucketImportMessage) JSON() string {
+	b.Status = "success"
+	msgBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(msgBytes)
+}
+
+// readBucketConfig reads and validates an exported bucket configuration.
+func readBucketConfig(fileName string) (*bucketConfig, *probe.Error) {
+	f, e := os.Open(fileName)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	cfg := &bucketConfig{}
+	if e = json.NewDecoder(f).Decode(cfg); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err.Trace(fileName)
+	}
+	return cfg, nil
+}
+
+// checkBucketImportSyntax - validate arguments passed by user
+func checkBucketImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "import", globalErrorExitStatus)
+	}
+}
+
+func mainBucketImport(cliCtx *cli.Context) error {
+	ctx, cancelBucketImport := context.WithCancel(globalContext)
+	defer cancelBucketImport()
+
+	checkBucketImportSyntax(cliCtx)
+	setBucketDisplayColorScheme()
+
+	args := cliCtx.Args()
+	fileName, urlStr := args.Get(0), args.Get(1)
+	if bucketFromURL(urlStr) == "" {
+		fatalIf(probe.NewError(BucketNameEmpty{}).Trace(urlStr), "Unable to import bucket configuration")
+	}
+	dryRun := cliCtx.Bool("dry-run")
+
+	cfg, err := readBucketConfig(fileName)
+	fatalIf(err.Trace(fileName), "Unable to read bucket configuration")
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	if !dryRun {
+		// Object lock can only be enabled when creating a bucket.
+		err = client.MakeBucket(ctx, "", true, cfg.ObjectLock != nil)
+		fatalIf(err.Trace(urlStr), "Unable to make bucket `"+urlStr+"`.")
+	}
+
+	for _, setting := range bucketConfigSettings(ctx, cfg, urlStr, client) {
+		msg := bucketImportMessage{Target: urlStr, Setting: setting.name, Result: "imported"}
+		if dryRun {
+			msg.Result = "dry-run"
+		} else if err = setting.apply(); err != nil {
+			if !isBucketConfigUnsupported(err) {
+				fatalIf(err.Trace(urlStr, setting.name), "Unable to import "+setting.name+" to `"+urlStr+"`.")
+			}
+			msg.Result = "unsupported"
+			msg.Error = err.ToGoError().Error()
+		}
+		printMsg(msg)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var bucketCmd = cli.Command{
+	Name:            "bucket",
+	Usage:           "export and import the whole configuration of a bucket",
+	Action:          mainBucket,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		bucketExportCmd,
+		bucketImportCmd,
+	},
+}
+
+const (
+	bucketThemeResultSuccess string = "Bucket-Success"
+	bucketThemeResultSkipped string = "Bucket-Skipped"
+)
+
+func mainBucket(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+}
+
+// Color scheme for the bucket commands.
+func setBucketDisplayColorScheme() {
+	console.SetColor(bucketThemeResultSuccess, color.New(color.FgGreen, color.Bold))
+	console.SetColor(bucketThemeResultSkipped, color.New(color.FgYellow, color.Bold))
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -33,10 +34,16 @@ import (
 )
 
 var (
-	// This is kept dummy for future purposes
-	// and also to add ioFlags and globalFlags
-	// in CLI registration.
-	catFlags = []cli.Flag{}
+	catFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "display an earlier object version, as of a given time or duration",
+		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "display a specific object version",
+		},
+	}
 )
 
 // Display contents of a file.
@@ -74,6 +81,12 @@ EXAMPLES:
   5. Display the content of encrypted object. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      {{.Prompt}} {{.HelpName}} --encrypt-key "play/my-bucket/=MzJieXRlc2xvbmdzZWNyZXRrZQltdXN0YmVnaXZlbjE="  play/my-bucket/my-object
+
+  6. Display the content of an object as it was 10 days ago.
+     {{.Prompt}} {{.HelpName}} --rewind 10d play/my-bucket/my-object
+
+  7. Display the content of a specific object version.
+     {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
 `,
 }
 
@@ -132,6 +145,12 @@ func checkCatSyntax(ctx *cli.Context) {
 	if !args.Present() {
 		args = []string{"-"}
 	}
+	if ctx.String("version-id") != "" && ctx.String("rewind") != "" {
+		fatalIf(errInvalidArgument().Trace(), "Flags `--version-id` and `--rewind` cannot be used together.")
+	}
+	if ctx.String("version-id") != "" && len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "Flag `--version-id` requires a single source.")
+	}
 	for _, arg := range args {
 		if strings.HasPrefix(arg, "-") && len(arg) > 1 {
 			fatalIf(probe.NewError(errors.New("")), fmt.Sprintf("Unknown flag `%s` passed.", arg))
@@ -140,7 +159,7 @@ func checkCatSyntax(ctx *cli.Context) {
 }
 
 // catURL displays contents of a URL to stdout.
-func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func catURL(ctx context.Context, sourceURL, versionID string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -153,11 +172,15 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 		// downloaded object is equal to the original one. FS files
 		// are ignored since some of them have zero size though they
 		// have contents like files under /proc.
-		client, content, err := url2Stat(ctx, sourceURL, false, encKeyDB)
+		client, content, err := url2StatWithVersion(ctx, sourceURL, versionID, timeRef, false, encKeyDB)
 		if err == nil && client.GetURL().Type == objectStorage {
 			size = content.Size
+			versionID = content.VersionID
+		} else if versionID != "" || !timeRef.IsZero() {
+			// A specific version was asked for, do not fall back to the latest one.
+			return err.Trace(sourceURL)
 		}
-		if reader, err = getSourceStreamFromURL(ctx, sourceURL, encKeyDB); err != nil {
+		if reader, err = getSourceStreamFromURL(ctx, sourceURL, versionID, encKeyDB); err != nil {
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
@@ -246,9 +269,12 @@ func mainCat(cliCtx *cli.Context) error {
 		}
 	}
 
+	versionID := cliCtx.String("version-id")
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(ctx, url, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(ctx, url, versionID, timeRef, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
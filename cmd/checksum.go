@@ -0,0 +1,189 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/sha256-simd"
+)
+
+// checksumAlgorithm - streaming checksum used to verify copied data.
+type checksumAlgorithm string
+
+// Supported checksum algorithms.
+const (
+	checksumMD5    checksumAlgorithm = "md5"
+	checksumSHA256 checksumAlgorithm = "sha256"
+	checksumCRC32C checksumAlgorithm = "crc32c"
+)
+
+// Objects may carry their checksum in user metadata under this
+// prefix followed by the algorithm, e.g. X-Amz-Meta-Checksum-Sha256.
+const checksumMetadataPrefix = "X-Amz-Meta-Checksum-"
+
+// parseChecksumAlgorithm validates a checksum algorithm name, an
+// empty name disables verification.
+func parseChecksumAlgorithm(algo string) (checksumAlgorithm, *probe.Error) {
+	switch a := checksumAlgorithm(strings.ToLower(algo)); a {
+	case "", checksumMD5, checksumSHA256, checksumCRC32C:
+		return a, nil
+	}
+	return "", errInvalidArgument().Trace(algo)
+}
+
+// newHash returns a new hash.Hash computing the checksum.
+func (a checksumAlgorithm) newHash() hash.Hash {
+	switch a {
+	case checksumSHA256:
+		return sha256.New()
+	case checksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	}
+	return md5.New()
+}
+
+// metadataKey returns the metadata key a checksum is stored under.
+func (a checksumAlgorithm) metadataKey() string {
+	return http.CanonicalHeaderKey(checksumMetadataPrefix + string(a))
+}
+
+// storedChecksum returns the checksum found in the metadata of content, if any.
+func storedChecksum(content *ClientContent, algo checksumAlgorithm) string {
+	key := algo.metadataKey()
+	if sum := content.Metadata[key]; sum != "" {
+		return strings.ToLower(sum)
+	}
+	if sum := content.UserMetadata[key]; sum != "" {
+		return strings.ToLower(sum)
+	}
+	return strings.ToLower(content.UserMetadata[strings.TrimPrefix(key, "X-Amz-Meta-")])
+}
+
+// etagChecksum returns the ETag of content when it is known to be the MD5 of
+// the data, multipart ETags carry a '-' suffix and are never used.
+func etagChecksum(content *ClientContent, algo checksumAlgorithm) string {
+	etag := strings.ToLower(strings.Trim(content.ETag, "\""))
+	if algo != checksumMD5 || len(etag) != hex.EncodedLen(md5.Size) {
+		return ""
+	}
+	if _, e := hex.DecodeString(etag); e != nil {
+		return ""
+	}
+	return etag
+}
+
+// streamChecksum computes the checksum of the object at urlStr by reading it.
+func streamChecksum(ctx context.Context, alias, urlStr, versionID string, algo checksumAlgorithm, sse encrypt.ServerSide) (string, *probe.Error) {
+	reader, _, err := getSourceStream(ctx, alias, urlStr, versionID, false, sse, false)
+	if err != nil {
+		return "", err.Trace(alias, urlStr)
+	}
+	defer reader.Close()
+
+	h := algo.newHash()
+	if _, e := io.Copy(h, reader); e != nil {
+		return "", probe.NewError(e).Trace(alias, urlStr)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyTargetChecksum compares the checksum of the copied source with the
+// target of urls. The target is trusted when its ETag matches, a checksum
+// stored in its metadata is authoritative, otherwise the target data is read
+// back. An empty sourceSum is computed by reading the source, this happens
+// for server side copies.
+func verifyTargetChecksum(ctx context.Context, urls URLs, sourceSum string, srcSSE, tgtSSE encrypt.ServerSide) *probe.Error {
+	algo := urls.Verify
+	sourceURL := urls.SourceContent.URL.String()
+	targetURL := urls.TargetContent.URL.String()
+
+	var err *probe.Error
+	if sourceSum == "" {
+		sourceSum, err = streamChecksum(ctx, urls.SourceAlias, sourceURL, urls.SourceContent.VersionID, algo, srcSSE)
+		if err != nil {
+			return err.Trace(sourceURL)
+		}
+	}
+
+	targetClnt, err := newClientFromAlias(urls.TargetAlias, targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	targetContent, err := targetClnt.Stat(ctx, false, true, tgtSSE)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+
+	if etagChecksum(targetContent, algo) == sourceSum {
+		return nil
+	}
+	targetSum := storedChecksum(targetContent, algo)
+	if targetSum == "" {
+		targetSum, err = streamChecksum(ctx, urls.TargetAlias, targetURL, "", algo, tgtSSE)
+		if err != nil {
+			return err.Trace(targetURL)
+		}
+	}
+	if targetSum != sourceSum {
+		return probe.NewError(ChecksumMismatch{
+			Algorithm: string(algo),
+			Source:    sourceURL,
+			Target:    targetURL,
+			Expected:  sourceSum,
+			Found:     targetSum,
+		})
+	}
+	return nil
+}
+
+// checksumEqual returns true if first and second hold the same data with
+// algo. Both ETags are trusted when they match as plain MD5 checksums and
+// checksums stored in the metadata of both objects are compared as they
+// are, otherwise the data of both objects is read.
+func checksumEqual(ctx context.Context, firstAlias string, first *ClientContent, secondAlias string, second *ClientContent, algo checksumAlgorithm, encKeyDB map[string][]prefixSSEPair) (bool, *probe.Error) {
+	if firstSum := etagChecksum(first, algo); firstSum != "" && firstSum == etagChecksum(second, algo) {
+		return true, nil
+	}
+	if firstSum, secondSum := storedChecksum(first, algo), storedChecksum(second, algo); firstSum != "" && secondSum != "" {
+		return firstSum == secondSum, nil
+	}
+
+	firstURL, secondURL := first.URL.String(), second.URL.String()
+	firstSSE := getSSE(filepath.ToSlash(filepath.Join(firstAlias, first.URL.Path)), encKeyDB[firstAlias])
+	secondSSE := getSSE(filepath.ToSlash(filepath.Join(secondAlias, second.URL.Path)), encKeyDB[secondAlias])
+
+	firstSum, err := streamChecksum(ctx, firstAlias, firstURL, first.VersionID, algo, firstSSE)
+	if err != nil {
+		return false, err.Trace(firstURL)
+	}
+	secondSum, err := streamChecksum(ctx, secondAlias, secondURL, second.VersionID, algo, secondSSE)
+	if err != nil {
+		return false, err.Trace(secondURL)
+	}
+	return firstSum == secondSum, nil
+}
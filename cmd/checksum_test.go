@@ -0,0 +1,125 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChecksumAlgorithm(t *testing.T) {
+	testCases := []struct {
+		algo     string
+		data     string
+		checksum string
+		success  bool
+	}{
+		{"md5", "hello", "5d41402abc4b2a76b9719d911017c592", true},
+		{"SHA256", "hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", true},
+		{"crc32c", "hello", "9a71bb4c", true},
+		{"sha1", "hello", "", false},
+	}
+
+	for i, testCase := range testCases {
+		algo, err := parseChecksumAlgorithm(testCase.algo)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, found error `%v`", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		h := algo.newHash()
+		h.Write([]byte(testCase.data))
+		if checksum := hex.EncodeToString(h.Sum(nil)); checksum != testCase.checksum {
+			t.Fatalf("Test %d: expected checksum `%s`, found `%s`", i+1, testCase.checksum, checksum)
+		}
+	}
+}
+
+func TestTargetChecksum(t *testing.T) {
+	testCases := []struct {
+		content *ClientContent
+		algo    checksumAlgorithm
+		etag    string
+		stored  string
+	}{
+		{&ClientContent{ETag: "5d41402abc4b2a76b9719d911017c592"}, checksumMD5, "5d41402abc4b2a76b9719d911017c592", ""},
+		{&ClientContent{ETag: "5d41402abc4b2a76b9719d911017c592"}, checksumSHA256, "", ""},
+		{&ClientContent{ETag: "d41d8cd98f00b204e9800998ecf8427e-2"}, checksumMD5, "", ""},
+		{&ClientContent{Metadata: map[string]string{"X-Amz-Meta-Checksum-Crc32c": "9A71BB4C"}}, checksumCRC32C, "", "9a71bb4c"},
+		{&ClientContent{UserMetadata: map[string]string{"Checksum-Sha256": "abcd"}}, checksumSHA256, "", "abcd"},
+	}
+
+	for i, testCase := range testCases {
+		if etag := etagChecksum(testCase.content, testCase.algo); etag != testCase.etag {
+			t.Fatalf("Test %d: expected ETag checksum `%s`, found `%s`", i+1, testCase.etag, etag)
+		}
+		if stored := storedChecksum(testCase.content, testCase.algo); stored != testCase.stored {
+			t.Fatalf("Test %d: expected stored checksum `%s`, found `%s`", i+1, testCase.stored, stored)
+		}
+	}
+}
+
+func TestChecksumEqual(t *testing.T) {
+	root, e := ioutil.TempDir("", "checksum-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+
+	newContent := func(name, data, etag string, metadata map[string]string) *ClientContent {
+		path := filepath.Join(root, name)
+		if e := ioutil.WriteFile(path, []byte(data), 0644); e != nil {
+			t.Fatal(e)
+		}
+		return &ClientContent{URL: *newClientURL(path), Size: int64(len(data)), ETag: etag, Metadata: metadata}
+	}
+
+	testCases := []struct {
+		first, second *ClientContent
+		algo          checksumAlgorithm
+		equal         bool
+	}{
+		// Matching plain MD5 ETags are trusted.
+		{newContent("a1", "hello", "5d41402abc4b2a76b9719d911017c592", nil),
+			newContent("a2", "world", "5d41402abc4b2a76b9719d911017c592", nil), checksumMD5, true},
+		// Matching multipart ETags are not, the data is read.
+		{newContent("b1", "hello", "d41d8cd98f00b204e9800998ecf8427e-2", nil),
+			newContent("b2", "world", "d41d8cd98f00b204e9800998ecf8427e-2", nil), checksumMD5, false},
+		// ETags are never used for other algorithms.
+		{newContent("c1", "hello", "5d41402abc4b2a76b9719d911017c592", nil),
+			newContent("c2", "world", "5d41402abc4b2a76b9719d911017c592", nil), checksumSHA256, false},
+		{newContent("d1", "hello", "", nil), newContent("d2", "hello", "", nil), checksumCRC32C, true},
+		// Stored checksums of the algorithm are compared as they are.
+		{newContent("e1", "hello", "", map[string]string{"X-Amz-Meta-Checksum-Sha256": "abcd"}),
+			newContent("e2", "hello", "", map[string]string{"X-Amz-Meta-Checksum-Sha256": "ef01"}), checksumSHA256, false},
+	}
+
+	for i, testCase := range testCases {
+		equal, err := checksumEqual(context.Background(), "", testCase.first, "", testCase.second, testCase.algo, nil)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if equal != testCase.equal {
+			t.Fatalf("Test %d: expected %t, found %t", i+1, testCase.equal, equal)
+		}
+	}
+}
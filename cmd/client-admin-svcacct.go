@@ -0,0 +1,212 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio-go/v6/pkg/s3signer"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// svcAcctClient sends the service account requests of the admin API,
+// which are not supported by the madmin package mc is built with.
+type svcAcctClient struct {
+	targetURL *url.URL
+	creds     *credentials.Credentials
+	transport http.RoundTripper
+	appInfo   string
+}
+
+// svcAcctAddReq is the body of an add service account request.
+type svcAcctAddReq struct {
+	Policy     json.RawMessage `json:"policy,omitempty"`
+	TargetUser string          `json:"targetUser,omitempty"`
+	AccessKey  string          `json:"accessKey,omitempty"`
+	SecretKey  string          `json:"secretKey,omitempty"`
+}
+
+// svcAcctAddResp is the body of an add service account response.
+type svcAcctAddResp struct {
+	Credentials struct {
+		AccessKey string `json:"accessKey"`
+		SecretKey string `json:"secretKey"`
+	} `json:"credentials"`
+}
+
+// svcAcctInfoResp is the body of an info service account response.
+type svcAcctInfoResp struct {
+	ParentUser    string `json:"parentUser"`
+	AccountStatus string `json:"accountStatus"`
+	ImpliedPolicy bool   `json:"impliedPolicy"`
+	Policy        string `json:"policy"`
+}
+
+// svcAcctListResp is the body of a list service accounts response.
+type svcAcctListResp struct {
+	Accounts []string `json:"accounts"`
+}
+
+// svcAcctUpdateReq is the body of an update service account request.
+type svcAcctUpdateReq struct {
+	NewStatus string `json:"newStatus,omitempty"`
+}
+
+// newSvcAcctClient returns a service account client for the alias of aliasedURL.
+func newSvcAcctClient(aliasedURL string) (*svcAcctClient, *probe.Error) {
+	alias, urlStrFull, hostCfg, err := expandAlias(aliasedURL)
+	if err != nil {
+		return nil, err.Trace(aliasedURL)
+	}
+	// Verify if the aliasedURL is a real URL, fail in those cases
+	// indicating the user to add alias.
+	if hostCfg == nil && urlRgx.MatchString(aliasedURL) {
+		return nil, errInvalidAliasedURL(aliasedURL).Trace(aliasedURL)
+	}
+	if hostCfg == nil {
+		return nil, probe.NewError(fmt.Errorf("No valid configuration found for '%s' host alias", urlStrFull))
+	}
+
+	config := NewS3Config(urlStrFull, hostCfg)
+	targetURL, e := url.Parse(config.HostURL)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(alias, urlStrFull)
+	}
+	creds, err := newAdminCredentials(config)
+	if err != nil {
+		return nil, err.Trace(alias, urlStrFull)
+	}
+	return &svcAcctClient{
+		targetURL: targetURL,
+		creds:     creds,
+		transport: newAdminTransport(config),
+		appInfo:   config.AppName + "/" + config.AppVersion,
+	}, nil
+}
+
+// execute sends a signed admin request, the request and response bodies
+// are encrypted with the secret key of the alias.
+func (c *svcAcctClient) execute(ctx context.Context, method, path string, query url.Values, reqData, respData interface{}) *probe.Error {
+	value, e := c.creds.Get()
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	var body []byte
+	if reqData != nil {
+		data, e := json.Marshal(reqData)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		if body, e = madmin.EncryptData(value.SecretAccessKey, data); e != nil {
+			return probe.NewError(e)
+		}
+	}
+
+	reqURL := url.URL{
+		Scheme:   c.targetURL.Scheme,
+		Host:     c.targetURL.Host,
+		Path:     "/minio/admin/v3/" + path,
+		RawQuery: query.Encode(),
+	}
+	req, e := http.NewRequest(method, reqURL.String(), bytes.NewReader(body))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", c.appInfo)
+	sha256Sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum[:]))
+	req.ContentLength = int64(len(body))
+	req = s3signer.SignV4(*req, value.AccessKeyID, value.SecretAccessKey, value.SessionToken, "")
+
+	resp, e := (&http.Client{Transport: c.transport}).Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, e := ioutil.ReadAll(resp.Body)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		errResponse := madmin.ErrorResponse{}
+		if e = json.Unmarshal(respBody, &errResponse); e != nil || errResponse.Code == "" {
+			errResponse.Code = resp.Status
+			errResponse.Message = http.StatusText(resp.StatusCode)
+		}
+		return probe.NewError(errResponse)
+	}
+	if respData == nil {
+		return nil
+	}
+
+	data, e := madmin.DecryptData(value.SecretAccessKey, resp.Body)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if e = json.Unmarshal(data, respData); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// AddServiceAccount creates a service account and returns its keys.
+func (c *svcAcctClient) AddServiceAccount(ctx context.Context, req svcAcctAddReq) (svcAcctAddResp, *probe.Error) {
+	var resp svcAcctAddResp
+	err := c.execute(ctx, http.MethodPut, "add-service-account", nil, req, &resp)
+	return resp, err
+}
+
+// InfoServiceAccount returns the parent user, status and policy of a service account.
+func (c *svcAcctClient) InfoServiceAccount(ctx context.Context, accessKey string) (svcAcctInfoResp, *probe.Error) {
+	var resp svcAcctInfoResp
+	err := c.execute(ctx, http.MethodGet, "info-service-account",
+		url.Values{"accessKey": []string{accessKey}}, nil, &resp)
+	return resp, err
+}
+
+// ListServiceAccounts returns the access keys of the service accounts of user.
+func (c *svcAcctClient) ListServiceAccounts(ctx context.Context, user string) (svcAcctListResp, *probe.Error) {
+	var resp svcAcctListResp
+	err := c.execute(ctx, http.MethodGet, "list-service-accounts",
+		url.Values{"user": []string{user}}, nil, &resp)
+	return resp, err
+}
+
+// UpdateServiceAccount changes the status of a service account.
+func (c *svcAcctClient) UpdateServiceAccount(ctx context.Context, accessKey string, req svcAcctUpdateReq) *probe.Error {
+	return c.execute(ctx, http.MethodPost, "update-service-account",
+		url.Values{"accessKey": []string{accessKey}}, req, nil)
+}
+
+// DeleteServiceAccount removes a service account.
+func (c *svcAcctClient) DeleteServiceAccount(ctx context.Context, accessKey string) *probe.Error {
+	return c.execute(ctx, http.MethodDelete, "delete-service-account",
+		url.Values{"accessKey": []string{accessKey}}, nil, nil)
+}
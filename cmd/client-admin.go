@@ -55,7 +55,7 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey))
+		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.Credentials.String()))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -64,8 +64,10 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 		var api *madmin.AdminClient
 		var found bool
 		if api, found = clientCache[confSum]; !found {
-			// Admin API only supports signature v4.
-			creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken)
+			creds, err := newAdminCredentials(config)
+			if err != nil {
+				return nil, err.Trace(config.HostURL)
+			}
 
 			// Not found. Instantiate a new MinIO
 			var e error
@@ -77,44 +79,8 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 				return nil, probe.NewError(e)
 			}
 
-			// Keep TLS config.
-			tlsConfig := &tls.Config{
-				RootCAs: globalRootCAs,
-				// Can't use SSLv3 because of POODLE and BEAST
-				// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
-				// Can't use TLSv1.1 because of RC4 cipher usage
-				MinVersion: tls.VersionTLS12,
-			}
-			if config.Insecure {
-				tlsConfig.InsecureSkipVerify = true
-			}
-
-			var transport http.RoundTripper = &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-				DialContext: (&net.Dialer{
-					Timeout:   10 * time.Second,
-					KeepAlive: 15 * time.Second,
-				}).DialContext,
-				MaxIdleConnsPerHost:   256,
-				IdleConnTimeout:       90 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 10 * time.Second,
-				TLSClientConfig:       tlsConfig,
-				// Set this value so that the underlying transport round-tripper
-				// doesn't try to auto decode the body of objects with
-				// content-encoding set to `gzip`.
-				//
-				// Refer:
-				//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
-				DisableCompression: true,
-			}
-
-			if config.Debug {
-				transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
-			}
-
 			// Set custom transport.
-			api.SetCustomTransport(transport)
+			api.SetCustomTransport(newAdminTransport(config))
 
 			// Set app info.
 			api.SetAppInfo(config.AppName, config.AppVersion)
@@ -128,6 +94,55 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 	}
 }
 
+// newAdminCredentials returns the credentials signing the admin
+// requests, the admin API only supports signature v4.
+func newAdminCredentials(config *Config) (*credentials.Credentials, *probe.Error) {
+	if config.Credentials.isStatic() {
+		return credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken), nil
+	}
+	return newCredentials(config)
+}
+
+// newAdminTransport returns the transport of the admin requests.
+func newAdminTransport(config *Config) http.RoundTripper {
+	// Keep TLS config.
+	tlsConfig := &tls.Config{
+		RootCAs: globalRootCAs,
+		// Can't use SSLv3 because of POODLE and BEAST
+		// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
+		// Can't use TLSv1.1 because of RC4 cipher usage
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.Insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 15 * time.Second,
+		}).DialContext,
+		MaxIdleConnsPerHost:   256,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 10 * time.Second,
+		TLSClientConfig:       tlsConfig,
+		// Set this value so that the underlying transport round-tripper
+		// doesn't try to auto decode the body of objects with
+		// content-encoding set to `gzip`.
+		//
+		// Refer:
+		//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
+		DisableCompression: true,
+	}
+
+	if config.Debug {
+		transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
+	}
+	return transport
+}
+
 // newAdminClient gives a new client interface
 func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 	alias, urlStrFull, hostCfg, err := expandAlias(aliasedURL)
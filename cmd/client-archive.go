@@ -0,0 +1,960 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/mc/cmd/ilm"
+	"github.com/minio/mc/cmd/replication"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/minio-go/v6/pkg/tags"
+)
+
+// archiveSeparator separates the URL of an archive from the path of an
+// entry in the archive, e.g. 's3/drops/batch.tar.gz#path/in/archive'.
+// URLs are only split with the --archive flag, otherwise '#' is a plain
+// character of paths and object keys.
+const archiveSeparator = "#"
+
+// Archive formats, by file name extension.
+const (
+	archiveFormatTar    = "tar"
+	archiveFormatTarGz  = "tar.gz"
+	archiveFormatTarZst = "tar.zst"
+	archiveFormatZip    = "zip"
+)
+
+var archiveExtensions = []struct {
+	ext    string
+	format string
+}{
+	{".tar.gz", archiveFormatTarGz},
+	{".tgz", archiveFormatTarGz},
+	{".tar.zst", archiveFormatTarZst},
+	{".tzst", archiveFormatTarZst},
+	{".tar", archiveFormatTar},
+	{".zip", archiveFormatZip},
+}
+
+// archiveFormat returns the archive format of a file name, empty if it
+// is not an archive.
+func archiveFormat(name string) string {
+	name = strings.ToLower(name)
+	for _, e := range archiveExtensions {
+		if strings.HasSuffix(name, e.ext) {
+			return e.format
+		}
+	}
+	return ""
+}
+
+// splitArchiveURL splits an URL into the URL of an archive and the path
+// of an entry in the archive. ok is false if the URL does not point into
+// an archive.
+func splitArchiveURL(urlStr string) (archiveURL, entry string, ok bool) {
+	for i := 0; i < len(urlStr); {
+		j := strings.Index(urlStr[i:], archiveSeparator)
+		if j < 0 {
+			break
+		}
+		i += j
+		if archiveFormat(urlStr[:i]) != "" {
+			entry = strings.TrimLeft(strings.Replace(urlStr[i+len(archiveSeparator):], "\\", "/", -1), "/")
+			return urlStr[:i], entry, true
+		}
+		i += len(archiveSeparator)
+	}
+	return urlStr, "", false
+}
+
+// isArchiveURL reports if an URL points into an archive, which requires
+// the --archive flag.
+func isArchiveURL(urlStr string) bool {
+	if !globalArchive {
+		return false
+	}
+	_, _, ok := splitArchiveURL(urlStr)
+	return ok
+}
+
+// archiveEntry is a file or a directory in an archive.
+type archiveEntry struct {
+	name    string
+	size    int64
+	modTime time.Time
+	mode    os.FileMode
+}
+
+func (e archiveEntry) isDir() bool {
+	return e.mode.IsDir()
+}
+
+// archiveReader iterates over the entries of an archive.
+type archiveReader interface {
+	// next returns the next entry and a reader of its contents,
+	// io.EOF at the end of the archive.
+	next() (archiveEntry, io.Reader, error)
+	io.Closer
+}
+
+// tarArchiveReader reads a tar or a gzip compressed tar stream.
+type tarArchiveReader struct {
+	tr      *tar.Reader
+	closers []io.Closer
+}
+
+func (t *tarArchiveReader) next() (archiveEntry, io.Reader, error) {
+	for {
+		hdr, e := t.tr.Next()
+		if e != nil {
+			return archiveEntry{}, nil, e
+		}
+		mode := hdr.FileInfo().Mode()
+		if !mode.IsRegular() && !mode.IsDir() {
+			// Links and special files are not listed.
+			continue
+		}
+		return archiveEntry{
+			name:    strings.TrimPrefix(path.Clean("/"+hdr.Name), "/"),
+			size:    hdr.Size,
+			modTime: hdr.ModTime,
+			mode:    mode,
+		}, t.tr, nil
+	}
+}
+
+func (t *tarArchiveReader) Close() error {
+	var err error
+	for i := len(t.closers) - 1; i >= 0; i-- {
+		if e := t.closers[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// zstdDecoderCloser releases the resources of a zstd decoder.
+type zstdDecoderCloser struct {
+	d *zstd.Decoder
+}
+
+func (z zstdDecoderCloser) Close() error {
+	z.d.Close()
+	return nil
+}
+
+// zipArchiveReader reads a zip file, which needs random access.
+type zipArchiveReader struct {
+	files  []*zip.File
+	cur    io.ReadCloser
+	closer io.Closer
+}
+
+func (z *zipArchiveReader) next() (archiveEntry, io.Reader, error) {
+	if z.cur != nil {
+		z.cur.Close()
+		z.cur = nil
+	}
+	for len(z.files) > 0 {
+		f := z.files[0]
+		z.files = z.files[1:]
+		mode := f.Mode()
+		if !mode.IsRegular() && !mode.IsDir() {
+			continue
+		}
+		entry := archiveEntry{
+			name:    strings.TrimPrefix(path.Clean("/"+f.Name), "/"),
+			size:    int64(f.UncompressedSize64),
+			modTime: f.Modified,
+			mode:    mode,
+		}
+		if entry.isDir() {
+			return entry, nil, nil
+		}
+		rc, e := f.Open()
+		if e != nil {
+			return entry, nil, e
+		}
+		z.cur = rc
+		return entry, rc, nil
+	}
+	return archiveEntry{}, nil, io.EOF
+}
+
+func (z *zipArchiveReader) Close() error {
+	if z.cur != nil {
+		z.cur.Close()
+	}
+	return z.closer.Close()
+}
+
+// archiveIndex is the sorted list of the entries of an archive, cached
+// per archive as long as the archive is not modified.
+type archiveIndex struct {
+	etag     string
+	size     int64
+	modTime  time.Time
+	entries  []archiveEntry
+	cursor   *archiveCursor
+	lastUsed uint64
+}
+
+// archiveIndexCacheSize is the number of archives whose index is cached.
+const archiveIndexCacheSize = 16
+
+var (
+	archiveIndexMu    sync.Mutex
+	archiveIndexCache = make(map[string]*archiveIndex)
+	archiveIndexClock uint64
+)
+
+// cachedArchiveIndex returns the cached index of an archive, nil if the
+// archive is not cached or was modified.
+func cachedArchiveIndex(key string, st *ClientContent) *archiveIndex {
+	archiveIndexMu.Lock()
+	defer archiveIndexMu.Unlock()
+	idx, ok := archiveIndexCache[key]
+	if !ok || idx.etag != st.ETag || idx.size != st.Size || !idx.modTime.Equal(st.Time) {
+		return nil
+	}
+	archiveIndexClock++
+	idx.lastUsed = archiveIndexClock
+	return idx
+}
+
+// cacheArchiveIndex caches the index of an archive, the least recently
+// used index is evicted when the cache is full.
+func cacheArchiveIndex(key string, idx *archiveIndex) {
+	archiveIndexMu.Lock()
+	defer archiveIndexMu.Unlock()
+	if old, ok := archiveIndexCache[key]; ok {
+		old.cursor.release()
+	} else if len(archiveIndexCache) >= archiveIndexCacheSize {
+		var oldestKey string
+		var oldest *archiveIndex
+		for k, v := range archiveIndexCache {
+			if oldest == nil || v.lastUsed < oldest.lastUsed {
+				oldestKey, oldest = k, v
+			}
+		}
+		delete(archiveIndexCache, oldestKey)
+		oldest.cursor.release()
+	}
+	archiveIndexClock++
+	idx.lastUsed = archiveIndexClock
+	archiveIndexCache[key] = idx
+}
+
+// archiveCursor reads the entries of an archive in a single pass, shared
+// by the clients of all its entries: a recursive copy reads each entry
+// after the previous one instead of reading the archive from its start
+// for every entry. The entries listed recursively which are passed over
+// before being read, as the listing is sorted by name and the archive
+// is not, are spooled to a temporary file until they are read. Entries
+// which do not fit in the spool are read again from the start of the
+// archive. Entries read concurrently are read by passes of their own.
+type archiveCursor struct {
+	// mu protects the fields below, it is not held while the contents
+	// of an entry are read.
+	mu   sync.Mutex
+	open func() (archiveReader, *probe.Error)
+	// Passes over the archive not in use, at the position of the
+	// entry they last read.
+	idle     []archiveReader
+	released bool
+	expected map[string]bool
+
+	// The spool file is removed as soon as it is created where open
+	// files can be removed, otherwise when the cursor is released.
+	spoolFile  *os.File
+	spoolName  string
+	spoolSize  int64
+	spoolLimit int64
+	spooled    map[string]spoolExtent
+}
+
+// archiveSpoolLimit is the maximum size of the entries of an archive
+// spooled to a temporary file.
+const archiveSpoolLimit = 256 << 20
+
+// spoolExtent locates the contents of an entry in the spool file.
+type spoolExtent struct {
+	offset, size int64
+}
+
+func newArchiveCursor(open func() (archiveReader, *probe.Error)) *archiveCursor {
+	return &archiveCursor{
+		open:       open,
+		expected:   make(map[string]bool),
+		spoolLimit: archiveSpoolLimit,
+		spooled:    make(map[string]spoolExtent),
+	}
+}
+
+// expect records the entries which are going to be read.
+func (a *archiveCursor) expect(names []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, name := range names {
+		if _, ok := a.spooled[name]; !ok {
+			a.expected[name] = true
+		}
+	}
+}
+
+// spoolWriter writes to the spool file from an offset.
+type spoolWriter struct {
+	f      *os.File
+	offset int64
+}
+
+func (w *spoolWriter) Write(b []byte) (int, error) {
+	n, e := w.f.WriteAt(b, w.offset)
+	w.offset += int64(n)
+	return n, e
+}
+
+// spool copies the contents of an entry passed over to the spool file if
+// the entry is expected and fits in the spool.
+func (a *archiveCursor) spool(entry archiveEntry, reader io.Reader) error {
+	a.mu.Lock()
+	if a.released || !a.expected[entry.name] || a.spoolSize+entry.size > a.spoolLimit {
+		a.mu.Unlock()
+		return nil
+	}
+	if a.spoolFile == nil {
+		f, e := ioutil.TempFile("", "mc-archive-")
+		if e != nil {
+			a.mu.Unlock()
+			return e
+		}
+		a.spoolFile = f
+		if os.Remove(f.Name()) != nil {
+			a.spoolName = f.Name()
+		}
+	}
+	delete(a.expected, entry.name)
+	// The extent is reserved, other passes spool concurrently.
+	extent := spoolExtent{offset: a.spoolSize, size: entry.size}
+	a.spoolSize += entry.size
+	f := a.spoolFile
+	a.mu.Unlock()
+
+	n, e := io.CopyN(&spoolWriter{f: f, offset: extent.offset}, reader, extent.size)
+	if e != nil || n != extent.size {
+		// The entry is read from the archive instead.
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.released {
+		a.spooled[entry.name] = extent
+	}
+	return nil
+}
+
+// get returns a reader of the contents of a file entry.
+func (a *archiveCursor) get(name string) (io.ReadCloser, *probe.Error) {
+	a.mu.Lock()
+	delete(a.expected, name)
+	if extent, ok := a.spooled[name]; ok {
+		delete(a.spooled, name)
+		f := a.spoolFile
+		a.mu.Unlock()
+		return ioutil.NopCloser(io.NewSectionReader(f, extent.offset, extent.size)), nil
+	}
+	var ar archiveReader
+	if n := len(a.idle); n > 0 {
+		ar, a.idle = a.idle[n-1], a.idle[:n-1]
+	}
+	a.mu.Unlock()
+
+	// The entry is searched from the current position, then once from
+	// the start of the archive if it was passed over.
+	for pass := 0; pass < 2; pass++ {
+		if ar == nil {
+			var err *probe.Error
+			if ar, err = a.open(); err != nil {
+				return nil, err.Trace(name)
+			}
+			pass++
+		}
+		for {
+			entry, reader, e := ar.next()
+			if e == io.EOF {
+				ar.Close()
+				ar = nil
+				break
+			}
+			if e != nil {
+				ar.Close()
+				return nil, probe.NewError(e).Trace(name)
+			}
+			if entry.isDir() {
+				continue
+			}
+			if entry.name == name {
+				return &cursorEntryReader{Reader: reader, cursor: a, ar: ar}, nil
+			}
+			if e = a.spool(entry, reader); e != nil {
+				ar.Close()
+				return nil, probe.NewError(e).Trace(entry.name)
+			}
+		}
+	}
+	return nil, probe.NewError(PathNotFound{Path: name})
+}
+
+// put returns a pass over the archive which is no longer in use.
+func (a *archiveCursor) put(ar archiveReader) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.released {
+		ar.Close()
+		return
+	}
+	a.idle = append(a.idle, ar)
+}
+
+// release closes the archive and removes the spool file, the entries
+// being read from the archive are closed once their readers are closed.
+func (a *archiveCursor) release() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.released = true
+	for _, ar := range a.idle {
+		ar.Close()
+	}
+	a.idle = nil
+	if a.spoolFile != nil {
+		a.spoolFile.Close()
+		if a.spoolName != "" {
+			os.Remove(a.spoolName)
+		}
+		a.spoolFile = nil
+	}
+	a.spooled = make(map[string]spoolExtent)
+	a.expected = make(map[string]bool)
+}
+
+// cursorEntryReader reads the contents of an entry from a pass over the
+// archive and gives the pass back to the cursor when closed.
+type cursorEntryReader struct {
+	io.Reader
+	cursor *archiveCursor
+	ar     archiveReader
+}
+
+func (r *cursorEntryReader) Close() error {
+	if r.ar != nil {
+		r.cursor.put(r.ar)
+		r.ar = nil
+	}
+	return nil
+}
+
+// archive client, read-only access to the entries of a tar or zip archive
+// stored on any other client.
+type archiveClient struct {
+	archive Client
+	format  string
+	entry   string
+	URL     ClientURL
+}
+
+// archiveNew - instantiate a new client for an entry in an archive.
+func archiveNew(alias, urlStr string) (Client, *probe.Error) {
+	archiveURL, entry, ok := splitArchiveURL(urlStr)
+	if !ok {
+		return nil, probe.NewError(errors.New("`" + urlStr + "` is not an archive URL"))
+	}
+	archive, err := newClientFromAlias(alias, archiveURL)
+	if err != nil {
+		return nil, err.Trace(alias, archiveURL)
+	}
+	return newArchiveClient(archive, entry), nil
+}
+
+func newArchiveClient(archive Client, entry string) *archiveClient {
+	u := archive.GetURL()
+	u.Path += archiveSeparator + "/" + entry
+	return &archiveClient{
+		archive: archive,
+		format:  archiveFormat(archive.GetURL().Path),
+		entry:   entry,
+		URL:     u,
+	}
+}
+
+// entryURL returns the URL of an entry of the archive.
+func (c *archiveClient) entryURL(name string) ClientURL {
+	u := c.archive.GetURL()
+	u.Path += archiveSeparator + "/" + name
+	return u
+}
+
+// open opens the archive for reading its entries in order.
+func (c *archiveClient) open(ctx context.Context, sse encrypt.ServerSide) (archiveReader, *probe.Error) {
+	reader, err := c.archive.Get(ctx, sse)
+	if err != nil {
+		return nil, err.Trace(c.archive.GetURL().String())
+	}
+
+	switch c.format {
+	case archiveFormatZip:
+		readerAt, ok := reader.(io.ReaderAt)
+		if !ok {
+			reader.Close()
+			return nil, probe.NewError(errors.New("zip archive does not support random access")).Trace(c.archive.GetURL().String())
+		}
+		st, err := c.archive.Stat(ctx, false, false, sse)
+		if err != nil {
+			reader.Close()
+			return nil, err.Trace(c.archive.GetURL().String())
+		}
+		zr, e := zip.NewReader(readerAt, st.Size)
+		if e != nil {
+			reader.Close()
+			return nil, probe.NewError(e).Trace(c.archive.GetURL().String())
+		}
+		return &zipArchiveReader{files: zr.File, closer: reader}, nil
+	case archiveFormatTarGz:
+		gz, e := gzip.NewReader(reader)
+		if e != nil {
+			reader.Close()
+			return nil, probe.NewError(e).Trace(c.archive.GetURL().String())
+		}
+		return &tarArchiveReader{tr: tar.NewReader(gz), closers: []io.Closer{reader, gz}}, nil
+	case archiveFormatTarZst:
+		zr, e := zstd.NewReader(reader)
+		if e != nil {
+			reader.Close()
+			return nil, probe.NewError(e).Trace(c.archive.GetURL().String())
+		}
+		return &tarArchiveReader{tr: tar.NewReader(zr), closers: []io.Closer{reader, zstdDecoderCloser{zr}}}, nil
+	default:
+		return &tarArchiveReader{tr: tar.NewReader(reader), closers: []io.Closer{reader}}, nil
+	}
+}
+
+// index returns the index of the archive, its entries sorted by name
+// include the directories which are only implied by the path of the files.
+func (c *archiveClient) index(ctx context.Context, sse encrypt.ServerSide) (*archiveIndex, *probe.Error) {
+	st, err := c.archive.Stat(ctx, false, false, sse)
+	if err != nil {
+		return nil, err.Trace(c.archive.GetURL().String())
+	}
+	if st.Type.IsDir() {
+		return nil, probe.NewError(PathIsNotRegular{Path: c.archive.GetURL().String()})
+	}
+
+	key := c.archive.GetURL().String()
+	if idx := cachedArchiveIndex(key, st); idx != nil {
+		return idx, nil
+	}
+
+	ar, err := c.open(ctx, sse)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	defer ar.Close()
+
+	entries := make(map[string]archiveEntry)
+	for {
+		entry, _, e := ar.next()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, probe.NewError(e).Trace(key)
+		}
+		if entry.name == "" {
+			continue
+		}
+		entries[entry.name] = entry
+		// Add the parent directories missing in the archive.
+		for dir := path.Dir(entry.name); dir != "."; dir = path.Dir(dir) {
+			if _, ok := entries[dir]; ok {
+				break
+			}
+			entries[dir] = archiveEntry{name: dir, modTime: entry.modTime, mode: os.ModeDir | 0755}
+		}
+	}
+
+	idx := &archiveIndex{etag: st.ETag, size: st.Size, modTime: st.Time}
+	for _, entry := range entries {
+		idx.entries = append(idx.entries, entry)
+	}
+	sort.Slice(idx.entries, func(i, j int) bool {
+		return idx.entries[i].name < idx.entries[j].name
+	})
+	// The cursor outlives the context of this call.
+	idx.cursor = newArchiveCursor(func() (archiveReader, *probe.Error) {
+		return c.open(context.Background(), sse)
+	})
+
+	cacheArchiveIndex(key, idx)
+	return idx, nil
+}
+
+// toClientContent converts an entry into a client content.
+func (c *archiveClient) toClientContent(entry archiveEntry) *ClientContent {
+	content := &ClientContent{
+		URL:  c.entryURL(entry.name),
+		Time: entry.modTime,
+		Type: entry.mode,
+	}
+	if entry.isDir() {
+		content.URL.Path += "/"
+		content.Type = os.ModeDir
+		return content
+	}
+	content.Size = entry.size
+	content.Metadata = map[string]string{
+		"Content-Type": guessURLContentType(entry.name),
+	}
+	return content
+}
+
+// GetURL get url.
+func (c *archiveClient) GetURL() ClientURL {
+	return c.URL.Clone()
+}
+
+// AddUserAgent - add custom user agent to the archive client.
+func (c *archiveClient) AddUserAgent(app, version string) {
+	c.archive.AddUserAgent(app, version)
+}
+
+// Stat - get metadata of an entry, the root of the archive and path
+// prefixes of entries are directories.
+func (c *archiveClient) Stat(ctx context.Context, isIncomplete, isPreserve bool, sse encrypt.ServerSide) (*ClientContent, *probe.Error) {
+	if isIncomplete {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "StatIncomplete",
+			APIType: "archive",
+		})
+	}
+	idx, err := c.index(ctx, sse)
+	if err != nil {
+		return nil, err.Trace(c.URL.String())
+	}
+	entries := idx.entries
+
+	name := strings.TrimSuffix(c.entry, "/")
+	if name == "" {
+		return &ClientContent{URL: c.GetURL(), Type: os.ModeDir}, nil
+	}
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].name >= name })
+	if i < len(entries) && entries[i].name == name {
+		if !entries[i].isDir() && strings.HasSuffix(c.entry, "/") {
+			return nil, probe.NewError(PathNotFound{Path: c.URL.String()})
+		}
+		content := c.toClientContent(entries[i])
+		content.URL = c.GetURL()
+		return content, nil
+	}
+	return nil, probe.NewError(PathNotFound{Path: c.URL.String()})
+}
+
+// List - list the entries of the archive under the entry of the client.
+func (c *archiveClient) List(ctx context.Context, isRecursive, isIncomplete, isFetchMeta bool, showDir DirOpt) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent)
+	go func() {
+		defer close(contentCh)
+		if isIncomplete {
+			return
+		}
+		idx, err := c.index(ctx, nil)
+		if err != nil {
+			contentCh <- &ClientContent{Err: err.Trace(c.URL.String())}
+			return
+		}
+		entries := idx.entries
+
+		send := func(content *ClientContent) bool {
+			select {
+			case contentCh <- content:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		prefix := c.entry
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			// An entry which is a file is listed on its own.
+			for _, entry := range entries {
+				if entry.name == prefix && !entry.isDir() {
+					send(c.toClientContent(entry))
+					return
+				}
+			}
+			prefix += "/"
+		}
+
+		var listed, dirs []archiveEntry
+		var files []string
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.name, prefix) || entry.name+"/" == prefix {
+				continue
+			}
+			rel := strings.TrimPrefix(entry.name, prefix)
+			if !isRecursive {
+				// Only list the entries immediately under prefix.
+				if strings.Contains(rel, "/") {
+					continue
+				}
+			} else if entry.isDir() {
+				switch showDir {
+				case DirNone:
+					continue
+				case DirLast:
+					dirs = append(dirs, entry)
+					continue
+				}
+			} else {
+				files = append(files, entry.name)
+			}
+			listed = append(listed, entry)
+		}
+		// The files listed recursively are expected to be read in a
+		// single pass, as done by a recursive copy.
+		idx.cursor.expect(files)
+
+		for _, entry := range listed {
+			if !send(c.toClientContent(entry)) {
+				return
+			}
+		}
+		// Directories are listed after their contents, deepest first.
+		for i := len(dirs) - 1; i >= 0; i-- {
+			if !send(c.toClientContent(dirs[i])) {
+				return
+			}
+		}
+	}()
+	return contentCh
+}
+
+// Get - read the contents of a file entry, the entries of an archive are
+// read through its cursor.
+func (c *archiveClient) Get(ctx context.Context, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	idx, err := c.index(ctx, sse)
+	if err != nil {
+		return nil, err.Trace(c.URL.String())
+	}
+	i := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].name >= c.entry })
+	if i == len(idx.entries) || idx.entries[i].name != c.entry || idx.entries[i].isDir() {
+		return nil, probe.NewError(PathNotFound{Path: c.URL.String()})
+	}
+	reader, err := idx.cursor.get(c.entry)
+	if err != nil {
+		return nil, err.Trace(c.URL.String())
+	}
+	return reader, nil
+}
+
+// archiveNotImplemented returns the error of operations which are not
+// supported by archives, they are read-only.
+func archiveNotImplemented(api string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     api,
+		APIType: "archive",
+	})
+}
+
+// Put - not implemented, archives are read-only.
+func (c *archiveClient) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide, md5, disableMultipart bool) (int64, *probe.Error) {
+	return 0, archiveNotImplemented("Put")
+}
+
+// Copy - not implemented, archives are read-only.
+func (c *archiveClient) Copy(ctx context.Context, source string, size int64, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide, metadata map[string]string, disableMultipart bool) *probe.Error {
+	return archiveNotImplemented("Copy")
+}
+
+// Select - not implemented.
+func (c *archiveClient) Select(ctx context.Context, expression string, sse encrypt.ServerSide, opts SelectObjectOpts) (io.ReadCloser, *probe.Error) {
+	return nil, archiveNotImplemented("Select")
+}
+
+// MakeBucket - not implemented, archives are read-only.
+func (c *archiveClient) MakeBucket(ctx context.Context, region string, ignoreExisting, withLock bool) *probe.Error {
+	return archiveNotImplemented("MakeBucket")
+}
+
+// SetObjectLockConfig - not implemented.
+func (c *archiveClient) SetObjectLockConfig(ctx context.Context, mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit) *probe.Error {
+	return archiveNotImplemented("SetObjectLockConfig")
+}
+
+// GetObjectLockConfig - not implemented.
+func (c *archiveClient) GetObjectLockConfig(ctx context.Context) (minio.RetentionMode, uint64, minio.ValidityUnit, *probe.Error) {
+	return "", 0, "", archiveNotImplemented("GetObjectLockConfig")
+}
+
+// GetAccess - not implemented.
+func (c *archiveClient) GetAccess(ctx context.Context) (string, string, *probe.Error) {
+	return "", "", archiveNotImplemented("GetAccess")
+}
+
+// GetAccessRules - not implemented.
+func (c *archiveClient) GetAccessRules(ctx context.Context) (map[string]string, *probe.Error) {
+	return nil, archiveNotImplemented("GetAccessRules")
+}
+
+// SetAccess - not implemented.
+func (c *archiveClient) SetAccess(ctx context.Context, access string, isJSON bool) *probe.Error {
+	return archiveNotImplemented("SetAccess")
+}
+
+// PutObjectRetention - not implemented.
+func (c *archiveClient) PutObjectRetention(ctx context.Context, mode minio.RetentionMode, retainUntilDate time.Time, bypassGovernance bool) *probe.Error {
+	return archiveNotImplemented("PutObjectRetention")
+}
+
+// PutObjectLegalHold - not implemented.
+func (c *archiveClient) PutObjectLegalHold(ctx context.Context, hold minio.LegalHoldStatus) *probe.Error {
+	return archiveNotImplemented("PutObjectLegalHold")
+}
+
+// ShareDownload - not implemented.
+func (c *archiveClient) ShareDownload(ctx context.Context, expires time.Duration) (string, *probe.Error) {
+	return "", archiveNotImplemented("ShareDownload")
+}
+
+// ShareUpload - not implemented.
+func (c *archiveClient) ShareUpload(startsWith bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+	return "", nil, archiveNotImplemented("ShareUpload")
+}
+
+// Watch - not implemented, archives are never modified in place.
+func (c *archiveClient) Watch(ctx context.Context, options WatchOptions) (*WatchObject, *probe.Error) {
+	return nil, archiveNotImplemented("Watch")
+}
+
+// Remove - not implemented, archives are read-only.
+func (c *archiveClient) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isBypass bool, contentCh <-chan *ClientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+	go func() {
+		defer close(errorCh)
+		for range contentCh {
+			errorCh <- archiveNotImplemented("Remove")
+		}
+	}()
+	return errorCh
+}
+
+// GetTags - not implemented.
+func (c *archiveClient) GetTags(ctx context.Context) (*tags.Tags, *probe.Error) {
+	return nil, archiveNotImplemented("GetTags")
+}
+
+// SetTags - not implemented.
+func (c *archiveClient) SetTags(ctx context.Context, tags string) *probe.Error {
+	return archiveNotImplemented("SetTags")
+}
+
+// DeleteTags - not implemented.
+func (c *archiveClient) DeleteTags(ctx context.Context) *probe.Error {
+	return archiveNotImplemented("DeleteTags")
+}
+
+// GetLifecycle - not implemented.
+func (c *archiveClient) GetLifecycle(ctx context.Context) (ilm.LifecycleConfiguration, *probe.Error) {
+	return ilm.LifecycleConfiguration{}, archiveNotImplemented("GetLifecycle")
+}
+
+// SetLifecycle - not implemented.
+func (c *archiveClient) SetLifecycle(ctx context.Context, _ ilm.LifecycleConfiguration) *probe.Error {
+	return archiveNotImplemented("SetLifecycle")
+}
+
+// GetVersioning - not implemented.
+func (c *archiveClient) GetVersioning(ctx context.Context) (string, *probe.Error) {
+	return "", archiveNotImplemented("GetVersioning")
+}
+
+// SetVersioning - not implemented.
+func (c *archiveClient) SetVersioning(ctx context.Context, status string) *probe.Error {
+	return archiveNotImplemented("SetVersioning")
+}
+
+// ListVersions - not implemented.
+func (c *archiveClient) ListVersions(ctx context.Context, isRecursive bool, timeRef time.Time, withOlderVersions bool) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- &ClientContent{Err: archiveNotImplemented("ListVersions")}
+	close(contentCh)
+	return contentCh
+}
+
+// StatVersion - not implemented.
+func (c *archiveClient) StatVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ClientContent, *probe.Error) {
+	return nil, archiveNotImplemented("StatVersion")
+}
+
+// GetVersion - not implemented.
+func (c *archiveClient) GetVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	return nil, archiveNotImplemented("GetVersion")
+}
+
+// RemoveVersions - not implemented, archives are read-only.
+func (c *archiveClient) RemoveVersions(ctx context.Context, isBypass bool, contentCh <-chan *ClientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+	go func() {
+		defer close(errorCh)
+		for range contentCh {
+			errorCh <- archiveNotImplemented("RemoveVersions")
+		}
+	}()
+	return errorCh
+}
+
+// GetReplication - not implemented.
+func (c *archiveClient) GetReplication(ctx context.Context) (replication.Config, *probe.Error) {
+	return replication.Config{}, archiveNotImplemented("GetReplication")
+}
+
+// SetReplication - not implemented.
+func (c *archiveClient) SetReplication(ctx context.Context, cfg replication.Config) *probe.Error {
+	return archiveNotImplemented("SetReplication")
+}
+
+// RemoveReplication - not implemented.
+func (c *archiveClient) RemoveReplication(ctx context.Context) *probe.Error {
+	return archiveNotImplemented("RemoveReplication")
+}
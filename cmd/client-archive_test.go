@@ -0,0 +1,291 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	. "gopkg.in/check.v1"
+)
+
+var testArchiveFiles = map[string]string{
+	"dir/a.txt":     "hello",
+	"dir/sub/b.txt": "world",
+	"c.txt":         "!",
+}
+
+func writeTestTarGz(c *C, name string) {
+	f, e := os.Create(name)
+	c.Assert(e, IsNil)
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, data := range testArchiveFiles {
+		c.Assert(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}), IsNil)
+		_, e = tw.Write([]byte(data))
+		c.Assert(e, IsNil)
+	}
+	c.Assert(tw.Close(), IsNil)
+	c.Assert(gz.Close(), IsNil)
+}
+
+func writeTestZip(c *C, name string) {
+	f, e := os.Create(name)
+	c.Assert(e, IsNil)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, data := range testArchiveFiles {
+		w, e := zw.Create(name)
+		c.Assert(e, IsNil)
+		_, e = w.Write([]byte(data))
+		c.Assert(e, IsNil)
+	}
+	c.Assert(zw.Close(), IsNil)
+}
+
+func (s *TestSuite) TestSplitArchiveURL(c *C) {
+	testCases := []struct {
+		urlStr     string
+		archiveURL string
+		entry      string
+		ok         bool
+	}{
+		{"s3/drops/batch.tar.gz#path/in/archive", "s3/drops/batch.tar.gz", "path/in/archive", true},
+		{"s3/drops/batch.ZIP#/path/", "s3/drops/batch.ZIP", "path/", true},
+		{"s3/drops/batch.tgz#", "s3/drops/batch.tgz", "", true},
+		{"s3/drops/a#b/batch.tar#c", "s3/drops/a#b/batch.tar", "c", true},
+		{"s3/drops/batch#1.txt", "s3/drops/batch#1.txt", "", false},
+		{"s3/drops/batch.tar.gz", "s3/drops/batch.tar.gz", "", false},
+	}
+	for _, testCase := range testCases {
+		archiveURL, entry, ok := splitArchiveURL(testCase.urlStr)
+		c.Assert(archiveURL, Equals, testCase.archiveURL)
+		c.Assert(entry, Equals, testCase.entry)
+		c.Assert(ok, Equals, testCase.ok)
+	}
+}
+
+func (s *TestSuite) TestArchiveClient(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "archive-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	tarGz := filepath.Join(root, "batch.tar.gz")
+	writeTestTarGz(c, tarGz)
+	zipFile := filepath.Join(root, "batch.zip")
+	writeTestZip(c, zipFile)
+
+	for _, archive := range []string{tarGz, zipFile} {
+		fsClient, err := fsNew(archive)
+		c.Assert(err, IsNil)
+		clnt := newArchiveClient(fsClient, "")
+
+		// Recursive listing of the files, sorted.
+		var names []string
+		for content := range clnt.List(context.Background(), true, false, false, DirNone) {
+			c.Assert(content.Err, IsNil)
+			names = append(names, strings.TrimPrefix(content.URL.Path, archive+"#/"))
+		}
+		c.Assert(names, DeepEquals, []string{"c.txt", "dir/a.txt", "dir/sub/b.txt"})
+
+		// Listing of a directory.
+		clnt = newArchiveClient(fsClient, "dir")
+		names = nil
+		for content := range clnt.List(context.Background(), false, false, false, DirNone) {
+			c.Assert(content.Err, IsNil)
+			names = append(names, strings.TrimPrefix(content.URL.Path, archive+"#/"))
+		}
+		c.Assert(names, DeepEquals, []string{"dir/a.txt", "dir/sub/"})
+
+		st, err := clnt.Stat(context.Background(), false, false, nil)
+		c.Assert(err, IsNil)
+		c.Assert(st.Type.IsDir(), Equals, true)
+
+		// Stat and read a file.
+		clnt = newArchiveClient(fsClient, "dir/sub/b.txt")
+		st, err = clnt.Stat(context.Background(), false, false, nil)
+		c.Assert(err, IsNil)
+		c.Assert(st.Size, Equals, int64(len("world")))
+		reader, err := clnt.Get(context.Background(), nil)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		c.Assert(reader.Close(), IsNil)
+		c.Assert(string(data), Equals, "world")
+
+		// Missing entry.
+		clnt = newArchiveClient(fsClient, "missing.txt")
+		_, err = clnt.Stat(context.Background(), false, false, nil)
+		c.Assert(err, NotNil)
+
+		// Archives are read-only.
+		_, err = clnt.Put(context.Background(), strings.NewReader("x"), 1, nil, nil, nil, false, false)
+		c.Assert(err, NotNil)
+	}
+}
+
+func (s *TestSuite) TestArchiveURLRequiresFlag(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "archive-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	// Plain files whose names contain '#', after an archive extension or not.
+	for _, name := range []string{"report#1.txt", "data.zip#1.txt"} {
+		c.Assert(ioutil.WriteFile(filepath.Join(root, name), []byte(name), 0644), IsNil)
+
+		urlStr := filepath.Join(root, name)
+		c.Assert(isArchiveURL(urlStr), Equals, false)
+		clnt, err := newClientFromAlias("", urlStr)
+		c.Assert(err, IsNil)
+		_, ok := clnt.(*fsClient)
+		c.Assert(ok, Equals, true)
+		reader, err := clnt.Get(context.Background(), nil)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		reader.Close()
+		c.Assert(string(data), Equals, name)
+	}
+
+	globalArchive = true
+	defer func() { globalArchive = false }()
+	clnt, err := newClientFromAlias("", filepath.Join(root, "data.zip#1.txt"))
+	c.Assert(err, IsNil)
+	_, ok := clnt.(*archiveClient)
+	c.Assert(ok, Equals, true)
+}
+
+func (s *TestSuite) TestArchiveCursor(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "archive-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	tarGz := filepath.Join(root, "batch.tar.gz")
+	writeTestTarGz(c, tarGz)
+	fsClient, err := fsNew(tarGz)
+	c.Assert(err, IsNil)
+	clnt := newArchiveClient(fsClient, "")
+
+	opens := 0
+	cursor := newArchiveCursor(func() (archiveReader, *probe.Error) {
+		opens++
+		return clnt.open(context.Background(), nil)
+	})
+	defer cursor.release()
+
+	var names []string
+	for name := range testArchiveFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	cursor.expect(names)
+
+	read := func(name string) {
+		reader, err := cursor.get(name)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		c.Assert(reader.Close(), IsNil)
+		c.Assert(string(data), Equals, testArchiveFiles[name])
+	}
+
+	// The expected entries are read in a single pass, whatever the order
+	// of the archive.
+	for _, name := range names {
+		read(name)
+	}
+	c.Assert(opens, Equals, 1)
+
+	// An entry read again is searched from the start of the archive.
+	read(names[0])
+	c.Assert(opens, Equals, 2)
+
+	_, err = cursor.get("missing.txt")
+	c.Assert(err, NotNil)
+
+	// An entry is read while another one is open.
+	first, err := cursor.get(names[0])
+	c.Assert(err, IsNil)
+	read(names[1])
+	c.Assert(first.Close(), IsNil)
+}
+
+func (s *TestSuite) TestArchiveCursorSpoolLimit(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "archive-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	tarGz := filepath.Join(root, "batch.tar.gz")
+	writeTestTarGz(c, tarGz)
+	fsClient, err := fsNew(tarGz)
+	c.Assert(err, IsNil)
+	clnt := newArchiveClient(fsClient, "")
+
+	cursor := newArchiveCursor(func() (archiveReader, *probe.Error) {
+		return clnt.open(context.Background(), nil)
+	})
+	defer cursor.release()
+	// Nothing fits in the spool, entries passed over are read again
+	// from the start of the archive.
+	cursor.spoolLimit = 0
+
+	var names []string
+	for name := range testArchiveFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	cursor.expect(names)
+
+	for _, name := range names {
+		reader, err := cursor.get(name)
+		c.Assert(err, IsNil)
+		data, e := ioutil.ReadAll(reader)
+		c.Assert(e, IsNil)
+		c.Assert(reader.Close(), IsNil)
+		c.Assert(string(data), Equals, testArchiveFiles[name])
+	}
+	c.Assert(cursor.spoolSize, Equals, int64(0))
+	c.Assert(cursor.spoolFile, IsNil)
+}
+
+func (s *TestSuite) TestArchiveIndexCacheBound(c *C) {
+	defer func() {
+		archiveIndexMu.Lock()
+		archiveIndexCache = make(map[string]*archiveIndex)
+		archiveIndexMu.Unlock()
+	}()
+
+	st := &ClientContent{ETag: "etag"}
+	for i := 0; i <= archiveIndexCacheSize; i++ {
+		cacheArchiveIndex(string(rune('a'+i)), &archiveIndex{etag: st.ETag})
+		// Keep the first archive in use.
+		c.Assert(cachedArchiveIndex("a", st), NotNil)
+	}
+	archiveIndexMu.Lock()
+	c.Assert(len(archiveIndexCache), Equals, archiveIndexCacheSize)
+	archiveIndexMu.Unlock()
+	c.Assert(cachedArchiveIndex("b", st), IsNil)
+}
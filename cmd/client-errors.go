@@ -49,6 +49,13 @@ func (e BucketExists) Error() string {
 	return "Bucket `" + e.Bucket + "` exists."
 }
 
+// ObjectLockConfigNotFound - no object lock configuration set on the bucket.
+type ObjectLockConfigNotFound GenericBucketError
+
+func (e ObjectLockConfigNotFound) Error() string {
+	return "No object lock configuration set on " + e.Bucket
+}
+
 // BucketNameEmpty - bucket name empty (http://goo.gl/wJlzDz)
 type BucketNameEmpty struct{}
 
@@ -160,6 +167,15 @@ func (e ObjectMissing) Error() string {
 	return "Object does not exist"
 }
 
+// ObjectVersionMissing - object version does not exist.
+type ObjectVersionMissing struct {
+	VersionID string
+}
+
+func (e ObjectVersionMissing) Error() string {
+	return "Object version `" + e.VersionID + "` does not exist."
+}
+
 // UnexpectedShortWrite - write wrote less bytes than expected.
 type UnexpectedShortWrite struct {
 	InputSize int
@@ -198,3 +214,56 @@ type SameFile struct {
 func (e SameFile) Error() string {
 	return fmt.Sprintf("'%s' and '%s' are the same file", e.Source, e.Destination)
 }
+
+// ChecksumMismatch - copied data does not match the source checksum.
+type ChecksumMismatch struct {
+	Algorithm       string
+	Source, Target  string
+	Expected, Found string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return fmt.Sprintf("%s checksum of `%s` is `%s`, but `%s` was copied with checksum `%s`.", e.Algorithm, e.Target, e.Found, e.Source, e.Expected)
+}
+
+// WatchEventsDropped - watch events were dropped or lost.
+type WatchEventsDropped struct {
+	Count    int
+	Overflow bool
+}
+
+func (e WatchEventsDropped) Error() string {
+	if e.Overflow {
+		return "Watch event buffer overflowed, some events may have been lost"
+	}
+	return fmt.Sprintf("%d watched file(s) disappeared before they were stable, their events were dropped", e.Count)
+}
+
+// UnsafeObjectKey - object key resolves outside of the target directory.
+type UnsafeObjectKey struct {
+	Key string
+}
+
+func (e UnsafeObjectKey) Error() string {
+	return "Object key `" + e.Key + "` resolves outside of the target directory."
+}
+
+// UnsafeSymlink - preserved symlink resolves outside of the target directory.
+type UnsafeSymlink struct {
+	Path   string
+	Target string
+}
+
+func (e UnsafeSymlink) Error() string {
+	return "Symlink `" + e.Path + "` to `" + e.Target + "` resolves outside of the target directory."
+}
+
+// SymlinkWrite - write through a symlink restored by the same command.
+type SymlinkWrite struct {
+	Path string
+	Link string
+}
+
+func (e SymlinkWrite) Error() string {
+	return "Unable to write `" + e.Path + "` through the restored symlink `" + e.Link + "`."
+}
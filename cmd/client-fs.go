@@ -20,11 +20,11 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
-	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -34,6 +34,7 @@ import (
 	"github.com/rjeczalik/notify"
 
 	"github.com/minio/mc/cmd/ilm"
+	"github.com/minio/mc/cmd/replication"
 	"github.com/minio/mc/pkg/disk"
 	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/ioutils"
@@ -52,6 +53,9 @@ const (
 	partSuffix     = ".part.minio"
 	slashSeperator = "/"
 	metaDataKey    = "X-Amz-Meta-Mc-Attrs"
+
+	// symlinkMetaDataKey holds the target of a preserved symlink.
+	symlinkMetaDataKey = "X-Amz-Meta-Mc-Symlink-Target"
 )
 
 var ( // GOOS specific ignore list.
@@ -126,14 +130,26 @@ func (f *fsClient) Select(ctx context.Context, expression string, sse encrypt.Se
 	})
 }
 
+// timeFormatFS is the format of the time of filesystem events.
+const timeFormatFS = "2006-01-02T15:04:05.000Z"
+
 // Watches for all fs events on an input path.
 func (f *fsClient) Watch(ctx context.Context, options WatchOptions) (*WatchObject, *probe.Error) {
+	if options.PollInterval > 0 {
+		return pollWatch(ctx, f, options)
+	}
+
 	eventChan := make(chan []EventInfo)
 	errorChan := make(chan *probe.Error)
 	doneChan := make(chan struct{})
 	// Make the channel buffered to ensure no event is dropped. Notify will drop
 	// an event if the receiver is not able to keep up the sending pace.
-	in, out := PipeChan(1000)
+	var in, out chan notify.EventInfo
+	if options.Debounce > 0 {
+		in = make(chan notify.EventInfo, 1000)
+	} else {
+		in, out = PipeChan(1000)
+	}
 
 	var fsEvents []notify.Event
 	for _, event := range options.Events {
@@ -159,6 +175,15 @@ func (f *fsClient) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 		return nil, probe.NewError(e)
 	}
 
+	if options.Debounce > 0 {
+		go debounceFSEvents(in, options.Debounce, eventChan, errorChan, doneChan)
+		return &WatchObject{
+			EventInfoChan: eventChan,
+			ErrorChan:     errorChan,
+			DoneChan:      doneChan,
+		}, nil
+	}
+
 	// wait for doneChan to close the watcher, eventChan and errorChan
 	go func() {
 		<-doneChan
@@ -168,8 +193,6 @@ func (f *fsClient) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 		notify.Stop(in)
 	}()
 
-	timeFormatFS := "2006-01-02T15:04:05.000Z"
-
 	// Get fsnotify notifications for events and errors, and sent them
 	// using eventChan and errorChan
 	go func() {
@@ -253,10 +276,25 @@ func preserveAttributes(fd *os.File, attr map[string]string) *probe.Error {
 
 /// Object operations.
 
+// checkSymlinkWrite refuses to write at the path of the client through
+// a symlink restored by the same command.
+func (f *fsClient) checkSymlinkWrite(ctx context.Context) *probe.Error {
+	absPath, e := filepath.Abs(f.PathURL.Path)
+	if e != nil {
+		return nil
+	}
+	return contextSymlinkScope(ctx).checkWrite(absPath)
+}
+
 func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, metadata map[string][]string, progress io.Reader) (int64, *probe.Error) {
 	// ContentType is not handled on purpose.
 	// For filesystem this is a redundant information.
 
+	// Never write through a symlink restored by the same command.
+	if err := f.checkSymlinkWrite(ctx); err != nil {
+		return 0, err.Trace(f.PathURL.Path)
+	}
+
 	// Extract dir name.
 	objectDir, objectName := filepath.Split(f.PathURL.Path)
 
@@ -369,6 +407,9 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, metada
 
 // Put - create a new file with metadata.
 func (f *fsClient) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide, md5, disableMultipart bool) (int64, *probe.Error) {
+	if target := metadata[symlinkMetaDataKey]; target != "" && contextSymlinkPolicy(ctx) == symlinkPreserve {
+		return 0, f.putSymlink(ctx, reader, target)
+	}
 	if metadata[metaDataKey] != "" {
 		meta := make(map[string][]string)
 		meta[metaDataKey] = append(meta[metaDataKey], metadata[metaDataKey])
@@ -377,6 +418,41 @@ func (f *fsClient) Put(ctx context.Context, reader io.Reader, size int64, metada
 	return f.put(ctx, reader, size, nil, progress)
 }
 
+// putSymlink - recreate a preserved symlink pointing to target.
+func (f *fsClient) putSymlink(ctx context.Context, reader io.Reader, target string) *probe.Error {
+	// Preserved symlinks have no content, close the input reader as well.
+	if closer, ok := reader.(io.Closer); ok {
+		closer.Close()
+	}
+
+	objectPath, e := filepath.Abs(f.PathURL.Path)
+	if e != nil {
+		return probe.NewError(e).Trace(f.PathURL.Path)
+	}
+	// Only links resolving below the target of the command are restored.
+	scope := contextSymlinkScope(ctx)
+	if err := scope.checkRestore(objectPath, target); err != nil {
+		return err.Trace(objectPath, target)
+	}
+	if e := os.MkdirAll(filepath.Dir(objectPath), 0777); e != nil {
+		err := f.toClientError(e, objectPath)
+		return err.Trace(objectPath)
+	}
+	// Replace any previous file or symlink, never a folder.
+	if st, e := os.Lstat(objectPath); e == nil && !st.IsDir() {
+		if e = os.Remove(objectPath); e != nil {
+			err := f.toClientError(e, objectPath)
+			return err.Trace(objectPath)
+		}
+	}
+	if e := os.Symlink(target, objectPath); e != nil {
+		err := f.toClientError(e, objectPath)
+		return err.Trace(objectPath, target)
+	}
+	scope.restored(objectPath)
+	return nil
+}
+
 // ShareDownload - share download not implemented for filesystem.
 func (f *fsClient) ShareDownload(ctx context.Context, expires time.Duration) (string, *probe.Error) {
 	return "", probe.NewError(APINotImplemented{
@@ -395,6 +471,15 @@ func (f *fsClient) ShareUpload(startsWith bool, expires time.Duration, contentTy
 
 // Copy - copy data from source to destination
 func (f *fsClient) Copy(ctx context.Context, source string, size int64, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide, metadata map[string]string, disableMultipart bool) *probe.Error {
+	// Never write through a symlink restored by the same command.
+	if err := f.checkSymlinkWrite(ctx); err != nil {
+		return err.Trace(f.PathURL.Path, source)
+	}
+	if contextSymlinkPolicy(ctx) == symlinkPreserve {
+		if target, e := os.Readlink(source); e == nil {
+			return f.putSymlink(ctx, nil, target).Trace(source)
+		}
+	}
 	rc, e := os.Open(source)
 	if e != nil {
 		err := f.toClientError(e, source)
@@ -411,6 +496,12 @@ func (f *fsClient) Copy(ctx context.Context, source string, size int64, progress
 
 // Get returns reader and any additional metadata.
 func (f *fsClient) Get(ctx context.Context, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	// Preserved symlinks are read as empty objects.
+	if contextSymlinkPolicy(ctx) == symlinkPreserve {
+		if st, e := os.Lstat(f.PathURL.Path); e == nil && st.Mode()&os.ModeSymlink == os.ModeSymlink {
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		}
+	}
 	fileData, e := os.Open(f.PathURL.Path)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
@@ -507,14 +598,15 @@ func (f *fsClient) List(ctx context.Context, isRecursive, isIncomplete, isMetada
 	contentCh := make(chan *ClientContent)
 	filteredCh := make(chan *ClientContent)
 
+	policy := contextSymlinkPolicy(ctx)
 	if isRecursive {
 		if showDir == DirNone {
-			go f.listRecursiveInRoutine(contentCh, isMetadata)
+			go f.listRecursiveInRoutine(contentCh, isMetadata, policy)
 		} else {
-			go f.listDirOpt(contentCh, isIncomplete, isMetadata, showDir)
+			go f.listDirOpt(contentCh, isIncomplete, isMetadata, showDir, policy)
 		}
 	} else {
-		go f.listInRoutine(contentCh, isMetadata)
+		go f.listInRoutine(contentCh, isMetadata, policy)
 	}
 
 	// This function filters entries from any  listing go routine
@@ -542,47 +634,11 @@ func (f *fsClient) List(ctx context.Context, isRecursive, isIncomplete, isMetada
 	return filteredCh
 }
 
-// byDirName implements sort.Interface.
-type byDirName []os.FileInfo
-
-func (f byDirName) Len() int { return len(f) }
-func (f byDirName) Less(i, j int) bool {
-	// For directory add an ending separator fortrue lexical
-	// order.
-	if f[i].Mode().IsDir() {
-		return f[i].Name()+string(filepath.Separator) < f[j].Name()
-	}
-	// For directory add an ending separator for true lexical
-	// order.
-	if f[j].Mode().IsDir() {
-		return f[i].Name() < f[j].Name()+string(filepath.Separator)
-	}
-	return f[i].Name() < f[j].Name()
-}
-func (f byDirName) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
-
-// readDir reads the directory named by dirname and returns
-// a list of sorted directory entries.
-func readDir(dirname string) ([]os.FileInfo, error) {
-	f, e := os.Open(dirname)
-	if e != nil {
-		return nil, e
-	}
-	list, e := f.Readdir(-1)
-	if e != nil {
-		return nil, e
-	}
-	if e = f.Close(); e != nil {
-		return nil, e
-	}
-	sort.Sort(byDirName(list))
-	return list, nil
-}
-
 // listPrefixes - list all files for any given prefix.
-func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent) {
+func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent, policy symlinkPolicy) {
 	dirName := filepath.Dir(prefix)
-	files, e := readDir(dirName)
+	// Links are listed with the type of their target.
+	dir, e := ioutils.OpenDir(dirName, policy == symlinkDefault || policy == symlinkFollow)
 	if e != nil {
 		err := f.toClientError(e, dirName)
 		contentCh <- &ClientContent{
@@ -590,7 +646,20 @@ func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent)
 		}
 		return
 	}
-	for _, fi := range files {
+	defer dir.Close()
+	for {
+		fi, e := dir.Next()
+		if e == io.EOF {
+			return
+		}
+		if e != nil {
+			err := f.toClientError(e, dirName)
+			contentCh <- &ClientContent{
+				Err: err.Trace(dirName),
+			}
+			return
+		}
+
 		// Skip ignored files.
 		if isIgnoredFile(fi.Name()) {
 			continue
@@ -598,18 +667,18 @@ func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent)
 
 		file := filepath.Join(dirName, fi.Name())
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
-			st, e := os.Stat(file)
-			if e != nil {
-				// Ignore any errors on symlink
+			st, metadata := resolveSymlink(file, fi, policy)
+			if st == nil {
 				continue
 			}
 			if strings.HasPrefix(file, prefix) {
 				contentCh <- &ClientContent{
-					URL:  *newClientURL(file),
-					Time: st.ModTime(),
-					Size: st.Size(),
-					Type: st.Mode(),
-					Err:  nil,
+					URL:      *newClientURL(file),
+					Time:     st.ModTime(),
+					Size:     st.Size(),
+					Type:     st.Mode(),
+					Metadata: metadata,
+					Err:      nil,
 				}
 				continue
 			}
@@ -626,7 +695,7 @@ func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent)
 	}
 }
 
-func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata bool) {
+func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata bool, policy symlinkPolicy) {
 	// close the channel when the function returns.
 	defer close(contentCh)
 
@@ -639,7 +708,7 @@ func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata boo
 		if _, ok := err.ToGoError().(PathNotFound); ok {
 			// If file does not exist treat it like a prefix and list all prefixes if any.
 			prefix := fpath
-			f.listPrefixes(prefix, contentCh)
+			f.listPrefixes(prefix, contentCh, policy)
 			return
 		}
 		// For all other errors we return genuine error back to the caller.
@@ -650,25 +719,33 @@ func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata boo
 	// Now if the file exists and doesn't end with a separator ('/') do not traverse it.
 	// If the directory doesn't end with a separator, do not traverse it.
 	if !strings.HasSuffix(fpath, string(pathURL.Separator)) && fst.Mode().IsDir() && fpath != "." {
-		f.listPrefixes(fpath, contentCh)
+		f.listPrefixes(fpath, contentCh, policy)
 		return
 	}
 
 	// If we really see the directory.
 	switch fst.Mode().IsDir() {
 	case true:
-		files, e := readDir(fpath)
-		if err != nil {
+		// Links are listed with the type of their target.
+		dir, e := ioutils.OpenDir(fpath, policy == symlinkDefault || policy == symlinkFollow)
+		if e != nil {
 			contentCh <- &ClientContent{Err: probe.NewError(e)}
 			return
 		}
-		for _, file := range files {
-			fi := file
+		defer dir.Close()
+		for {
+			fi, e := dir.Next()
+			if e == io.EOF {
+				break
+			}
+			if e != nil {
+				contentCh <- &ClientContent{Err: probe.NewError(e)}
+				return
+			}
+			var metadata map[string]string
 			if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 				fp := filepath.Join(fpath, fi.Name())
-				fi, e = os.Stat(fp)
-				if e != nil {
-					// Ignore all errors on symlinks
+				if fi, metadata = resolveSymlink(fp, fi, policy); fi == nil {
 					continue
 				}
 			}
@@ -682,27 +759,37 @@ func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata boo
 				}
 
 				contentCh <- &ClientContent{
-					URL:  pathURL,
-					Time: fi.ModTime(),
-					Size: fi.Size(),
-					Type: fi.Mode(),
-					Err:  nil,
+					URL:      pathURL,
+					Time:     fi.ModTime(),
+					Size:     fi.Size(),
+					Type:     fi.Mode(),
+					Metadata: metadata,
+					Err:      nil,
 				}
 			}
 		}
 	default:
+		var metadata map[string]string
+		if policy == symlinkPreserve {
+			if lst, e := os.Lstat(fpath); e == nil && lst.Mode()&os.ModeSymlink == os.ModeSymlink {
+				if st, meta := resolveSymlink(fpath, lst, policy); st != nil {
+					fst, metadata = st, meta
+				}
+			}
+		}
 		contentCh <- &ClientContent{
-			URL:  pathURL,
-			Time: fst.ModTime(),
-			Size: fst.Size(),
-			Type: fst.Mode(),
-			Err:  nil,
+			URL:      pathURL,
+			Time:     fst.ModTime(),
+			Size:     fst.Size(),
+			Type:     fst.Mode(),
+			Metadata: metadata,
+			Err:      nil,
 		}
 	}
 }
 
 // List files recursively using non-recursive mode.
-func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool, isMetadata bool, dirOpt DirOpt) {
+func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool, isMetadata bool, dirOpt DirOpt, policy symlinkPolicy) {
 	defer close(contentCh)
 
 	// Trim trailing / or \.
@@ -712,10 +799,19 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool,
 		currentPath = strings.TrimSuffix(currentPath, `\`)
 	}
 
+	// Real paths of the listed folder and of the folders entered
+	// through followed symlinks.
+	var linkParents []string
+	if policy == symlinkFollow {
+		if root, e := filepath.EvalSymlinks(currentPath); e == nil {
+			linkParents = append(linkParents, root)
+		}
+	}
+
 	// Closure function reads currentPath and sends to contentCh. If a directory is found, it lists the directory content recursively.
 	var listDir func(currentPath string) bool
 	listDir = func(currentPath string) (isStop bool) {
-		files, e := readDir(currentPath)
+		dir, e := ioutils.OpenDir(currentPath, policy == symlinkFollow)
 		if e != nil {
 			if os.IsPermission(e) {
 				contentCh <- &ClientContent{
@@ -730,16 +826,41 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool,
 			return true
 		}
 
-		for _, file := range files {
+		defer dir.Close()
+		for {
+			file, e := dir.Next()
+			if e == io.EOF {
+				break
+			}
+			if e != nil {
+				contentCh <- &ClientContent{Err: probe.NewError(e)}
+				return true
+			}
 			name := filepath.Join(currentPath, file.Name())
+			var metadata map[string]string
+			isLink := file.Mode()&os.ModeSymlink == os.ModeSymlink
+			if isLink && policy != symlinkDefault {
+				if file, metadata = resolveSymlink(name, file, policy); file == nil {
+					continue
+				}
+			}
 			content := ClientContent{
-				URL:  *newClientURL(name),
-				Time: file.ModTime(),
-				Size: file.Size(),
-				Type: file.Mode(),
-				Err:  nil,
+				URL:      *newClientURL(name),
+				Time:     file.ModTime(),
+				Size:     file.Size(),
+				Type:     file.Mode(),
+				Metadata: metadata,
+				Err:      nil,
 			}
 			if file.Mode().IsDir() {
+				if isLink {
+					target, loop := isSymlinkLoop(name, linkParents)
+					if loop {
+						contentCh <- &ClientContent{Err: probe.NewError(TooManyLevelsSymlink{Path: name})}
+						continue
+					}
+					linkParents = append(linkParents, target)
+				}
 				if dirOpt == DirFirst && !isIncomplete {
 					contentCh <- &content
 				}
@@ -749,6 +870,9 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool,
 				if dirOpt == DirLast && !isIncomplete {
 					contentCh <- &content
 				}
+				if isLink {
+					linkParents = linkParents[:len(linkParents)-1]
+				}
 
 				continue
 			}
@@ -772,13 +896,22 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool,
 	}
 }
 
-func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetadata bool) {
+func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetadata bool, policy symlinkPolicy) {
 	// close channels upon return.
 	defer close(contentCh)
 	var dirName string
 	var filePrefix string
+	// Real paths of the listed folder and of the folders entered
+	// through followed symlinks.
+	var linkParents []string
+	// Links to folders are walked in lexical order when followed.
+	walk := ioutils.FTW
+	if policy == symlinkFollow {
+		walk = ioutils.FTWFollow
+	}
 	pathURL := *f.PathURL
-	visitFS := func(fp string, fi os.FileInfo, e error) error {
+	var visitFS ioutils.FTWFunc
+	visitFS = func(fp string, fi os.FileInfo, e error) error {
 		// If file path ends with filepath.Separator and equals to root path, skip it.
 		if strings.HasSuffix(fp, string(pathURL.Separator)) {
 			if fp == dirName {
@@ -833,20 +966,38 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 			}
 			return e
 		}
+		var metadata map[string]string
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
-			fi, e = os.Stat(fp)
-			if e != nil {
-				// Ignore any errors for symlink
+			if fi, metadata = resolveSymlink(fp, fi, policy); fi == nil {
+				return nil
+			}
+			if fi.IsDir() && policy == symlinkFollow {
+				target, loop := isSymlinkLoop(fp, linkParents)
+				if loop {
+					contentCh <- &ClientContent{
+						Err: probe.NewError(TooManyLevelsSymlink{Path: fp}),
+					}
+					return nil
+				}
+				linkParents = append(linkParents, target)
+				defer func() { linkParents = linkParents[:len(linkParents)-1] }()
+				// Walk the linked folder under the path of the link.
+				if e = walk(fp+string(pathURL.Separator), visitFS); e != nil {
+					contentCh <- &ClientContent{
+						Err: probe.NewError(e),
+					}
+				}
 				return nil
 			}
 		}
 		if fi.Mode().IsRegular() {
 			contentCh <- &ClientContent{
-				URL:  *newClientURL(fp),
-				Time: fi.ModTime(),
-				Size: fi.Size(),
-				Type: fi.Mode(),
-				Err:  nil,
+				URL:      *newClientURL(fp),
+				Time:     fi.ModTime(),
+				Size:     fi.Size(),
+				Type:     fi.Mode(),
+				Metadata: metadata,
+				Err:      nil,
 			}
 		}
 		return nil
@@ -867,8 +1018,13 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 		// filePrefix is kept for filtering incoming contents through WalkFunc.
 		filePrefix = pathURL.Path
 	}
+	if policy == symlinkFollow {
+		if root, e := filepath.EvalSymlinks(dirName); e == nil {
+			linkParents = append(linkParents, root)
+		}
+	}
 	// walks invokes our custom function.
-	e := ioutils.FTW(dirName, visitFS)
+	e := walk(dirName, visitFS)
 	if e != nil {
 		contentCh <- &ClientContent{
 			Err: probe.NewError(e),
@@ -988,6 +1144,21 @@ func (f *fsClient) SetAccess(ctx context.Context, access string, isJSON bool) *p
 
 // Stat - get metadata from path.
 func (f *fsClient) Stat(ctx context.Context, isIncomplete, isPreserve bool, sse encrypt.ServerSide) (content *ClientContent, err *probe.Error) {
+	// Preserved symlinks are described by their target, even dangling ones.
+	if !isIncomplete && contextSymlinkPolicy(ctx) == symlinkPreserve {
+		if lst, e := os.Lstat(f.PathURL.Path); e == nil && lst.Mode()&os.ModeSymlink == os.ModeSymlink {
+			if st, metadata := resolveSymlink(f.PathURL.Path, lst, symlinkPreserve); st != nil {
+				metadata["Content-Type"] = guessURLContentType(f.PathURL.Path)
+				return &ClientContent{
+					URL:      *f.PathURL,
+					Time:     st.ModTime(),
+					Type:     st.Mode(),
+					Metadata: metadata,
+				}, nil
+			}
+		}
+	}
+
 	st, err := f.fsStat(isIncomplete)
 	if err != nil {
 		return nil, err.Trace(f.PathURL.String())
@@ -1101,3 +1272,90 @@ func (f *fsClient) SetLifecycle(ctx context.Context, _ ilm.LifecycleConfiguratio
 		APIType: "filesystem",
 	})
 }
+
+// Get bucket versioning status, not implemented.
+func (f *fsClient) GetVersioning(ctx context.Context) (string, *probe.Error) {
+	return "", probe.NewError(APINotImplemented{
+		API:     "GetVersioning",
+		APIType: "filesystem",
+	})
+}
+
+// Set bucket versioning status, not implemented.
+func (f *fsClient) SetVersioning(ctx context.Context, status string) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetVersioning",
+		APIType: "filesystem",
+	})
+}
+
+// List object versions, not implemented.
+func (f *fsClient) ListVersions(ctx context.Context, isRecursive bool, timeRef time.Time, withOlderVersions bool) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- &ClientContent{
+		Err: probe.NewError(APINotImplemented{
+			API:     "ListVersions",
+			APIType: "filesystem",
+		}),
+	}
+	close(contentCh)
+	return contentCh
+}
+
+// Stat a specific object version, not implemented.
+func (f *fsClient) StatVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ClientContent, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "StatVersion",
+		APIType: "filesystem",
+	})
+}
+
+// Get a specific object version, not implemented.
+func (f *fsClient) GetVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetVersion",
+		APIType: "filesystem",
+	})
+}
+
+// Remove specific object versions, not implemented.
+func (f *fsClient) RemoveVersions(ctx context.Context, isBypass bool, contentCh <-chan *ClientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+
+	go func() {
+		defer close(errorCh)
+
+		for range contentCh {
+			errorCh <- probe.NewError(APINotImplemented{
+				API:     "RemoveVersions",
+				APIType: "filesystem",
+			})
+		}
+	}()
+
+	return errorCh
+}
+
+// Get bucket replication configuration, not implemented.
+func (f *fsClient) GetReplication(ctx context.Context) (replication.Config, *probe.Error) {
+	return replication.Config{}, probe.NewError(APINotImplemented{
+		API:     "GetReplication",
+		APIType: "filesystem",
+	})
+}
+
+// Set bucket replication configuration, not implemented.
+func (f *fsClient) SetReplication(ctx context.Context, cfg replication.Config) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetReplication",
+		APIType: "filesystem",
+	})
+}
+
+// Remove bucket replication configuration, not implemented.
+func (f *fsClient) RemoveReplication(ctx context.Context) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "RemoveReplication",
+		APIType: "filesystem",
+	})
+}
@@ -214,6 +214,27 @@ func (s *TestSuite) TestStatBucket(c *C) {
 	c.Assert(err, IsNil)
 }
 
+// Test object versions are not supported on filesystem.
+func (s *TestSuite) TestStatVersionFails(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	objectPath := filepath.Join(root, "object")
+	c.Assert(ioutil.WriteFile(objectPath, []byte("hello"), 0644), IsNil)
+
+	fsClient, err := fsNew(objectPath)
+	c.Assert(err, IsNil)
+
+	_, err = fsClient.StatVersion(context.Background(), "v1", nil)
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(APINotImplemented)
+	c.Assert(ok, Equals, true)
+
+	_, err = fsClient.GetVersion(context.Background(), "v1", nil)
+	c.Assert(err, NotNil)
+}
+
 // Test bucket acl fails for directories.
 func (s *TestSuite) TestBucketACLFails(c *C) {
 	root, e := ioutil.TempDir(os.TempDir(), "fs-")
@@ -0,0 +1,197 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/cmd/replication"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/s3signer"
+)
+
+// Header holding the replication status of an object.
+const amzReplicationStatus = "X-Amz-Replication-Status"
+
+// executeBucketRequest sends a signed request to a sub-resource of bucket
+// which is not supported by the minio-go API, such as 'replication', and
+// returns the body of the response.
+func (c *S3Client) executeBucketRequest(ctx context.Context, method, bucket, subResource string, body []byte) ([]byte, *probe.Error) {
+	location, err := c.getBucketLocation(ctx, bucket)
+	if err != nil {
+		return nil, err.Trace(bucket)
+	}
+	return c.executeSignedBucketRequest(ctx, method, bucket, subResource, location, body)
+}
+
+// getBucketLocation returns the region of bucket. The location of a
+// bucket is always requested with a signature for us-east-1.
+func (c *S3Client) getBucketLocation(ctx context.Context, bucket string) (string, *probe.Error) {
+	locationXML, err := c.executeSignedBucketRequest(ctx, http.MethodGet, bucket, "location", "us-east-1", nil)
+	if err != nil {
+		return "", err
+	}
+	var location struct {
+		XMLName xml.Name `xml:"LocationConstraint"`
+		Region  string   `xml:",chardata"`
+	}
+	if e := xml.Unmarshal(locationXML, &location); e != nil {
+		return "", probe.NewError(e)
+	}
+	switch location.Region {
+	case "":
+		return "us-east-1", nil
+	case "EU":
+		return "eu-west-1", nil
+	}
+	return location.Region, nil
+}
+
+// executeSignedBucketRequest sends a request to a sub-resource of bucket,
+// signed for location.
+func (c *S3Client) executeSignedBucketRequest(ctx context.Context, method, bucket, subResource, location string, body []byte) ([]byte, *probe.Error) {
+	reqURL := url.URL{
+		Scheme:   c.targetURL.Scheme,
+		Host:     c.targetURL.Host,
+		Path:     "/" + bucket + "/",
+		RawQuery: url.Values{subResource: []string{""}}.Encode(),
+	}
+	if c.virtualStyle {
+		reqURL.Host = bucket + "." + reqURL.Host
+		reqURL.Path = "/"
+	}
+
+	req, e := http.NewRequest(method, reqURL.String(), bytes.NewReader(body))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	req = req.WithContext(ctx)
+	sha256Sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum[:]))
+	if len(body) > 0 {
+		md5Sum := md5.Sum(body)
+		req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+		req.ContentLength = int64(len(body))
+	}
+
+	value, e := c.creds.Get()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	switch {
+	case value.SignerType.IsAnonymous():
+	case value.SignerType.IsV2():
+		req = s3signer.SignV2(*req, value.AccessKeyID, value.SecretAccessKey, c.virtualStyle)
+	default:
+		req = s3signer.SignV4(*req, value.AccessKeyID, value.SecretAccessKey, value.SessionToken, location)
+	}
+
+	resp, e := (&http.Client{Transport: c.transport}).Do(req)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	respBody, e := ioutil.ReadAll(resp.Body)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if resp.StatusCode/100 != 2 {
+		errResponse := minio.ErrorResponse{}
+		if e = xml.Unmarshal(respBody, &errResponse); e != nil || errResponse.Code == "" {
+			errResponse.Code = resp.Status
+			errResponse.Message = http.StatusText(resp.StatusCode)
+		}
+		errResponse.StatusCode = resp.StatusCode
+		errResponse.BucketName = bucket
+		return nil, probe.NewError(errResponse)
+	}
+	return respBody, nil
+}
+
+// GetReplication - Get the replication configuration of a bucket, empty if not set.
+func (c *S3Client) GetReplication(ctx context.Context) (replication.Config, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return replication.Config{}, probe.NewError(BucketNameEmpty{})
+	}
+
+	replicationXML, err := c.executeBucketRequest(ctx, http.MethodGet, bucket, "replication", nil)
+	if err != nil {
+		switch minio.ToErrorResponse(err.ToGoError()).Code {
+		case "ReplicationConfigurationNotFoundError":
+			return replication.Config{}, nil
+		case "NoSuchBucket":
+			return replication.Config{}, probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		}
+		return replication.Config{}, err.Trace(bucket)
+	}
+
+	return replication.ParseXML(bytes.NewReader(replicationXML))
+}
+
+// SetReplication - Set the replication configuration of a bucket, an empty
+// configuration removes it.
+func (c *S3Client) SetReplication(ctx context.Context, cfg replication.Config) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object != "" {
+		return probe.NewError(errors.New("replication can only be configured on a bucket"))
+	}
+
+	if cfg.Empty() {
+		return c.RemoveReplication(ctx)
+	}
+	if err := replication.Validate(cfg); err != nil {
+		return err.Trace(bucket)
+	}
+
+	replicationXML, e := xml.Marshal(cfg)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, err := c.executeBucketRequest(ctx, http.MethodPut, bucket, "replication", replicationXML); err != nil {
+		return err.Trace(bucket)
+	}
+	return nil
+}
+
+// RemoveReplication - Remove the replication configuration of a bucket.
+func (c *S3Client) RemoveReplication(ctx context.Context) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+
+	if _, err := c.executeBucketRequest(ctx, http.MethodDelete, bucket, "replication", nil); err != nil {
+		return err.Trace(bucket)
+	}
+	return nil
+}
@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"hash/fnv"
 	"io"
 	"net"
@@ -55,6 +54,10 @@ type S3Client struct {
 	targetURL    *ClientURL
 	api          *minio.Client
 	virtualStyle bool
+
+	// Used to sign and send the requests api does not support.
+	creds     *credentials.Credentials
+	transport http.RoundTripper
 }
 
 const (
@@ -87,7 +90,7 @@ var timeSentinel = time.Unix(0, 0).UTC()
 
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
-	clientCache := make(map[uint32]*minio.Client)
+	clientCache := make(map[uint32]*S3Client)
 	var mutex sync.Mutex
 
 	// Return New function.
@@ -118,24 +121,19 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		}
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken))
+		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken + config.Credentials.String()))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
 		mutex.Lock()
 		defer mutex.Unlock()
-		var api *minio.Client
-		var found bool
-		if api, found = clientCache[confSum]; !found {
-			// if Signature version '4' use NewV4 directly.
-			creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken)
-			// if Signature version '2' use NewV2 directly.
-			if strings.ToUpper(config.Signature) == "S3V2" {
-				creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
+		cached, found := clientCache[confSum]
+		if !found {
+			creds, err := newCredentials(config)
+			if err != nil {
+				return nil, err.Trace(config.HostURL)
 			}
 			// Not found. Instantiate a new MinIO
-			var e error
-
 			options := minio.Options{
 				Creds:        creds,
 				Secure:       useTLS,
@@ -143,7 +141,7 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				BucketLookup: config.Lookup,
 			}
 
-			api, e = minio.NewWithOptions(hostName, &options)
+			api, e := minio.NewWithOptions(hostName, &options)
 			if e != nil {
 				return nil, probe.NewError(e)
 			}
@@ -212,11 +210,14 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 			api.SetAppInfo(config.AppName, config.AppVersion)
 
 			// Cache the new MinIO Client with hash of config as key.
-			clientCache[confSum] = api
+			cached = &S3Client{api: api, creds: creds, transport: transport}
+			clientCache[confSum] = cached
 		}
 
 		// Store the new api object.
-		s3Clnt.api = api
+		s3Clnt.api = cached.api
+		s3Clnt.creds = cached.creds
+		s3Clnt.transport = cached.transport
 
 		return s3Clnt, nil
 	}
@@ -729,6 +730,10 @@ func (c *S3Client) notificationToEventsInfo(ninfo minio.NotificationInfo) []Even
 
 // Watch - Start watching on all bucket events for a given account ID.
 func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObject, *probe.Error) {
+	if options.PollInterval > 0 {
+		return pollWatch(ctx, c, options)
+	}
+
 	// Extract bucket and object.
 	bucket, object := c.url2BucketAndObject()
 
@@ -812,9 +817,16 @@ func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 
 // Get - get object with metadata.
 func (c *S3Client) Get(ctx context.Context, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	return c.GetVersion(ctx, "", sse)
+}
+
+// GetVersion - get a specific version of an object, an empty
+// versionID returns the latest version.
+func (c *S3Client) GetVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
 	opts := minio.GetObjectOptions{}
 	opts.ServerSideEncryption = sse
+	opts.VersionID = versionID
 	reader, e := c.api.GetObjectWithContext(ctx, bucket, object, opts)
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
@@ -831,6 +843,9 @@ func (c *S3Client) Get(ctx context.Context, sse encrypt.ServerSide) (io.ReadClos
 		if errResponse.Code == "NoSuchKey" {
 			return nil, probe.NewError(ObjectMissing{})
 		}
+		if errResponse.Code == "NoSuchVersion" {
+			return nil, probe.NewError(ObjectVersionMissing{VersionID: versionID})
+		}
 		return nil, probe.NewError(e)
 	}
 	return reader, nil
@@ -966,10 +981,12 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 			retainUntilDate = t.UTC()
 		}
 	}
+	transfer := getTransferOptionsFromContext(ctx)
 	opts := minio.PutObjectOptions{
 		UserMetadata:         metadata,
 		Progress:             progress,
-		NumThreads:           defaultMultipartThreadsNum,
+		NumThreads:           transfer.numThreads(),
+		PartSize:             transfer.uploadPartSize(size),
 		ContentType:          contentType,
 		CacheControl:         cacheControl,
 		ContentDisposition:   contentDisposition,
@@ -1453,12 +1470,35 @@ func (c *S3Client) getObjectStat(ctx context.Context, bucket, object string, opt
 		if errResponse.Code == "NoSuchKey" {
 			return nil, probe.NewError(ObjectMissing{})
 		}
+		if errResponse.Code == "NoSuchVersion" {
+			return nil, probe.NewError(ObjectVersionMissing{VersionID: opts.VersionID})
+		}
 		return nil, probe.NewError(e)
 	}
 	objectMetadata := c.objectInfo2ClientContent(bucket, objectStat)
 	return objectMetadata, nil
 }
 
+// StatVersion - send a 'HEAD' on a specific version of an object.
+func (c *S3Client) StatVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ClientContent, *probe.Error) {
+	if versionID == "" {
+		return c.Stat(ctx, false, false, sse)
+	}
+
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectNameEmpty{})
+	}
+
+	opts := minio.StatObjectOptions{}
+	opts.ServerSideEncryption = sse
+	opts.VersionID = versionID
+	return c.getObjectStat(ctx, bucket, object, opts)
+}
+
 func isAmazon(host string) bool {
 	return s3utils.IsAmazonEndpoint(url.URL{Host: host})
 }
@@ -1853,6 +1893,14 @@ func (c *S3Client) objectInfo2ClientContent(bucket string, entry minio.ObjectInf
 	content.ETag = entry.ETag
 	content.Time = entry.LastModified
 	content.Expires = entry.Expires
+	content.VersionID = entry.VersionID
+	content.IsLatest = entry.IsLatest
+	content.IsDeleteMarker = entry.IsDeleteMarker
+	content.ReplicationStatus = entry.Metadata.Get(amzReplicationStatus)
+	if content.ReplicationStatus == "" {
+		// Listings with metadata report it with the user metadata.
+		content.ReplicationStatus = entry.UserMetadata[amzReplicationStatus]
+	}
 	content.Metadata = map[string]string{}
 	content.UserMetadata = map[string]string{}
 	for k, v := range entry.UserMetadata {
@@ -2185,7 +2233,7 @@ func (c *S3Client) GetObjectLockConfig(ctx context.Context) (minio.RetentionMode
 		return *mode, vuint64, *unit, nil
 	}
 
-	return "", 0, "", probe.NewError(fmt.Errorf("No object lock configuration set on %s", bucket)).Trace(c.GetURL().String())
+	return "", 0, "", probe.NewError(ObjectLockConfigNotFound{Bucket: bucket}).Trace(c.GetURL().String())
 }
 
 // GetTags - Get tags of bucket or object.
@@ -2305,3 +2353,156 @@ func (c *S3Client) SetLifecycle(ctx context.Context, ilmCfg ilm.LifecycleConfigu
 
 	return nil
 }
+
+// Bucket versioning states as reported by the server.
+const (
+	versioningEnabled   = "Enabled"
+	versioningSuspended = "Suspended"
+)
+
+// GetVersioning - Get bucket versioning status.
+func (c *S3Client) GetVersioning(ctx context.Context) (string, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", probe.NewError(BucketNameEmpty{})
+	}
+
+	versioningCfg, e := c.api.GetBucketVersioningWithContext(ctx, bucket)
+	if e != nil {
+		errResponse := minio.ToErrorResponse(e)
+		if errResponse.Code == "NoSuchBucket" {
+			return "", probe.NewError(BucketDoesNotExist{
+				Bucket: bucket,
+			})
+		}
+		return "", probe.NewError(e)
+	}
+
+	return versioningCfg.Status, nil
+}
+
+// SetVersioning - Enable or suspend versioning on a bucket.
+func (c *S3Client) SetVersioning(ctx context.Context, status string) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object != "" {
+		return probe.NewError(errors.New("versioning can only be configured on a bucket"))
+	}
+
+	var e error
+	switch status {
+	case versioningEnabled:
+		e = c.api.EnableVersioningWithContext(ctx, bucket)
+	case versioningSuspended:
+		e = c.api.SuspendVersioningWithContext(ctx, bucket)
+	default:
+		return errInvalidArgument().Trace(status)
+	}
+	if e != nil {
+		errResponse := minio.ToErrorResponse(e)
+		if errResponse.Code == "NoSuchBucket" {
+			return probe.NewError(BucketDoesNotExist{
+				Bucket: bucket,
+			})
+		}
+		return probe.NewError(e)
+	}
+
+	return nil
+}
+
+// ListVersions - list object versions at the current prefix. When
+// withOlderVersions is set every version and delete marker is sent,
+// otherwise only the version which was current at timeRef is sent
+// and keys deleted at that time are skipped. A zero timeRef stands
+// for the present.
+func (c *S3Client) ListVersions(ctx context.Context, isRecursive bool, timeRef time.Time, withOlderVersions bool) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent)
+	go c.listVersionsInRoutine(ctx, contentCh, isRecursive, timeRef, withOlderVersions)
+	return contentCh
+}
+
+func (c *S3Client) listVersionsInRoutine(ctx context.Context, contentCh chan *ClientContent, isRecursive bool, timeRef time.Time, withOlderVersions bool) {
+	defer close(contentCh)
+
+	b, o := c.url2BucketAndObject()
+	if b == "" {
+		contentCh <- &ClientContent{Err: probe.NewError(BucketNameEmpty{})}
+		return
+	}
+
+	// Versions of a key are always listed from the newest to the
+	// oldest, remember the last key we have already resolved.
+	var lastKey string
+	for object := range c.api.ListObjectVersionsWithContext(ctx, b, o, isRecursive, nil) {
+		if object.Err != nil {
+			contentCh <- &ClientContent{
+				Err: probe.NewError(object.Err),
+			}
+			return
+		}
+
+		content := c.objectInfo2ClientContent(b, object)
+		if content.Type.IsDir() {
+			// Avoid sending an empty directory when we are specifically listing it
+			if o == object.Key {
+				continue
+			}
+			contentCh <- content
+			continue
+		}
+
+		if !timeRef.IsZero() && object.LastModified.After(timeRef) {
+			continue
+		}
+
+		if withOlderVersions {
+			contentCh <- content
+			continue
+		}
+
+		if object.Key == lastKey {
+			continue
+		}
+		lastKey = object.Key
+		if object.IsDeleteMarker {
+			continue
+		}
+		contentCh <- content
+	}
+}
+
+// RemoveVersions - remove the object versions read from contentCh.
+func (c *S3Client) RemoveVersions(ctx context.Context, isBypass bool, contentCh <-chan *ClientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+
+	go func() {
+		defer close(errorCh)
+
+		for content := range contentCh {
+			bucket, objectName := c.splitPath(content.URL.Path)
+			if bucket == "" || objectName == "" {
+				continue
+			}
+
+			opts := minio.RemoveObjectOptions{
+				GovernanceBypass: isBypass,
+				VersionID:        content.VersionID,
+			}
+			if e := c.api.RemoveObjectWithOptions(bucket, objectName, opts); e != nil {
+				errResponse := minio.ToErrorResponse(e)
+				if errResponse.Code == "AccessDenied" {
+					errorCh <- probe.NewError(PathInsufficientPermission{
+						Path: content.URL.String(),
+					})
+					continue
+				}
+				errorCh <- probe.NewError(e)
+			}
+		}
+	}()
+
+	return errorCh
+}
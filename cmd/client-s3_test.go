@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -237,6 +238,89 @@ func (s *TestSuite) TestObjectOperations(c *C) {
 	}
 }
 
+// versionedObjectHandler is an http.Handler that serves the versions of a
+// single object selected by the versionId query parameter.
+type versionedObjectHandler struct {
+	resource string
+	versions map[string][]byte
+}
+
+func (h versionedObjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		response := []byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write(response)
+		return
+	}
+	versionID := r.URL.Query().Get("versionId")
+	data, ok := h.versions[versionID]
+	if r.URL.Path != h.resource || !ok {
+		if r.Method == "HEAD" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		response := []byte("<Error><Code>NoSuchVersion</Code><Message>The specified version does not exist.</Message></Error>")
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(response)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Last-Modified", UTCNow().Format(http.TimeFormat))
+	w.Header().Set("ETag", "9af2f8218b150c351ad802c6f3d66abe")
+	w.Header().Set("x-amz-version-id", versionID)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == "GET" {
+		io.Copy(w, bytes.NewReader(data))
+	}
+}
+
+// Test reading specific object versions.
+func (s *TestSuite) TestObjectVersions(c *C) {
+	object := versionedObjectHandler{
+		resource: "/bucket/object",
+		versions: map[string][]byte{
+			"v1": []byte("Hello, World"),
+			"v2": []byte("Hello, versioned World"),
+		},
+	}
+	server := httptest.NewServer(object)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + object.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	for versionID, data := range object.versions {
+		content, err := s3c.StatVersion(context.Background(), versionID, nil)
+		c.Assert(err, IsNil)
+		c.Assert(content.Size, Equals, int64(len(data)))
+
+		reader, err := s3c.GetVersion(context.Background(), versionID, nil)
+		c.Assert(err, IsNil)
+		var buffer bytes.Buffer
+		_, e := io.Copy(&buffer, reader)
+		c.Assert(e, IsNil)
+		c.Assert(buffer.Bytes(), DeepEquals, data)
+		reader.Close()
+	}
+
+	_, err = s3c.StatVersion(context.Background(), "missing", nil)
+	c.Assert(err, NotNil)
+
+	reader, err := s3c.GetVersion(context.Background(), "missing", nil)
+	if err == nil {
+		// The object reader reports errors on first read.
+		_, e := io.Copy(ioutil.Discard, reader)
+		c.Assert(e, NotNil)
+		reader.Close()
+	}
+}
+
 var testSelectCompressionTypeCases = []struct {
 	opts            SelectObjectOpts
 	object          string
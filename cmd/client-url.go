@@ -23,6 +23,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/mimedb"
@@ -199,6 +200,53 @@ func url2Stat(ctx context.Context, urlStr string, fileAttr bool, encKeyDB map[st
 	return client, content, nil
 }
 
+// url2StatWithVersion returns stat info for a specific version of URL. The
+// version is either given by versionID or resolved as the version which was
+// current at timeRef. Without any of them it behaves like url2Stat.
+func url2StatWithVersion(ctx context.Context, urlStr, versionID string, timeRef time.Time, fileAttr bool, encKeyDB map[string][]prefixSSEPair) (client Client, content *ClientContent, err *probe.Error) {
+	if versionID == "" && timeRef.IsZero() {
+		return url2Stat(ctx, urlStr, fileAttr, encKeyDB)
+	}
+
+	client, err = newClient(urlStr)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	alias, _ := url2Alias(urlStr)
+	sse := getSSE(urlStr, encKeyDB[alias])
+
+	if versionID != "" {
+		content, err = client.StatVersion(ctx, versionID, sse)
+		if err != nil {
+			return nil, nil, err.Trace(urlStr, versionID)
+		}
+		return client, content, nil
+	}
+
+	// Prefixes are not versioned, return them as they are.
+	content, err = client.Stat(ctx, false, fileAttr, sse)
+	if err == nil && content.Type.IsDir() {
+		return client, content, nil
+	}
+
+	isRecursive := false
+	withOlderVersions := false
+	for version := range client.ListVersions(ctx, isRecursive, timeRef, withOlderVersions) {
+		if version.Err != nil {
+			return nil, nil, version.Err.Trace(urlStr)
+		}
+		if version.URL.Path != client.GetURL().Path {
+			continue
+		}
+		content, err = client.StatVersion(ctx, version.VersionID, sse)
+		if err != nil {
+			return nil, nil, err.Trace(urlStr, version.VersionID)
+		}
+		return client, content, nil
+	}
+	return nil, nil, probe.NewError(ObjectMissing{}).Trace(urlStr, timeRef.String())
+}
+
 // url2Alias separates alias and path from the URL. Aliased URL is of
 // the form alias/path/to/blah.
 func url2Alias(aliasedURL string) (alias, path string) {
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/minio/mc/cmd/ilm"
+	"github.com/minio/mc/cmd/replication"
 	"github.com/minio/mc/pkg/probe"
 	minio "github.com/minio/minio-go/v6"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
@@ -97,6 +98,19 @@ type Client interface {
 	// Lifecycle operations
 	GetLifecycle(ctx context.Context) (ilm.LifecycleConfiguration, *probe.Error)
 	SetLifecycle(ctx context.Context, lfcCfg ilm.LifecycleConfiguration) *probe.Error
+
+	// Versioning operations
+	GetVersioning(ctx context.Context) (status string, err *probe.Error)
+	SetVersioning(ctx context.Context, status string) *probe.Error
+	ListVersions(ctx context.Context, isRecursive bool, timeRef time.Time, withOlderVersions bool) <-chan *ClientContent
+	StatVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ClientContent, *probe.Error)
+	GetVersion(ctx context.Context, versionID string, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error)
+	RemoveVersions(ctx context.Context, isBypass bool, contentCh <-chan *ClientContent) <-chan *probe.Error
+
+	// Replication operations
+	GetReplication(ctx context.Context) (replication.Config, *probe.Error)
+	SetReplication(ctx context.Context, cfg replication.Config) *probe.Error
+	RemoveReplication(ctx context.Context) *probe.Error
 }
 
 // ClientContent - Content container for content metadata
@@ -116,6 +130,10 @@ type ClientContent struct {
 	BypassGovernance  bool
 	LegalHoldEnabled  bool
 	LegalHold         string
+	VersionID         string
+	IsLatest          bool
+	IsDeleteMarker    bool
+	ReplicationStatus string
 
 	Err *probe.Error
 }
@@ -133,6 +151,7 @@ type Config struct {
 	Debug        bool
 	Insecure     bool
 	Lookup       minio.BucketLookupType
+	Credentials  *credentialsConfigV10
 }
 
 // SelectObjectOpts - opts entered for select API
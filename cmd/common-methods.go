@@ -19,7 +19,9 @@ package cmd
 import (
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -148,17 +150,18 @@ func getSourceStreamMetadataFromURL(ctx context.Context, urlStr string, encKeyDB
 		return nil, nil, err.Trace(urlStr)
 	}
 	sseKey := getSSE(urlStr, encKeyDB[alias])
-	return getSourceStream(ctx, alias, urlStrFull, true, sseKey, false)
+	return getSourceStream(ctx, alias, urlStrFull, "", true, sseKey, false)
 }
 
-// getSourceStreamFromURL gets a reader from URL.
-func getSourceStreamFromURL(ctx context.Context, urlStr string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser, err *probe.Error) {
+// getSourceStreamFromURL gets a reader from URL, an empty versionID
+// reads the latest version.
+func getSourceStreamFromURL(ctx context.Context, urlStr, versionID string, encKeyDB map[string][]prefixSSEPair) (reader io.ReadCloser, err *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return nil, err.Trace(urlStr)
 	}
 	sse := getSSE(urlStr, encKeyDB[alias])
-	reader, _, err = getSourceStream(ctx, alias, urlStrFull, false, sse, false)
+	reader, _, err = getSourceStream(ctx, alias, urlStrFull, versionID, false, sse, false)
 	return reader, err
 }
 
@@ -210,12 +213,16 @@ func isReadAt(reader io.Reader) (ok bool) {
 }
 
 // getSourceStream gets a reader from URL.
-func getSourceStream(ctx context.Context, alias string, urlStr string, fetchStat bool, sse encrypt.ServerSide, preserve bool) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
+func getSourceStream(ctx context.Context, alias, urlStr, versionID string, fetchStat bool, sse encrypt.ServerSide, preserve bool) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
 	sourceClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
-	reader, err = sourceClnt.Get(ctx, sse)
+	if versionID != "" {
+		reader, err = sourceClnt.GetVersion(ctx, versionID, sse)
+	} else {
+		reader, err = sourceClnt.Get(ctx, sse)
+	}
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
@@ -240,6 +247,11 @@ func getSourceStream(ctx context.Context, alias string, urlStr string, fetchStat
 				st.Metadata[k] = oinfo.Metadata.Get(k)
 			}
 			st.ETag = oinfo.ETag
+		} else if versionID != "" {
+			st, err = sourceClnt.StatVersion(ctx, versionID, sse)
+			if err != nil {
+				return nil, nil, err.Trace(alias, urlStr, versionID)
+			}
 		} else {
 			st, err = sourceClnt.Stat(ctx, false, preserve, sse)
 			if err != nil {
@@ -320,7 +332,7 @@ func putTargetStream(ctx context.Context, alias, urlStr, mode, until, legalHold
 }
 
 // putTargetStreamWithURL writes to URL from reader. If length=-1, read until EOF.
-func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide, md5, disableMultipart bool, metadata map[string]string) (int64, *probe.Error) {
+func putTargetStreamWithURL(ctx context.Context, urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide, md5, disableMultipart bool, metadata map[string]string) (int64, *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
@@ -330,7 +342,7 @@ func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse enc
 		metadata = map[string]string{}
 	}
 	metadata["Content-Type"] = contentType
-	return putTargetStream(context.Background(), alias, urlStrFull, "", "", "", reader, size, metadata, nil, sse, md5, disableMultipart)
+	return putTargetStream(ctx, alias, urlStrFull, "", "", "", reader, size, metadata, nil, sse, md5, disableMultipart)
 }
 
 // copySourceToTargetURL copies to targetURL from source.
@@ -375,7 +387,12 @@ func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSS
 	if err != nil {
 		return nil, err.Trace(sourceAlias, sourceURLStr)
 	}
-	st, err := sourceClnt.Stat(ctx, false, preserve, srcSSE)
+	var st *ClientContent
+	if versionID := urls.SourceContent.VersionID; versionID != "" {
+		st, err = sourceClnt.StatVersion(ctx, versionID, srcSSE)
+	} else {
+		st, err = sourceClnt.Stat(ctx, false, preserve, srcSSE)
+	}
 	if err != nil {
 		return nil, err.Trace(sourceAlias, sourceURLStr)
 	}
@@ -391,6 +408,13 @@ func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSS
 	return filterMetadata(metadata), nil
 }
 
+// isServerSideCopy returns true if urls can be copied by the target
+// server itself, a specific source version can only be streamed.
+func isServerSideCopy(urls URLs) bool {
+	return urls.SourceAlias == urls.TargetAlias && urls.SourceContent.VersionID == "" &&
+		!isArchiveURL(urls.SourceContent.URL.String())
+}
+
 // uploadSourceToTargetURL - uploads to targetURL from source.
 // optionally optimizes copy for object sizes <= 5GiB by using
 // server side copy operation.
@@ -451,8 +475,11 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 
+	// Source checksum computed while streaming, if verification is requested.
+	var sourceHash hash.Hash
+
 	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	if isServerSideCopy(urls) {
 		// If no metadata populated already by the caller
 		// just do a Stat() to obtain the metadata.
 		if len(metadata) == 0 {
@@ -507,7 +534,7 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 
 		var reader io.ReadCloser
 		// Proceed with regular stream copy.
-		reader, metadata, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), true, srcSSE, preserve)
+		reader, metadata, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), urls.SourceContent.VersionID, true, srcSSE, preserve)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
@@ -523,7 +550,15 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			metadata[http.CanonicalHeaderKey(k)] = v
 		}
 
-		if isReadAt(reader) {
+		if urls.Verify != "" {
+			// Checksum the data while it streams, reading at
+			// offsets would bypass the hash.
+			sourceHash = urls.Verify.newHash()
+			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+				legalHold, io.TeeReader(io.LimitReader(reader, length), sourceHash),
+				length, filterMetadata(metadata), progress, tgtSSE, urls.MD5,
+				urls.DisableMultipart)
+		} else if isReadAt(reader) {
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, reader, length, filterMetadata(metadata),
 				progress, tgtSSE, urls.MD5, urls.DisableMultipart)
@@ -538,6 +573,17 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		return urls.WithError(err.Trace(sourceURL.String()))
 	}
 
+	if urls.Verify != "" {
+		var sourceSum string
+		if sourceHash != nil {
+			sourceSum = hex.EncodeToString(sourceHash.Sum(nil))
+		}
+		err = verifyTargetChecksum(ctx, urls, sourceSum, srcSSE, tgtSSE)
+		if err != nil {
+			return urls.WithError(err.Trace(sourceURL.String()))
+		}
+	}
+
 	return urls.WithError(nil)
 }
 
@@ -550,6 +596,15 @@ func newClientFromAlias(alias, urlStr string) (Client, *probe.Error) {
 		return nil, err.Trace(alias, urlStr)
 	}
 
+	if isArchiveURL(urlStr) {
+		// Entries of an archive stored on the alias.
+		archiveClient, err := archiveNew(alias, urlStr)
+		if err != nil {
+			return nil, err.Trace(alias, urlStr)
+		}
+		return archiveClient, nil
+	}
+
 	if hostCfg == nil {
 		// No matching host config. So we treat it like a
 		// filesystem.
@@ -0,0 +1,76 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var configDecryptCmd = cli.Command{
+	Name:            "decrypt",
+	Usage:           "store secrets of all hosts in plain text in configuration file",
+	Action:          mainConfigDecrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_CONFIG_PASSPHRASE:  passphrase of the configuration file
+  MC_CONFIG_KEY_FILE:    file holding the passphrase of the configuration file
+
+EXAMPLES:
+  1. Decrypt secrets of all hosts with a passphrase entered on the terminal.
+     {{.Prompt}} {{.HelpName}}
+     Enter config passphrase:
+`,
+}
+
+// mainConfigDecrypt is the handle for "mc config decrypt" command.
+func mainConfigDecrypt(ctx *cli.Context) error {
+	checkConfigEncryptSyntax(ctx)
+
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if conf.Encryption == nil {
+		fatalIf(errInvalidArgument().Trace(), "Configuration file is not encrypted.")
+	}
+
+	for alias, hostCfg := range conf.Hosts {
+		fatalIf(conf.decryptHost(&hostCfg).Trace(alias), "Unable to decrypt secrets of `"+alias+"`.")
+		conf.Hosts[alias] = hostCfg
+	}
+	conf.Encryption = nil
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(), "Unable to save config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(configEncryptMessage{op: "decrypt", Hosts: len(conf.Hosts)})
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var configEncryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "encrypt secrets of all hosts in configuration file",
+	Action:          mainConfigEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_CONFIG_PASSPHRASE:  passphrase of the configuration file
+  MC_CONFIG_KEY_FILE:    file holding the passphrase of the configuration file
+
+  The passphrase is asked on the terminal when none of them is set.
+
+EXAMPLES:
+  1. Encrypt secrets of all hosts with a passphrase entered on the terminal.
+     {{.Prompt}} {{.HelpName}}
+     Enter config passphrase:
+     Confirm config passphrase:
+
+  2. Encrypt secrets of all hosts with a passphrase read from a key file.
+     {{.Prompt}} export MC_CONFIG_KEY_FILE=/etc/mc/passphrase
+     {{.Prompt}} {{.HelpName}}
+`,
+}
+
+// configEncryptMessage container for content message structure
+type configEncryptMessage struct {
+	op     string
+	Status string `json:"status"`
+	Hosts  int    `json:"hosts"`
+}
+
+func (c configEncryptMessage) String() string {
+	if c.op == "decrypt" {
+		return console.Colorize("ConfigEncrypt", "Decrypted secrets of configuration file successfully.")
+	}
+	return console.Colorize("ConfigEncrypt", "Encrypted secrets of configuration file successfully.")
+}
+
+func (c configEncryptMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkConfigEncryptSyntax - verifies input arguments to 'config encrypt'.
+func checkConfigEncryptSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// mainConfigEncrypt is the handle for "mc config encrypt" command.
+func mainConfigEncrypt(ctx *cli.Context) error {
+	checkConfigEncryptSyntax(ctx)
+
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if conf.Encryption != nil {
+		fatalIf(errInvalidArgument().Trace(), "Configuration file is already encrypted.")
+	}
+
+	passphrase, err := readConfigPassphrase(true)
+	fatalIf(err.Trace(), "Unable to read config passphrase.")
+
+	conf.Encryption, err = newEncryptionConfig(passphrase)
+	fatalIf(err.Trace(), "Unable to initialize config encryption.")
+
+	for alias, hostCfg := range conf.Hosts {
+		fatalIf(conf.encryptHost(&hostCfg).Trace(alias), "Unable to encrypt secrets of `"+alias+"`.")
+		conf.Hosts[alias] = hostCfg
+	}
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(), "Unable to save config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(configEncryptMessage{op: "encrypt", Hosts: len(conf.Hosts)})
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -44,6 +45,34 @@ var hostAddFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringFlag{
+		Name:  "credentials-source",
+		Usage: "obtain credentials from '[static,env,aws-profile,sts-web-identity,sts-client-grants,iam,process]'",
+	},
+	cli.StringFlag{
+		Name:  "profile",
+		Usage: "profile of the AWS credentials file for 'aws-profile'",
+	},
+	cli.StringFlag{
+		Name:  "credentials-file",
+		Usage: "AWS credentials file for 'aws-profile', defaults to ~/.aws/credentials",
+	},
+	cli.StringFlag{
+		Name:  "credentials-endpoint",
+		Usage: "STS endpoint for 'sts-web-identity' and 'sts-client-grants', metadata endpoint for 'iam'",
+	},
+	cli.StringFlag{
+		Name:  "token-file",
+		Usage: "file holding the identity token for 'sts-web-identity' and 'sts-client-grants'",
+	},
+	cli.IntFlag{
+		Name:  "token-duration",
+		Usage: "requested validity in seconds of STS credentials",
+	},
+	cli.StringFlag{
+		Name:  "credential-process",
+		Usage: "command printing credentials as JSON for 'process'",
+	},
 }
 var configHostAddCmd = cli.Command{
 	Name:            "add",
@@ -58,6 +87,7 @@ var configHostAddCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} ALIAS URL ACCESSKEY SECRETKEY
+  {{.HelpName}} ALIAS URL --credentials-source SOURCE [CREDENTIALS FLAGS]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -90,6 +120,17 @@ EXAMPLES:
      {{.Prompt}} echo -e "BKIKJAA5BMMU2RHO6IBB\nV8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12" | \
                  {{.HelpName}} mys3 https://s3.amazonaws.com --api "s3v4" --lookup "dns"
      {{.EnableHistory}}
+
+  6. Add Amazon S3 storage service under "mys3" alias using the "backup" profile of ~/.aws/credentials.
+     {{.Prompt}} {{.HelpName}} mys3 https://s3.amazonaws.com --credentials-source aws-profile --profile backup
+
+  7. Add MinIO service under "myminio" alias using temporary credentials from STS AssumeRoleWithWebIdentity.
+     {{.Prompt}} {{.HelpName}} myminio https://minio.example.com --credentials-source sts-web-identity \
+                 --credentials-endpoint https://minio.example.com --token-file /var/run/secrets/token
+
+  8. Add MinIO service under "myminio" alias using credentials printed by an external command.
+     {{.Prompt}} {{.HelpName}} myminio https://minio.example.com --credentials-source process \
+                 --credential-process "vault-creds minio"
 `,
 }
 
@@ -136,25 +177,80 @@ func checkConfigHostAddSyntax(ctx *cli.Context, accessKey string, secretKey stri
 	}
 }
 
+// getCredentialsConfig returns the credentials source requested for
+// 'config host add', nil for static keys.
+func getCredentialsConfig(ctx *cli.Context) *credentialsConfigV10 {
+	credsCfg := &credentialsConfigV10{
+		Source:          strings.ToLower(ctx.String("credentials-source")),
+		Profile:         ctx.String("profile"),
+		File:            ctx.String("credentials-file"),
+		Endpoint:        ctx.String("credentials-endpoint"),
+		TokenFile:       ctx.String("token-file"),
+		DurationSeconds: ctx.Int("token-duration"),
+		Command:         ctx.String("credential-process"),
+	}
+	if credsCfg.isStatic() {
+		return nil
+	}
+	return credsCfg
+}
+
+// checkConfigHostAddCredentialsSyntax - verifies input arguments to 'config host add'
+// when credentials are not static.
+func checkConfigHostAddCredentialsSyntax(ctx *cli.Context, credsCfg *credentialsConfigV10) {
+	args := ctx.Args()
+	if len(args) != 2 {
+		fatalIf(errInvalidArgument().Trace(args.Tail()...),
+			"Access and secret keys cannot be specified with `--credentials-source`.")
+	}
+
+	alias := cleanAlias(args.Get(0))
+	if !isValidAlias(alias) {
+		fatalIf(errInvalidAlias(alias), "Invalid alias.")
+	}
+
+	if url := args.Get(1); !isValidHostURL(url) {
+		fatalIf(errInvalidURL(url), "Invalid URL.")
+	}
+
+	if api := ctx.String("api"); api != "" && !strings.EqualFold(api, "S3v4") {
+		fatalIf(errInvalidArgument().Trace(api),
+			"Only `S3v4` API signature is supported with `--credentials-source`.")
+	}
+
+	if bucketLookup := ctx.String("lookup"); !isValidLookup(bucketLookup) {
+		fatalIf(errInvalidArgument().Trace(bucketLookup),
+			"Unrecognized bucket lookup. Valid options are `[dns,auto, path]`.")
+	}
+
+	fatalIf(credsCfg.validate(), "Invalid credentials source `"+credsCfg.Source+"`. Valid options are `["+
+		strings.Join(validCredentialsSources, ", ")+"]`, STS sources require an endpoint and a token file, "+
+		"`process` requires a command.")
+}
+
 // addHost - add a host config.
-func addHost(alias string, hostCfgV9 hostConfigV9) {
-	mcCfgV9, err := loadMcConfig()
+func addHost(alias string, hostCfgV10 hostConfigV10) {
+	mcCfgV10, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
 
-	// Add new host.
-	mcCfgV9.Hosts[alias] = hostCfgV9
+	// Add new host, secrets are stored encrypted when the config is encrypted.
+	storedHostCfg := hostCfgV10
+	fatalIf(mcCfgV10.encryptHost(&storedHostCfg).Trace(alias), "Unable to encrypt secrets of `"+alias+"`.")
+	mcCfgV10.Hosts[alias] = storedHostCfg
 
-	err = saveMcConfig(mcCfgV9)
+	err = saveMcConfig(mcCfgV10)
 	fatalIf(err.Trace(alias), "Unable to update hosts in config version `"+mustGetMcConfigPath()+"`.")
 
 	printMsg(hostMessage{
 		op:        "add",
 		Alias:     alias,
-		URL:       hostCfgV9.URL,
-		AccessKey: hostCfgV9.AccessKey,
-		SecretKey: hostCfgV9.SecretKey,
-		API:       hostCfgV9.API,
-		Lookup:    hostCfgV9.Lookup,
+		URL:       hostCfgV10.URL,
+		AccessKey: hostCfgV10.AccessKey,
+		SecretKey: hostCfgV10.SecretKey,
+		API:       hostCfgV10.API,
+		Lookup:    hostCfgV10.Lookup,
+
+		CredentialsSource: credentialsSource(hostCfgV10.Credentials),
 	})
 }
 
@@ -212,7 +308,7 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (st
 // signature auto-probe when needed.
 func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, lookup string) (*Config, *probe.Error) {
 
-	s3Config := NewS3Config(url, &hostConfigV9{
+	s3Config := NewS3Config(url, &hostConfigV10{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 		URL:       url,
@@ -283,6 +379,20 @@ func mainConfigHostAdd(cli *cli.Context) error {
 		api    = cli.String("api")
 		lookup = cli.String("lookup")
 	)
+
+	if credsCfg := getCredentialsConfig(cli); credsCfg != nil {
+		checkConfigHostAddCredentialsSyntax(cli, credsCfg)
+		// Keys are only known once retrieved from the source,
+		// signature probing is not possible.
+		addHost(alias, hostConfigV10{
+			URL:         url,
+			API:         "S3v4",
+			Lookup:      lookup,
+			Credentials: credsCfg,
+		})
+		return nil
+	}
+
 	accessKey, secretKey := fetchHostKeys(args)
 	checkConfigHostAddSyntax(cli, accessKey, secretKey)
 
@@ -292,7 +402,7 @@ func mainConfigHostAdd(cli *cli.Context) error {
 	s3Config, err := BuildS3Config(ctx, url, accessKey, secretKey, api, lookup)
 	fatalIf(err.Trace(cli.Args()...), "Unable to initialize new config from the provided credentials.")
 
-	addHost(alias, hostConfigV9{
+	addHost(alias, hostConfigV10{
 		URL:       s3Config.HostURL,
 		AccessKey: s3Config.AccessKey,
 		SecretKey: s3Config.SecretKey,
@@ -25,13 +25,20 @@ import (
 	"github.com/minio/minio/pkg/console"
 )
 
+var configHostListFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "show-secrets",
+		Usage: "show secret keys instead of masking them",
+	},
+}
+
 var configHostListCmd = cli.Command{
 	Name:            "list",
 	ShortName:       "ls",
 	Usage:           "list hosts in configuration file",
 	Action:          mainConfigHostList,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(configHostListFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -48,6 +55,9 @@ EXAMPLES:
 
   2. List a specific host.
      {{.Prompt}} {{.HelpName}} s3
+
+  3. List a specific host with its secret key, decrypted if the configuration file is encrypted.
+     {{.Prompt}} {{.HelpName}} --show-secrets s3
 `,
 }
 
@@ -71,10 +81,11 @@ func mainConfigHostList(ctx *cli.Context) error {
 	console.SetColor("SecretKey", color.New(color.FgCyan))
 	console.SetColor("API", color.New(color.FgBlue))
 	console.SetColor("Lookup", color.New(color.FgCyan))
+	console.SetColor("Credentials", color.New(color.FgCyan))
 
 	alias := cleanAlias(ctx.Args().Get(0))
 
-	listHosts(alias) // List all configured hosts.
+	listHosts(alias, ctx.Bool("show-secrets")) // List all configured hosts.
 	return nil
 }
 
@@ -107,14 +118,28 @@ func (d byAlias) Len() int           { return len(d) }
 func (d byAlias) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
 func (d byAlias) Less(i, j int) bool { return d[i].Alias < d[j].Alias }
 
+// hostSecretKey returns the secret key of host to be displayed, masked
+// unless showSecrets is set.
+func hostSecretKey(conf *configV10, alias string, host hostConfigV10, showSecrets bool) string {
+	if host.SecretKey == "" {
+		return ""
+	}
+	if !showSecrets {
+		return "********"
+	}
+	fatalIf(conf.decryptHost(&host).Trace(alias), "Unable to decrypt secrets of `"+alias+"`.")
+	return host.SecretKey
+}
+
 // listHosts - list all host URLs or a requested host.
-func listHosts(alias string) {
+func listHosts(alias string, showSecrets bool) {
 	conf, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
 
 	// If specific alias is requested, look for it and print.
 	if alias != "" {
 		if v, ok := conf.Hosts[alias]; ok {
+			v.SecretKey = hostSecretKey(conf, alias, v, showSecrets)
 			printHosts(hostMessage{
 				op:          "list",
 				prettyPrint: false,
@@ -124,6 +149,8 @@ func listHosts(alias string) {
 				SecretKey:   v.SecretKey,
 				API:         v.API,
 				Lookup:      v.Lookup,
+
+				CredentialsSource: credentialsSource(v.Credentials),
 			})
 			return
 		}
@@ -132,6 +159,7 @@ func listHosts(alias string) {
 
 	var hosts []hostMessage
 	for k, v := range conf.Hosts {
+		v.SecretKey = hostSecretKey(conf, k, v, showSecrets)
 		hosts = append(hosts, hostMessage{
 			op:          "list",
 			prettyPrint: true,
@@ -141,6 +169,8 @@ func listHosts(alias string) {
 			SecretKey:   v.SecretKey,
 			API:         v.API,
 			Lookup:      v.Lookup,
+
+			CredentialsSource: credentialsSource(v.Credentials),
 		})
 	}
 
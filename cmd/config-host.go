@@ -55,6 +55,8 @@ type hostMessage struct {
 	SecretKey   string `json:"secretKey,omitempty"`
 	API         string `json:"api,omitempty"`
 	Lookup      string `json:"lookup,omitempty"`
+
+	CredentialsSource string `json:"credentialsSource,omitempty"`
 }
 
 // Print the config information of one alias, when prettyPrint flag
@@ -72,6 +74,17 @@ func (h hostMessage) String() string {
 			Row{"API", "API"},
 			Row{"Lookup", "Lookup"},
 		)
+		if h.CredentialsSource != "" {
+			// Keys are obtained from an external source.
+			t = newPrettyRecord(2,
+				Row{"Alias", "Alias"},
+				Row{"URL", "URL"},
+				Row{"Credentials", "Credentials"},
+				Row{"API", "API"},
+				Row{"Lookup", "Lookup"},
+			)
+			return t.buildRecord(h.Alias, h.URL, h.CredentialsSource, h.API, h.Lookup)
+		}
 		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, h.Lookup)
 	case "remove":
 		return console.Colorize("HostMessage", "Removed `"+h.Alias+"` successfully.")
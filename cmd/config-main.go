@@ -42,6 +42,8 @@ var configCmd = cli.Command{
 	Flags:           append(configFlags, globalFlags...),
 	Subcommands: []cli.Command{
 		configHostCmd,
+		configEncryptCmd,
+		configDecryptCmd,
 	},
 }
 
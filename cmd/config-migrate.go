@@ -45,6 +45,8 @@ func migrateConfig() {
 	migrateConfigV7ToV8()
 	// Migrate config V8 to V9
 	migrateConfigV8ToV9()
+	// Migrate config V9 to V10
+	migrateConfigV9ToV10()
 }
 
 // Migrate from config version 1.0 to 1.0.1. Populate example entries and save it back.
@@ -482,3 +484,39 @@ func migrateConfigV8ToV9() {
 
 	console.Infof("Successfully migrated %s from version `8` to version `9`.\n", mustGetMcConfigPath())
 }
+
+// Migrate config version `9` to `10`. Add optional encryption of
+// secrets, existing secrets are kept in plain text.
+func migrateConfigV9ToV10() {
+	if !isMcConfigExists() {
+		return
+	}
+
+	mcCfgV9, e := quick.LoadConfig(mustGetMcConfigPath(), nil, newConfigV9())
+	fatalIf(probe.NewError(e), "Unable to load mc config V9.")
+
+	if mcCfgV9.Version() != "9" {
+		return
+	}
+
+	cfgV10 := newConfigV10()
+	for host, hostCfgV9 := range mcCfgV9.Data().(*configV9).Hosts {
+		cfgV10.Hosts[host] = hostConfigV10{
+			URL:          hostCfgV9.URL,
+			AccessKey:    hostCfgV9.AccessKey,
+			SecretKey:    hostCfgV9.SecretKey,
+			SessionToken: hostCfgV9.SessionToken,
+			API:          hostCfgV9.API,
+			Lookup:       hostCfgV9.Lookup,
+			Credentials:  (*credentialsConfigV10)(hostCfgV9.Credentials),
+		}
+	}
+
+	mcNewCfgV10, e := quick.NewConfig(cfgV10, nil)
+	fatalIf(probe.NewError(e), "Unable to initialize quick config for config version `10`.")
+
+	e = mcNewCfgV10.Save(mustGetMcConfigPath())
+	fatalIf(probe.NewError(e), "Unable to save config version `10`.")
+
+	console.Infof("Successfully migrated %s from version `9` to version `10`.\n", mustGetMcConfigPath())
+}
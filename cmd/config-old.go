@@ -305,4 +305,90 @@ func (c *configV8) loadDefaults() {
 }
 
 /////////////////// Config V9 ///////////////////
+// hostConfig configuration of a host.
+type hostConfigV9 struct {
+	URL          string `json:"url"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	SessionToken string `json:"sessionToken,omitempty"`
+	API          string `json:"api"`
+	Lookup       string `json:"lookup"`
+
+	// Optional source of credentials, static keys above when unset.
+	Credentials *credentialsConfigV9 `json:"credentials,omitempty"`
+}
+
+// credentialsConfigV9 selects where the credentials of an alias are
+// obtained from, only the fields relevant to Source are used.
+type credentialsConfigV9 struct {
+	Source          string `json:"source"`
+	Profile         string `json:"profile,omitempty"`
+	File            string `json:"file,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	TokenFile       string `json:"tokenFile,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	Command         string `json:"command,omitempty"`
+}
+
+// configV9 config version.
+type configV9 struct {
+	Version string                  `json:"version"`
+	Hosts   map[string]hostConfigV9 `json:"hosts"`
+}
+
+// newConfigV9 - new config version.
+func newConfigV9() *configV9 {
+	cfg := new(configV9)
+	cfg.Version = "9"
+	cfg.Hosts = make(map[string]hostConfigV9)
+	return cfg
+}
+
+// SetHost sets host config if not empty.
+func (c *configV9) setHost(alias string, cfg hostConfigV9) {
+	if _, ok := c.Hosts[alias]; !ok {
+		c.Hosts[alias] = cfg
+	}
+}
+
+// load default values for missing entries.
+func (c *configV9) loadDefaults() {
+	// MinIO server running locally.
+	c.setHost("local", hostConfigV9{
+		URL:       "http://localhost:9000",
+		AccessKey: "",
+		SecretKey: "",
+		API:       "S3v4",
+		Lookup:    "auto",
+	})
+
+	// Amazon S3 cloud storage service.
+	c.setHost("s3", hostConfigV9{
+		URL:       "https://s3.amazonaws.com",
+		AccessKey: defaultAccessKey,
+		SecretKey: defaultSecretKey,
+		API:       "S3v4",
+		Lookup:    "dns",
+	})
+
+	// Google cloud storage service.
+	c.setHost("gcs", hostConfigV9{
+		URL:       "https://storage.googleapis.com",
+		AccessKey: defaultAccessKey,
+		SecretKey: defaultSecretKey,
+		API:       "S3v2",
+		Lookup:    "dns",
+	})
+
+	// MinIO anonymous server for demo.
+	c.setHost("play", hostConfigV9{
+		URL:       "https://play.min.io",
+		AccessKey: "Q3AM3UQ867SPQQA43P2F",
+		SecretKey: "zuf+tfteSlswRu7BJ86wekitnifILbZam1KYY3TG",
+		API:       "S3v4",
+		Lookup:    "auto",
+	})
+}
+
+/////////////////// Config V10 ///////////////////
 // RESERVED FOR FUTURE
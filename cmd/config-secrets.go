@@ -0,0 +1,257 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// Environment variables holding the passphrase of an encrypted
+	// config, or the path of a file holding it.
+	mcEnvConfigPassphrase = "MC_CONFIG_PASSPHRASE"
+	mcEnvConfigKeyFile    = "MC_CONFIG_KEY_FILE"
+
+	// Secrets are encrypted with AES-256-GCM, the key is derived from
+	// the passphrase with Argon2id.
+	configEncryptionAlgorithm = "argon2id-aes256gcm"
+
+	// Prefix of encrypted secrets in the config file.
+	encryptedSecretPrefix = "encrypted:"
+
+	// Value encrypted in the 'check' field of the encryption config.
+	configCheckValue = "mc"
+
+	configSaltSize = 32
+)
+
+// configKey caches the key derived from the passphrase, the
+// derivation is expensive and the passphrase is asked only once.
+var configKey = struct {
+	sync.Mutex
+	salt string
+	key  []byte
+}{}
+
+// readConfigPassphrase returns the passphrase of the config from the
+// environment, the key file or the terminal, in that order.
+func readConfigPassphrase(confirm bool) ([]byte, *probe.Error) {
+	if passphrase, ok := os.LookupEnv(mcEnvConfigPassphrase); ok {
+		return []byte(passphrase), nil
+	}
+	if keyFile, ok := os.LookupEnv(mcEnvConfigKeyFile); ok {
+		passphrase, e := ioutil.ReadFile(keyFile)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(keyFile)
+		}
+		return bytes.TrimRight(passphrase, "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil, probe.NewError(fmt.Errorf("config is encrypted, set %s or %s",
+			mcEnvConfigPassphrase, mcEnvConfigKeyFile))
+	}
+	fmt.Print("Enter config passphrase: ")
+	passphrase, e := terminal.ReadPassword(fd)
+	fmt.Println()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if confirm {
+		fmt.Print("Confirm config passphrase: ")
+		confirmation, e := terminal.ReadPassword(fd)
+		fmt.Println()
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		if !bytes.Equal(passphrase, confirmation) {
+			return nil, probe.NewError(errors.New("passphrases do not match"))
+		}
+	}
+	if len(passphrase) == 0 {
+		return nil, probe.NewError(errors.New("passphrase cannot be empty"))
+	}
+	return passphrase, nil
+}
+
+// deriveConfigKey derives the encryption key from the passphrase.
+func deriveConfigKey(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, 1, 64*1024, 4, 32)
+}
+
+// newEncryptionConfig initializes a new encryption config protected by
+// passphrase, its key is cached for the following operations.
+func newEncryptionConfig(passphrase []byte) (*encryptionConfigV10, *probe.Error) {
+	salt := make([]byte, configSaltSize)
+	if _, e := io.ReadFull(rand.Reader, salt); e != nil {
+		return nil, probe.NewError(e)
+	}
+	key := deriveConfigKey(passphrase, salt)
+	check, err := encryptSecret(key, configCheckValue)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	encCfg := &encryptionConfigV10{
+		Algorithm: configEncryptionAlgorithm,
+		Salt:      base64.StdEncoding.EncodeToString(salt),
+		Check:     check,
+	}
+
+	configKey.Lock()
+	configKey.salt, configKey.key = encCfg.Salt, key
+	configKey.Unlock()
+	return encCfg, nil
+}
+
+// key returns the key of the encryption config, asking for the
+// passphrase if it was not used before.
+func (c *encryptionConfigV10) key() ([]byte, *probe.Error) {
+	configKey.Lock()
+	defer configKey.Unlock()
+
+	if configKey.key != nil && configKey.salt == c.Salt {
+		return configKey.key, nil
+	}
+	if c.Algorithm != configEncryptionAlgorithm {
+		return nil, probe.NewError(fmt.Errorf("unsupported config encryption algorithm `%s`", c.Algorithm))
+	}
+	salt, e := base64.StdEncoding.DecodeString(c.Salt)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	passphrase, err := readConfigPassphrase(false)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	key := deriveConfigKey(passphrase, salt)
+	if check, err := decryptSecret(key, c.Check); err != nil || check != configCheckValue {
+		return nil, probe.NewError(errors.New("incorrect config passphrase"))
+	}
+	configKey.salt, configKey.key = c.Salt, key
+	return key, nil
+}
+
+// isEncryptedSecret returns true if secret is stored encrypted.
+func isEncryptedSecret(secret string) bool {
+	return strings.HasPrefix(secret, encryptedSecretPrefix)
+}
+
+// encryptSecret encrypts secret with key.
+func encryptSecret(key []byte, secret string) (string, *probe.Error) {
+	aead, err := newConfigAEAD(key)
+	if err != nil {
+		return "", err.Trace()
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, e := io.ReadFull(rand.Reader, nonce); e != nil {
+		return "", probe.NewError(e)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedSecretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret decrypts a secret encrypted by encryptSecret.
+func decryptSecret(key []byte, secret string) (string, *probe.Error) {
+	if !isEncryptedSecret(secret) {
+		return "", errInvalidArgument().Trace()
+	}
+	sealed, e := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, encryptedSecretPrefix))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	aead, err := newConfigAEAD(key)
+	if err != nil {
+		return "", err.Trace()
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", probe.NewError(errors.New("encrypted secret is truncated"))
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, e := aead.Open(nil, nonce, ciphertext, nil)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(plaintext), nil
+}
+
+func newConfigAEAD(key []byte) (cipher.AEAD, *probe.Error) {
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	aead, e := cipher.NewGCM(block)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return aead, nil
+}
+
+// encryptHost encrypts the secrets of host if the config is encrypted.
+func (c *configV10) encryptHost(host *hostConfigV10) *probe.Error {
+	if c.Encryption == nil {
+		return nil
+	}
+	key, err := c.Encryption.key()
+	if err != nil {
+		return err.Trace()
+	}
+	for _, secret := range []*string{&host.SecretKey, &host.SessionToken} {
+		if *secret == "" || isEncryptedSecret(*secret) {
+			continue
+		}
+		if *secret, err = encryptSecret(key, *secret); err != nil {
+			return err.Trace()
+		}
+	}
+	return nil
+}
+
+// decryptHost decrypts the secrets of host if they are encrypted.
+func (c *configV10) decryptHost(host *hostConfigV10) *probe.Error {
+	for _, secret := range []*string{&host.SecretKey, &host.SessionToken} {
+		if !isEncryptedSecret(*secret) {
+			continue
+		}
+		if c.Encryption == nil {
+			return probe.NewError(errors.New("encrypted secret found in an unencrypted config"))
+		}
+		key, err := c.Encryption.key()
+		if err != nil {
+			return err.Trace()
+		}
+		if *secret, err = decryptSecret(key, *secret); err != nil {
+			return err.Trace()
+		}
+	}
+	return nil
+}
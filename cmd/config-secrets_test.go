@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	key := deriveConfigKey([]byte("passphrase"), []byte("salt-of-the-config"))
+	otherKey := deriveConfigKey([]byte("other passphrase"), []byte("salt-of-the-config"))
+
+	testCases := []string{"", "zuf+tfteSlswRu7BJ86wekitnifILbZam1KYY3TG", "with spaces and ünicode"}
+	for i, secret := range testCases {
+		encrypted, err := encryptSecret(key, secret)
+		if err != nil {
+			t.Fatalf("Test %d: unable to encrypt secret: %s", i+1, err)
+		}
+		if !isEncryptedSecret(encrypted) {
+			t.Fatalf("Test %d: expected encrypted secret, got %s", i+1, encrypted)
+		}
+		decrypted, err := decryptSecret(key, encrypted)
+		if err != nil {
+			t.Fatalf("Test %d: unable to decrypt secret: %s", i+1, err)
+		}
+		if decrypted != secret {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, secret, decrypted)
+		}
+		if _, err = decryptSecret(otherKey, encrypted); err == nil {
+			t.Fatalf("Test %d: expected decryption with another key to fail", i+1)
+		}
+	}
+}
+
+func TestConfigEncryptHost(t *testing.T) {
+	os.Setenv(mcEnvConfigPassphrase, "passphrase")
+	defer os.Unsetenv(mcEnvConfigPassphrase)
+
+	conf := newConfigV10()
+	encCfg, err := newEncryptionConfig([]byte("passphrase"))
+	if err != nil {
+		t.Fatalf("Unable to initialize config encryption: %s", err)
+	}
+	conf.Encryption = encCfg
+
+	host := hostConfigV10{AccessKey: "access", SecretKey: "secret", SessionToken: "token"}
+	if err = conf.encryptHost(&host); err != nil {
+		t.Fatalf("Unable to encrypt host: %s", err)
+	}
+	if host.AccessKey != "access" || !isEncryptedSecret(host.SecretKey) || !isEncryptedSecret(host.SessionToken) {
+		t.Fatalf("Unexpected encrypted host: %#v", host)
+	}
+	if err = conf.decryptHost(&host); err != nil {
+		t.Fatalf("Unable to decrypt host: %s", err)
+	}
+	if host.SecretKey != "secret" || host.SessionToken != "token" {
+		t.Fatalf("Unexpected decrypted host: %#v", host)
+	}
+}
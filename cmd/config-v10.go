@@ -30,46 +30,71 @@ const (
 
 var (
 	// set once during first load.
-	cacheCfgV9 *configV9
+	cacheCfgV10 *configV10
 	// All access to mc config file should be synchronized.
 	cfgMutex = &sync.RWMutex{}
 )
 
 // hostConfig configuration of a host.
-type hostConfigV9 struct {
+type hostConfigV10 struct {
 	URL          string `json:"url"`
 	AccessKey    string `json:"accessKey"`
 	SecretKey    string `json:"secretKey"`
 	SessionToken string `json:"sessionToken,omitempty"`
 	API          string `json:"api"`
 	Lookup       string `json:"lookup"`
+
+	// Optional source of credentials, static keys above when unset.
+	Credentials *credentialsConfigV10 `json:"credentials,omitempty"`
+}
+
+// credentialsConfigV10 selects where the credentials of an alias are
+// obtained from, only the fields relevant to Source are used.
+type credentialsConfigV10 struct {
+	Source          string `json:"source"`
+	Profile         string `json:"profile,omitempty"`
+	File            string `json:"file,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	TokenFile       string `json:"tokenFile,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	Command         string `json:"command,omitempty"`
+}
+
+// encryptionConfigV10 describes how secrets of the hosts are encrypted.
+type encryptionConfigV10 struct {
+	Algorithm string `json:"algorithm"`
+	Salt      string `json:"salt"`
+	// Check is a known value encrypted with the same key, used
+	// to verify the passphrase before decrypting any secret.
+	Check string `json:"check"`
 }
 
-// configV8 config version.
-type configV9 struct {
-	Version string                  `json:"version"`
-	Hosts   map[string]hostConfigV9 `json:"hosts"`
+// configV10 config version.
+type configV10 struct {
+	Version    string                   `json:"version"`
+	Encryption *encryptionConfigV10     `json:"encryption,omitempty"`
+	Hosts      map[string]hostConfigV10 `json:"hosts"`
 }
 
-// newConfigV9 - new config version.
-func newConfigV9() *configV9 {
-	cfg := new(configV9)
+// newConfigV10 - new config version.
+func newConfigV10() *configV10 {
+	cfg := new(configV10)
 	cfg.Version = globalMCConfigVersion
-	cfg.Hosts = make(map[string]hostConfigV9)
+	cfg.Hosts = make(map[string]hostConfigV10)
 	return cfg
 }
 
 // SetHost sets host config if not empty.
-func (c *configV9) setHost(alias string, cfg hostConfigV9) {
+func (c *configV10) setHost(alias string, cfg hostConfigV10) {
 	if _, ok := c.Hosts[alias]; !ok {
 		c.Hosts[alias] = cfg
 	}
 }
 
 // load default values for missing entries.
-func (c *configV9) loadDefaults() {
+func (c *configV10) loadDefaults() {
 	// MinIO server running locally.
-	c.setHost("local", hostConfigV9{
+	c.setHost("local", hostConfigV10{
 		URL:       "http://localhost:9000",
 		AccessKey: "",
 		SecretKey: "",
@@ -78,7 +103,7 @@ func (c *configV9) loadDefaults() {
 	})
 
 	// Amazon S3 cloud storage service.
-	c.setHost("s3", hostConfigV9{
+	c.setHost("s3", hostConfigV10{
 		URL:       "https://s3.amazonaws.com",
 		AccessKey: defaultAccessKey,
 		SecretKey: defaultSecretKey,
@@ -87,7 +112,7 @@ func (c *configV9) loadDefaults() {
 	})
 
 	// Google cloud storage service.
-	c.setHost("gcs", hostConfigV9{
+	c.setHost("gcs", hostConfigV10{
 		URL:       "https://storage.googleapis.com",
 		AccessKey: defaultAccessKey,
 		SecretKey: defaultSecretKey,
@@ -96,7 +121,7 @@ func (c *configV9) loadDefaults() {
 	})
 
 	// MinIO anonymous server for demo.
-	c.setHost("play", hostConfigV9{
+	c.setHost("play", hostConfigV10{
 		URL:       "https://play.min.io",
 		AccessKey: "Q3AM3UQ867SPQQA43P2F",
 		SecretKey: "zuf+tfteSlswRu7BJ86wekitnifILbZam1KYY3TG",
@@ -105,14 +130,14 @@ func (c *configV9) loadDefaults() {
 	})
 }
 
-// loadConfigV9 - loads a new config.
-func loadConfigV9() (*configV9, *probe.Error) {
+// loadConfigV10 - loads a new config.
+func loadConfigV10() (*configV10, *probe.Error) {
 	cfgMutex.RLock()
 	defer cfgMutex.RUnlock()
 
 	// If already cached, return the cached value.
-	if cacheCfgV9 != nil {
-		return cacheCfgV9, nil
+	if cacheCfgV10 != nil {
+		return cacheCfgV10, nil
 	}
 
 	if !isMcConfigExists() {
@@ -120,7 +145,7 @@ func loadConfigV9() (*configV9, *probe.Error) {
 	}
 
 	// Initialize a new config loader.
-	qc, e := quick.NewConfig(newConfigV9(), nil)
+	qc, e := quick.NewConfig(newConfigV10(), nil)
 	if e != nil {
 		return nil, probe.NewError(e)
 	}
@@ -131,27 +156,27 @@ func loadConfigV9() (*configV9, *probe.Error) {
 		return nil, probe.NewError(e)
 	}
 
-	cfgV9 := qc.Data().(*configV9)
+	cfgV10 := qc.Data().(*configV10)
 
 	// Cache config.
-	cacheCfgV9 = cfgV9
+	cacheCfgV10 = cfgV10
 
 	// Success.
-	return cfgV9, nil
+	return cfgV10, nil
 }
 
-// saveConfigV8 - saves an updated config.
-func saveConfigV9(cfgV9 *configV9) *probe.Error {
+// saveConfigV10 - saves an updated config.
+func saveConfigV10(cfgV10 *configV10) *probe.Error {
 	cfgMutex.Lock()
 	defer cfgMutex.Unlock()
 
-	qs, e := quick.NewConfig(cfgV9, nil)
+	qs, e := quick.NewConfig(cfgV10, nil)
 	if e != nil {
 		return probe.NewError(e)
 	}
 
 	// update the cache.
-	cacheCfgV9 = cfgV9
+	cacheCfgV10 = cfgV10
 
 	e = qs.Save(mustGetMcConfigPath())
 	if e != nil {
@@ -22,7 +22,7 @@ import (
 )
 
 // Check if version of the config is valid
-func validateConfigVersion(config *configV9) (bool, string) {
+func validateConfigVersion(config *configV10) (bool, string) {
 	if config.Version != globalMCConfigVersion {
 		return false, fmt.Sprintf("Config version '%s' does not match mc config version '%s', please update your binary.\n",
 			config.Version, globalMCConfigVersion)
@@ -31,7 +31,7 @@ func validateConfigVersion(config *configV9) (bool, string) {
 }
 
 // Verifies the config file of the MinIO Client
-func validateConfigFile(config *configV9) (bool, []string) {
+func validateConfigFile(config *configV10) (bool, []string) {
 	ok, err := validateConfigVersion(config)
 	var validationSuccessful = true
 	var errors []string
@@ -50,13 +50,18 @@ func validateConfigFile(config *configV9) (bool, []string) {
 	return validationSuccessful, errors
 }
 
-func validateConfigHost(host hostConfigV9) (bool, []string) {
+func validateConfigHost(host hostConfigV10) (bool, []string) {
 	var validationSuccessful = true
 	var hostErrors []string
 	if !isValidAPI(strings.ToLower(host.API)) {
 		validationSuccessful = false
 		hostErrors = append(hostErrors, errInvalidAPISignature(host.API, host.URL).ToGoError().Error())
 	}
+	if !host.Credentials.isStatic() && strings.EqualFold(host.API, "S3v2") {
+		// Only static keys can sign with V2.
+		validationSuccessful = false
+		hostErrors = append(hostErrors, errCredentialsSourceSignature(host.API, host.URL, host.Credentials.Source).ToGoError().Error())
+	}
 	if !isValidHostURL(host.URL) {
 		validationSuccessful = false
 		hostErrors = append(hostErrors, errInvalidURL(host.URL).ToGoError().Error())
@@ -105,29 +105,29 @@ func mustGetMcConfigPath() string {
 	return path
 }
 
-// newMcConfig - initializes a new version '9' config.
-func newMcConfig() *configV9 {
-	cfg := newConfigV9()
+// newMcConfig - initializes a new version '10' config.
+func newMcConfig() *configV10 {
+	cfg := newConfigV10()
 	cfg.loadDefaults()
 	return cfg
 }
 
 // loadMcConfigCached - returns loadMcConfig with a closure for config cache.
-func loadMcConfigFactory() func() (*configV9, *probe.Error) {
+func loadMcConfigFactory() func() (*configV10, *probe.Error) {
 	// Load once and cache in a closure.
-	cfgCache, err := loadConfigV9()
+	cfgCache, err := loadConfigV10()
 
 	// loadMcConfig - reads configuration file and returns config.
-	return func() (*configV9, *probe.Error) {
+	return func() (*configV10, *probe.Error) {
 		return cfgCache, err
 	}
 }
 
 // loadMcConfig - returns configuration, initialized later.
-var loadMcConfig func() (*configV9, *probe.Error)
+var loadMcConfig func() (*configV10, *probe.Error)
 
 // saveMcConfig - saves configuration file and returns error if any.
-func saveMcConfig(config *configV9) *probe.Error {
+func saveMcConfig(config *configV10) *probe.Error {
 	if config == nil {
 		return errInvalidArgument().Trace()
 	}
@@ -138,7 +138,7 @@ func saveMcConfig(config *configV9) *probe.Error {
 	}
 
 	// Save the config.
-	if err := saveConfigV9(config); err != nil {
+	if err := saveConfigV10(config); err != nil {
 		return err.Trace(mustGetMcConfigPath())
 	}
 
@@ -173,7 +173,7 @@ func isValidAlias(alias string) bool {
 }
 
 // getHostConfig retrieves host specific configuration such as access keys, signature type.
-func getHostConfig(alias string) (*hostConfigV9, *probe.Error) {
+func getHostConfig(alias string) (*hostConfigV10, *probe.Error) {
 	mcCfg, err := loadMcConfig()
 	if err != nil {
 		return nil, err.Trace(alias)
@@ -190,8 +190,12 @@ func getHostConfig(alias string) (*hostConfigV9, *probe.Error) {
 }
 
 // mustGetHostConfig retrieves host specific configuration such as access keys, signature type.
-func mustGetHostConfig(alias string) *hostConfigV9 {
+func mustGetHostConfig(alias string) *hostConfigV10 {
 	hostCfg, _ := getHostConfig(alias)
+	if hostCfg != nil {
+		mcCfg, _ := loadMcConfig()
+		fatalIf(mcCfg.decryptHost(hostCfg).Trace(alias), "Unable to decrypt secrets of `"+alias+"`.")
+	}
 	// If alias is not found,
 	// look for it in the environment variable.
 	if hostCfg == nil {
@@ -265,13 +269,13 @@ const (
 	mcEnvHostsDeprecatedPrefix = "MC_HOSTS_"
 )
 
-func expandAliasFromEnv(envURL string) (*hostConfigV9, *probe.Error) {
+func expandAliasFromEnv(envURL string) (*hostConfigV10, *probe.Error) {
 	u, accessKey, secretKey, sessionToken, err := parseEnvURLStr(envURL)
 	if err != nil {
 		return nil, err.Trace(envURL)
 	}
 
-	return &hostConfigV9{
+	return &hostConfigV10{
 		URL:          u.String(),
 		API:          "S3v4",
 		AccessKey:    accessKey,
@@ -281,7 +285,7 @@ func expandAliasFromEnv(envURL string) (*hostConfigV9, *probe.Error) {
 }
 
 // expandAlias expands aliased URL if any match is found, returns as is otherwise.
-func expandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV9, err *probe.Error) {
+func expandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV10, err *probe.Error) {
 	// Extract alias from the URL.
 	alias, path := url2Alias(aliasedURL)
 
@@ -311,7 +315,7 @@ func expandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostC
 }
 
 // mustExpandAlias expands aliased URL if any match is found, returns as is otherwise.
-func mustExpandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV9) {
+func mustExpandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV10) {
 	alias, urlStr, hostCfg, _ = expandAlias(aliasedURL)
 	return alias, urlStr, hostCfg
 }
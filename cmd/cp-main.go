@@ -30,6 +30,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
 )
@@ -49,6 +50,14 @@ var (
 			Name:  "newer-than",
 			Usage: "copy objects newer than L days, M hours and N minutes",
 		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "roll back object(s) to current version at specified time",
+		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "select an object version to copy",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object(s) on target",
@@ -77,6 +86,10 @@ var (
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
 		},
+		cli.StringFlag{
+			Name:  "verify",
+			Usage: "verify copied object(s) against a streaming checksum of the source (md5, sha256, crc32c)",
+		},
 		cli.StringFlag{
 			Name:  rmFlag,
 			Usage: "retention mode to be applied on the object (governance, compliance)",
@@ -105,7 +118,7 @@ var cpCmd = cli.Command{
 	Usage:  "copy objects",
 	Action: mainCopy,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(append(append(cpFlags, filterFlags...), symlinkFlags...), limitFlags...), multipartFlags...), workerFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -174,6 +187,34 @@ EXAMPLES:
 
   18. Copy a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
+
+  19. Roll back 10 days in the past to copy the content of 'mybucket'
+      {{.Prompt}} {{.HelpName}} --rewind 10d -r play/mybucket/ /tmp/dest/
+
+  20. Copy a specific object version to a local path
+      {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/mybucket/object /tmp/object
+
+  21. Copy a folder recursively from MinIO cloud storage to a local path while limiting downloads to 10MiB/s.
+      {{.Prompt}} {{.HelpName}} --recursive --limit-download 10MiB play/mybucket/burningman2011/ ~/burningman2011/
+
+  22. Copy a folder recursively to MinIO cloud storage and verify each object with a SHA256 checksum.
+      {{.Prompt}} {{.HelpName}} --recursive --verify sha256 backup/ play/mybucket/backup/
+
+  23. Copy a folder recursively to MinIO cloud storage, skipping the files matched by the rules of '.mcignore'.
+      {{.Prompt}} {{.HelpName}} --recursive --exclude-from project/.mcignore project/ play/mybucket/project/
+
+  24. Copy a large file to Amazon S3 cloud storage over a high-latency link, tuning the part size from the
+      measured throughput and uploading 16 parts in parallel.
+      {{.Prompt}} {{.HelpName}} --part-size auto --parallel-parts 16 backup.tar s3/mybucket/
+
+  25. Copy a folder out of a tar.gz archive stored on Amazon S3 cloud storage, without downloading and unpacking
+      the archive. With --archive, entries of tar, tar.gz, tgz and zip archives are addressed after a '#'.
+      {{.Prompt}} {{.HelpName}} --archive --recursive s3/drops/batch.tar.gz#path/in/archive/ play/mybucket/batch/
+
+  26. Copy a local folder recursively to MinIO cloud storage keeping its symlinks, then restore it with the
+      symlinks recreated.
+      {{.Prompt}} {{.HelpName}} --recursive --preserve-symlinks ~/project/ play/mybucket/project/
+      {{.Prompt}} {{.HelpName}} --recursive --preserve-symlinks play/mybucket/project/ ~/restore/
 `,
 }
 
@@ -216,7 +257,7 @@ type ProgressReader interface {
 }
 
 // doCopy - Copy a single file from source to destination
-func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair, isMvCmd bool, preserve bool) URLs {
+func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, limits bandwidthLimits, encKeyDB map[string][]prefixSSEPair, isMvCmd bool, preserve bool) URLs {
 	if cpURLs.Error != nil {
 		cpURLs.Error = cpURLs.Error.Trace()
 		return cpURLs
@@ -242,7 +283,13 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 		})
 	}
 
-	urls := uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB, preserve)
+	progress := limits.progressHook(cpURLs, pg)
+	if transfer := getTransferOptionsFromContext(ctx); transfer.throughput != nil {
+		// Sample the throughput to tune the size of parts.
+		progress = hookreader.NewHook(progress, transfer.throughput)
+	}
+
+	urls := uploadSourceToTargetURL(ctx, cpURLs, progress, encKeyDB, preserve)
 	if isMvCmd && urls.Error == nil {
 		bgRemove(ctx, sourcePath)
 	}
@@ -265,7 +312,7 @@ func doCopyFake(ctx context.Context, cpURLs URLs, pg Progress, isMvCmd bool) URL
 }
 
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
-func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy context.CancelFunc) (totalBytes, totalObjects int64) {
+func doPrepareCopyURLs(ctx context.Context, session *sessionV8, filter *listFilter, cancelCopy context.CancelFunc) (totalBytes, totalObjects int64) {
 	// Separate source and target. 'cp' can take only one target,
 	// but any number of sources.
 	sourceURLs := session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
@@ -276,6 +323,8 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 
 	olderThan := session.Header.CommandStringFlags["older-than"]
 	newerThan := session.Header.CommandStringFlags["newer-than"]
+	versionID := session.Header.CommandStringFlags["version-id"]
+	timeRef := parseRewindFlag(session.Header.CommandStringFlags["rewind"])
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
@@ -289,7 +338,7 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 		scanBar = scanBarFactory()
 	}
 
-	URLsCh := prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive, encKeyDB, olderThan, newerThan)
+	URLsCh := prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive, filter, encKeyDB, olderThan, newerThan, versionID, timeRef)
 	done := false
 	for !done {
 		select {
@@ -341,12 +390,34 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	return
 }
 
-func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
+func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, session *sessionV8, filter *listFilter, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
 	var isCopied func(string) bool
 	var totalObjects, totalBytes int64
 
 	var cpURLsCh = make(chan URLs, 10000)
 
+	// A resumed session copies with the flags it was started with.
+	var flags flagValues = cli
+	userMetaMap := make(map[string]string)
+	if session != nil {
+		flags = sessionFlagValues{session.Header}
+		userMetaMap = session.Header.UserMetaData
+	} else if cli.String("attr") != "" {
+		userMetaMap, _ = getMetaDataEntry(cli.String("attr"))
+	}
+
+	// Bandwidth limits are shared by all copy workers.
+	limits, err := parseBandwidthLimits(flags.String("limit-upload"), flags.String("limit-download"))
+	fatalIf(err, "Unable to parse bandwidth limits.")
+
+	checksum, err := parseChecksumAlgorithm(flags.String("verify"))
+	fatalIf(err, "Unable to parse checksum algorithm.")
+
+	transfer, err := getTransferOptions(flags)
+	fatalIf(err, "Unable to parse multipart options.")
+	maxWorkers, err := getMaxWorkers(flags)
+	fatalIf(err, "Unable to parse the maximum number of workers.")
+
 	// Store a progress bar or an accounter
 	var pg ProgressReader
 
@@ -354,7 +425,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	if !globalQuiet && !globalJSON { // set up progress bar
 		pg = newProgressBar(totalBytes)
 	} else {
-		pg = newAccounter(totalBytes)
+		pg = newAccounter(totalBytes).SetLimits(limits)
 	}
 
 	if session != nil {
@@ -362,8 +433,11 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		// or not. This is useful when we resume from a session.
 		isCopied = isLastFactory(session.Header.LastCopied)
 
+		// Already copied objects are accounted again as they are skipped.
+		session.Header.DoneBytes, session.Header.DoneObjects = 0, 0
+
 		if !session.HasData() {
-			totalBytes, totalObjects = doPrepareCopyURLs(ctx, session, cancelCopy)
+			totalBytes, totalObjects = doPrepareCopyURLs(ctx, session, filter, cancelCopy)
 		} else {
 			totalBytes, totalObjects = session.Header.TotalBytes, session.Header.TotalObjects
 		}
@@ -397,11 +471,13 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		isRecursive := cli.Bool("recursive")
 		olderThan := cli.String("older-than")
 		newerThan := cli.String("newer-than")
+		versionID := cli.String("version-id")
+		timeRef := parseRewindFlag(cli.String("rewind"))
 
 		go func() {
 			totalBytes := int64(0)
 			for cpURLs := range prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive,
-				encKeyDB, olderThan, newerThan) {
+				filter, encKeyDB, olderThan, newerThan, versionID, timeRef) {
 				if cpURLs.Error != nil {
 					// Print in new line and adjust to top so that we
 					// don't print over the ongoing scan bar
@@ -431,7 +507,11 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	var quitCh = make(chan struct{})
 	var statusCh = make(chan URLs)
 
-	parallel, queueCh := newParallelManager(statusCh)
+	parallel, queueCh := newParallelManager(statusCh, maxWorkers)
+	if transfer.autoPartSize {
+		transfer.throughput = parallel
+	}
+	copyCtx := withTransferOptions(ctx, transfer)
 
 	go func() {
 		gracefulStop := func() {
@@ -464,44 +544,30 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				cpURLs.TargetContent.UserMetadata = make(map[string]string)
 
 				// Check and handle storage class if passed in command line args
-				if storageClass := cli.String("storage-class"); storageClass != "" {
+				if storageClass := flags.String("storage-class"); storageClass != "" {
 					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = storageClass
 				}
 
 				// update Object retention related fields
-				if session != nil {
-					cpURLs.TargetContent.RetentionMode = session.Header.CommandStringFlags[rmFlag]
-					if cpURLs.TargetContent.RetentionMode != "" {
-						cpURLs.TargetContent.RetentionEnabled = true
-					}
-					cpURLs.TargetContent.RetentionDuration = session.Header.CommandStringFlags[rdFlag]
-					cpURLs.TargetContent.LegalHold = strings.ToUpper(session.Header.CommandStringFlags[lhFlag])
-					if cpURLs.TargetContent.LegalHold != "" {
-						cpURLs.TargetContent.LegalHoldEnabled = true
-					}
-				} else {
-					if rm := cli.String(rmFlag); rm != "" {
-						cpURLs.TargetContent.RetentionMode = rm
-						cpURLs.TargetContent.RetentionEnabled = true
-					}
-					if rd := cli.String(rdFlag); rd != "" {
-						cpURLs.TargetContent.RetentionDuration = rd
-					}
-					if lh := cli.String(lhFlag); lh != "" {
-						cpURLs.TargetContent.LegalHold = strings.ToUpper(lh)
-						cpURLs.TargetContent.LegalHoldEnabled = true
-					}
+				if rm := flags.String(rmFlag); rm != "" {
+					cpURLs.TargetContent.RetentionMode = rm
+					cpURLs.TargetContent.RetentionEnabled = true
 				}
-				if cli.String("attr") != "" {
-					userMetaMap, _ := getMetaDataEntry(cli.String("attr"))
-					for metaDataKey, metaDataVal := range userMetaMap {
-						cpURLs.TargetContent.UserMetadata[metaDataKey] = metaDataVal
-					}
+				if rd := flags.String(rdFlag); rd != "" {
+					cpURLs.TargetContent.RetentionDuration = rd
+				}
+				if lh := flags.String(lhFlag); lh != "" {
+					cpURLs.TargetContent.LegalHold = strings.ToUpper(lh)
+					cpURLs.TargetContent.LegalHoldEnabled = true
+				}
+				for metaDataKey, metaDataVal := range userMetaMap {
+					cpURLs.TargetContent.UserMetadata[metaDataKey] = metaDataVal
 				}
 
-				preserve := cli.Bool("preserve")
-				cpURLs.MD5 = cli.Bool("md5")
-				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
+				preserve := flags.Bool("preserve")
+				cpURLs.MD5 = flags.Bool("md5")
+				cpURLs.DisableMultipart = flags.Bool("disable-multipart")
+				cpURLs.Verify = checksum
 
 				// Verify if previously copied, notify progress bar.
 				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
@@ -510,7 +576,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					}
 				} else {
 					queueCh <- func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve)
+						return doCopy(copyCtx, cpURLs, pg, limits, encKeyDB, isMvCmd, preserve)
 					}
 				}
 			}
@@ -543,6 +609,8 @@ loop:
 			if cpURLs.Error == nil {
 				if session != nil {
 					session.Header.LastCopied = cpURLs.SourceContent.URL.String()
+					session.Header.DoneBytes += cpURLs.SourceContent.Size
+					session.Header.DoneObjects++
 					session.Save()
 				}
 				cpAllFilesErr = false
@@ -630,6 +698,15 @@ func mainCopy(cliCtx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", cliCtx.String("attr"))
 	}
 
+	// Parse the symlink policy, local sources are checked with it.
+	symlinks, err := getSymlinkPolicy(cliCtx)
+	fatalIf(err, "Unable to parse the symlink options, only one of --follow-symlinks, --skip-symlinks and --preserve-symlinks can be set.")
+	var targetURL string
+	if args := cliCtx.Args(); len(args) > 0 {
+		targetURL = args[len(args)-1]
+	}
+	ctx = withSymlinkPolicy(ctx, symlinks, targetURL)
+
 	// check 'copy' cli arguments.
 	checkCopySyntax(ctx, cliCtx, encKeyDB, false)
 
@@ -667,6 +744,17 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.CommandBoolFlags["recursive"] = recursive
 			session.Header.CommandStringFlags["older-than"] = olderThan
 			session.Header.CommandStringFlags["newer-than"] = newerThan
+			session.Header.CommandStringFlags["version-id"] = cliCtx.String("version-id")
+			session.Header.CommandStringFlags["rewind"] = cliCtx.String("rewind")
+			session.Header.CommandStringFlags["limit-upload"] = cliCtx.String("limit-upload")
+			session.Header.CommandStringFlags["limit-download"] = cliCtx.String("limit-download")
+			session.Header.CommandStringFlags["part-size"] = cliCtx.String("part-size")
+			session.Header.CommandIntFlags["parallel-parts"] = cliCtx.Int("parallel-parts")
+			session.Header.CommandIntFlags["max-workers"] = cliCtx.Int("max-workers")
+			for _, name := range []string{"include", "exclude", "include-regex"} {
+				session.Header.CommandStringFlags[name] = strings.Join(cliCtx.StringSlice(name), "\n")
+			}
+			session.Header.CommandStringFlags["exclude-from"] = cliCtx.String("exclude-from")
 			session.Header.CommandStringFlags["storage-class"] = storageClass
 			session.Header.CommandStringFlags[rmFlag] = retentionMode
 			session.Header.CommandStringFlags[rdFlag] = retentionDuration
@@ -680,7 +768,11 @@ func mainCopy(cliCtx *cli.Context) error {
 			}
 			session.Header.UserMetaData = userMetaMap
 			session.Header.CommandBoolFlags["md5"] = cliCtx.Bool("md5")
+			session.Header.CommandStringFlags["verify"] = cliCtx.String("verify")
 			session.Header.CommandBoolFlags["disable-multipart"] = cliCtx.Bool("disable-multipart")
+			for _, name := range []string{"follow-symlinks", "skip-symlinks", "preserve-symlinks"} {
+				session.Header.CommandBoolFlags[name] = cliCtx.Bool(name)
+			}
 
 			var e error
 			if session.Header.RootPath, e = os.Getwd(); e != nil {
@@ -693,7 +785,11 @@ func mainCopy(cliCtx *cli.Context) error {
 		}
 	}
 
-	e := doCopySession(ctx, cancelCopy, cliCtx, session, encKeyDB, false)
+	// Filter the objects listed by recursive copies.
+	filter, err := getListFilter(cliCtx)
+	fatalIf(err, "Unable to parse the filter options.")
+
+	e := doCopySession(ctx, cancelCopy, cliCtx, session, filter, encKeyDB, false)
 	if session != nil {
 		session.Delete()
 	}
@@ -17,8 +17,10 @@
 package cmd
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -61,3 +63,24 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestGuessCopyURLTypeVersionID(t *testing.T) {
+	testCases := []struct {
+		sourceURLs  []string
+		isRecursive bool
+	}{
+		// --version-id with --recursive.
+		{[]string{"play/bucket/object"}, true},
+		// --version-id with multiple sources.
+		{[]string{"play/bucket/object1", "play/bucket/object2"}, false},
+	}
+	for i, testCase := range testCases {
+		cpType, err := guessCopyURLType(context.Background(), testCase.sourceURLs, "play/bucket/dir/", testCase.isRecursive, "3ddac055-89a7-40fa-8cd3-530a5581b6b8", time.Time{}, nil)
+		if err == nil {
+			t.Fatalf("Test %d: expected an error, got copy type %d", i+1, cpType)
+		}
+		if cpType != copyURLsTypeInvalid {
+			t.Fatalf("Test %d: expected invalid copy type, got %d", i+1, cpType)
+		}
+	}
+}
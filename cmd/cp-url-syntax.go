@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
 )
 
@@ -42,10 +44,29 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	srcURLs := URLs[:len(URLs)-1]
 	tgtURL := URLs[len(URLs)-1]
 	isRecursive := cliCtx.Bool("recursive")
+	versionID := cliCtx.String("version-id")
+	rewind := cliCtx.String("rewind")
+
+	if versionID != "" && rewind != "" {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify both --version-id and --rewind flags at the same time.")
+	}
+	if versionID != "" && (len(srcURLs) > 1 || isRecursive) {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify --version-id with multiple sources or with --recursive flag.")
+	}
+	timeRef := parseRewindFlag(rewind)
+
+	if _, err := parseChecksumAlgorithm(cliCtx.String("verify")); err != nil {
+		fatalIf(err.Trace(), "Unsupported checksum algorithm, valid values are md5, sha256 and crc32c.")
+	}
 
 	// Verify if source(s) exists.
 	for _, srcURL := range srcURLs {
-		_, _, err := url2Stat(ctx, srcURL, false, encKeyDB)
+		var err *probe.Error
+		if !isRecursive {
+			_, _, err = url2StatWithVersion(ctx, srcURL, versionID, timeRef, false, encKeyDB)
+		} else {
+			_, _, err = url2Stat(ctx, srcURL, false, encKeyDB)
+		}
 		if err != nil {
 			console.Fatalf("Unable to validate source %s\n", srcURL)
 		}
@@ -73,16 +94,16 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	}
 
 	// Guess CopyURLsType based on source and target URLs.
-	copyURLsType, err := guessCopyURLType(ctx, srcURLs, tgtURL, isRecursive, encKeyDB)
+	copyURLsType, err := guessCopyURLType(ctx, srcURLs, tgtURL, isRecursive, versionID, timeRef, encKeyDB)
 	if err != nil {
 		fatalIf(errInvalidArgument().Trace(), "Unable to guess the type of "+operation+" operation.")
 	}
 
 	switch copyURLsType {
 	case copyURLsTypeA: // File -> File.
-		checkCopySyntaxTypeA(ctx, srcURLs, tgtURL, encKeyDB, isMvCmd)
+		checkCopySyntaxTypeA(ctx, srcURLs, versionID, timeRef, tgtURL, encKeyDB, isMvCmd)
 	case copyURLsTypeB: // File -> Folder.
-		checkCopySyntaxTypeB(ctx, srcURLs, tgtURL, encKeyDB, isMvCmd)
+		checkCopySyntaxTypeB(ctx, srcURLs, versionID, timeRef, tgtURL, encKeyDB, isMvCmd)
 	case copyURLsTypeC: // Folder... -> Folder.
 		checkCopySyntaxTypeC(ctx, srcURLs, tgtURL, isRecursive, encKeyDB, isMvCmd)
 	case copyURLsTypeD: // File1...FileN -> Folder.
@@ -98,13 +119,13 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 }
 
 // checkCopySyntaxTypeA verifies if the source and target are valid file arguments.
-func checkCopySyntaxTypeA(ctx context.Context, srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair, isMvCmd bool) {
+func checkCopySyntaxTypeA(ctx context.Context, srcURLs []string, versionID string, timeRef time.Time, tgtURL string, keys map[string][]prefixSSEPair, isMvCmd bool) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(ctx, srcURL, false, keys)
+	_, srcContent, err := url2StatWithVersion(ctx, srcURL, versionID, timeRef, false, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -113,13 +134,13 @@ func checkCopySyntaxTypeA(ctx context.Context, srcURLs []string, tgtURL string,
 }
 
 // checkCopySyntaxTypeB verifies if the source is a valid file and target is a valid folder.
-func checkCopySyntaxTypeB(ctx context.Context, srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair, isMvCmd bool) {
+func checkCopySyntaxTypeB(ctx context.Context, srcURLs []string, versionID string, timeRef time.Time, tgtURL string, keys map[string][]prefixSSEPair, isMvCmd bool) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(ctx, srcURL, false, keys)
+	_, srcContent, err := url2StatWithVersion(ctx, srcURL, versionID, timeRef, false, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 )
@@ -52,10 +53,16 @@ const (
 
 // guessCopyURLType guesses the type of clientURL. This approach all allows prepareURL
 // functions to accurately report failure causes.
-func guessCopyURLType(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
+func guessCopyURLType(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, versionID string, timeRef time.Time, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
+	// A version ID addresses a single object, recursive and
+	// multi-source copies have no way to apply it.
+	if versionID != "" && (len(sourceURLs) > 1 || isRecursive) {
+		return copyURLsTypeInvalid, errInvalidArgument().Trace(sourceURLs...)
+	}
+
 	if len(sourceURLs) == 1 { // 1 Source, 1 Target
 		sourceURL := sourceURLs[0]
-		_, sourceContent, err := url2Stat(ctx, sourceURL, false, keys)
+		_, sourceContent, err := url2StatWithVersion(ctx, sourceURL, versionID, timeRef, false, keys)
 		if err != nil {
 			return copyURLsTypeInvalid, err
 		}
@@ -63,6 +70,9 @@ func guessCopyURLType(ctx context.Context, sourceURLs []string, targetURL string
 		// If recursion is ON, it is type C.
 		// If source is a folder, it is Type C.
 		if sourceContent.Type.IsDir() || isRecursive {
+			if versionID != "" {
+				return copyURLsTypeInvalid, errInvalidSource(sourceURL).Trace(sourceURL, versionID)
+			}
 			return copyURLsTypeC, nil
 		}
 
@@ -84,13 +94,13 @@ func guessCopyURLType(ctx context.Context, sourceURLs []string, targetURL string
 
 // SINGLE SOURCE - Type A: copy(f, f) -> copy(f, f)
 // prepareCopyURLsTypeA - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeA(ctx context.Context, sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeA(ctx context.Context, sourceURL, versionID string, timeRef time.Time, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(ctx, sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatWithVersion(ctx, sourceURL, versionID, timeRef, false, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -117,13 +127,13 @@ func makeCopyContentTypeA(sourceAlias string, sourceContent *ClientContent, targ
 
 // SINGLE SOURCE - Type B: copy(f, d) -> copy(f, d/f) -> A
 // prepareCopyURLsTypeB - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeB(ctx context.Context, sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeB(ctx context.Context, sourceURL, versionID string, timeRef time.Time, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(ctx, sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatWithVersion(ctx, sourceURL, versionID, timeRef, false, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -151,7 +161,7 @@ func makeCopyContentTypeB(sourceAlias string, sourceContent *ClientContent, targ
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive bool, filter *listFilter, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -166,8 +176,16 @@ func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRe
 			return
 		}
 
-		isIncomplete := false
-		for sourceContent := range sourceClient.List(ctx, isRecursive, isIncomplete, false, DirNone) {
+		var sourceCh <-chan *ClientContent
+		if !timeRef.IsZero() {
+			withOlderVersions := false
+			sourceCh = sourceClient.ListVersions(ctx, isRecursive, timeRef, withOlderVersions)
+		} else {
+			isIncomplete := false
+			sourceCh = sourceClient.List(ctx, isRecursive, isIncomplete, false, DirNone)
+		}
+
+		for sourceContent := range filterListing(ctx, filter, sourceClient.GetURL(), sourceCh) {
 			if sourceContent.Err != nil {
 				// Listing failed.
 				copyURLsCh <- URLs{Error: sourceContent.Err.Trace(sourceClient.GetURL().String())}
@@ -195,7 +213,7 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL ClientURL, sourceContent
 		sourcePrefix := filepath.ToSlash(sourceURL.Path[:pathSeparatorIndex])
 		// do not preserve unix cp behavior when copying a filesytem dir to
 		// objectstore.
-		if sourceAlias == "" && targetAlias != "" {
+		if sourceAlias == "" && targetAlias != "" && !isArchiveURL(sourceURL.Path) {
 			// Check if sourceURL.Path is a directory or not
 			fileInfo, err := os.Stat(sourceURL.Path)
 			if err != nil {
@@ -207,18 +225,21 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL ClientURL, sourceContent
 		}
 		newSourceSuffix = strings.TrimPrefix(newSourceSuffix, sourcePrefix)
 	}
-	newTargetURL := urlJoinPath(targetURL, newSourceSuffix)
+	newTargetURL, err := joinTargetKey(targetAlias, targetURL, newSourceSuffix, false)
+	if err != nil {
+		return URLs{Error: err.Trace(newSourceURL.String())}
+	}
 	return makeCopyContentTypeA(sourceAlias, sourceContent, targetAlias, newTargetURL, encKeyDB)
 }
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, filter *listFilter, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, filter, timeRef, encKeyDB) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -226,25 +247,26 @@ func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL st
 	return copyURLsCh
 }
 
-// prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan string) chan URLs {
+// prepareCopyURLs - prepares target and source clientURLs for copying. A
+// non empty versionID or a non zero timeRef copies older object versions.
+func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, filter *listFilter, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan, versionID string, timeRef time.Time) chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair) {
 		defer close(copyURLsCh)
-		cpType, err := guessCopyURLType(ctx, sourceURLs, targetURL, isRecursive, encKeyDB)
+		cpType, err := guessCopyURLType(ctx, sourceURLs, targetURL, isRecursive, versionID, timeRef, encKeyDB)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
 		switch cpType {
 		case copyURLsTypeA:
-			copyURLsCh <- prepareCopyURLsTypeA(ctx, sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeA(ctx, sourceURLs[0], versionID, timeRef, targetURL, encKeyDB)
 		case copyURLsTypeB:
-			copyURLsCh <- prepareCopyURLsTypeB(ctx, sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeB(ctx, sourceURLs[0], versionID, timeRef, targetURL, encKeyDB)
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(ctx, sourceURLs[0], targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(ctx, sourceURLs[0], targetURL, isRecursive, filter, timeRef, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(ctx, sourceURLs, targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(ctx, sourceURLs, targetURL, isRecursive, filter, timeRef, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		default:
@@ -0,0 +1,246 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+// Supported sources of alias credentials.
+const (
+	// Static keys stored in the alias, this is the default.
+	credentialsSourceStatic = "static"
+	// MINIO_ACCESS_KEY/MINIO_SECRET_KEY or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY.
+	credentialsSourceEnv = "env"
+	// A profile of an AWS shared credentials file, ~/.aws/credentials by default.
+	credentialsSourceAWSProfile = "aws-profile"
+	// STS AssumeRoleWithWebIdentity using a token read from a file.
+	credentialsSourceSTSWebIdentity = "sts-web-identity"
+	// STS AssumeRoleWithClientGrants using a token read from a file.
+	credentialsSourceSTSClientGrants = "sts-client-grants"
+	// IAM role of the EC2 instance or ECS task.
+	credentialsSourceIAM = "iam"
+	// JSON output of an external command, as AWS credential_process.
+	credentialsSourceProcess = "process"
+)
+
+var validCredentialsSources = []string{
+	credentialsSourceStatic,
+	credentialsSourceEnv,
+	credentialsSourceAWSProfile,
+	credentialsSourceSTSWebIdentity,
+	credentialsSourceSTSClientGrants,
+	credentialsSourceIAM,
+	credentialsSourceProcess,
+}
+
+// Credentials are refreshed this long before they expire.
+const credentialsExpiryWindow = time.Minute
+
+// isStatic returns true if the static keys of the alias are used.
+func (c *credentialsConfigV10) isStatic() bool {
+	return c == nil || c.Source == "" || c.Source == credentialsSourceStatic
+}
+
+// credentialsSource returns the source of non static credentials, empty otherwise.
+func credentialsSource(c *credentialsConfigV10) string {
+	if c.isStatic() {
+		return ""
+	}
+	return c.Source
+}
+
+// validate verifies that all fields required by the source are set.
+func (c *credentialsConfigV10) validate() *probe.Error {
+	if c.isStatic() {
+		return nil
+	}
+	switch c.Source {
+	case credentialsSourceEnv, credentialsSourceAWSProfile, credentialsSourceIAM:
+		return nil
+	case credentialsSourceSTSWebIdentity, credentialsSourceSTSClientGrants:
+		if c.Endpoint == "" || c.TokenFile == "" {
+			return errInvalidArgument().Trace(c.Source, c.Endpoint, c.TokenFile)
+		}
+		return nil
+	case credentialsSourceProcess:
+		if strings.TrimSpace(c.Command) == "" {
+			return errInvalidArgument().Trace(c.Source)
+		}
+		return nil
+	}
+	return errInvalidArgument().Trace(c.Source)
+}
+
+// String returns a description of the source used as client cache key.
+func (c *credentialsConfigV10) String() string {
+	if c.isStatic() {
+		return credentialsSourceStatic
+	}
+	return fmt.Sprintf("%s:%s:%s:%s:%s:%d:%s", c.Source, c.Profile, c.File, c.Endpoint,
+		c.TokenFile, c.DurationSeconds, c.Command)
+}
+
+// newCredentials returns the credentials of config. Credentials obtained
+// from a non static source are retrieved again when they expire, which
+// keeps long running commands such as `mirror --watch` going.
+func newCredentials(config *Config) (*credentials.Credentials, *probe.Error) {
+	c := config.Credentials
+	if c.isStatic() {
+		// if Signature version '2' use NewV2 directly.
+		if strings.ToUpper(config.Signature) == "S3V2" {
+			return credentials.NewStaticV2(config.AccessKey, config.SecretKey, ""), nil
+		}
+		return credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken), nil
+	}
+	if err := c.validate(); err != nil {
+		return nil, err.Trace(config.HostURL)
+	}
+	if strings.ToUpper(config.Signature) == "S3V2" {
+		return nil, errCredentialsSourceSignature(config.Signature, config.HostURL, c.Source)
+	}
+
+	switch c.Source {
+	case credentialsSourceEnv:
+		return credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvMinio{},
+			&credentials.EnvAWS{},
+		}), nil
+	case credentialsSourceAWSProfile:
+		return credentials.NewFileAWSCredentials(c.File, c.Profile), nil
+	case credentialsSourceIAM:
+		return credentials.NewIAM(c.Endpoint), nil
+	case credentialsSourceSTSWebIdentity:
+		creds, e := credentials.NewSTSWebIdentity(c.Endpoint, func() (*credentials.WebIdentityToken, error) {
+			token, e := readCredentialsToken(c.TokenFile)
+			return &credentials.WebIdentityToken{Token: token, Expiry: c.DurationSeconds}, e
+		})
+		if e != nil {
+			return nil, probe.NewError(e).Trace(c.Endpoint)
+		}
+		return creds, nil
+	case credentialsSourceSTSClientGrants:
+		creds, e := credentials.NewSTSClientGrants(c.Endpoint, func() (*credentials.ClientGrantsToken, error) {
+			token, e := readCredentialsToken(c.TokenFile)
+			return &credentials.ClientGrantsToken{Token: token, Expiry: c.DurationSeconds}, e
+		})
+		if e != nil {
+			return nil, probe.NewError(e).Trace(c.Endpoint)
+		}
+		return creds, nil
+	case credentialsSourceProcess:
+		return credentials.New(&processCredentials{command: c.Command}), nil
+	}
+	return nil, errInvalidArgument().Trace(c.Source)
+}
+
+// readCredentialsToken reads a token from file, it is read again on
+// every refresh since identity providers rotate them.
+func readCredentialsToken(file string) (string, error) {
+	data, e := ioutil.ReadFile(file)
+	if e != nil {
+		return "", e
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.New("token file `" + file + "` is empty")
+	}
+	return token, nil
+}
+
+// processCredentials retrieves credentials from the JSON output of an
+// external command, in the format used by AWS credential_process.
+type processCredentials struct {
+	command string
+
+	mu        sync.Mutex
+	retrieved bool
+	expires   bool
+	expiry    credentials.Expiry
+}
+
+// processCredentialsOutput is the JSON printed by the external command.
+type processCredentialsOutput struct {
+	Version         int        `json:"Version"`
+	AccessKeyID     string     `json:"AccessKeyId"`
+	SecretAccessKey string     `json:"SecretAccessKey"`
+	SessionToken    string     `json:"SessionToken"`
+	Expiration      *time.Time `json:"Expiration"`
+}
+
+// Retrieve runs the command and parses its output.
+func (p *processCredentials) Retrieve() (credentials.Value, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", p.command)
+	} else {
+		cmd = exec.Command("sh", "-c", p.command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if e := cmd.Run(); e != nil {
+		return credentials.Value{}, fmt.Errorf("credential process `%s` failed: %v %s", p.command, e, strings.TrimSpace(stderr.String()))
+	}
+
+	var out processCredentialsOutput
+	if e := json.Unmarshal(stdout.Bytes(), &out); e != nil {
+		return credentials.Value{}, fmt.Errorf("credential process `%s` returned invalid JSON: %v", p.command, e)
+	}
+	if out.Version != 1 {
+		return credentials.Value{}, fmt.Errorf("credential process `%s` returned unsupported version %d", p.command, out.Version)
+	}
+	if out.AccessKeyID == "" || out.SecretAccessKey == "" {
+		return credentials.Value{}, fmt.Errorf("credential process `%s` returned no keys", p.command)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retrieved = true
+	p.expires = out.Expiration != nil
+	if p.expires {
+		p.expiry.SetExpiration(*out.Expiration, credentialsExpiryWindow)
+	}
+	return credentials.Value{
+		AccessKeyID:     out.AccessKeyID,
+		SecretAccessKey: out.SecretAccessKey,
+		SessionToken:    out.SessionToken,
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
+
+// IsExpired returns true if the command has to run again.
+func (p *processCredentials) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.retrieved {
+		return true
+	}
+	return p.expires && p.expiry.IsExpired()
+}
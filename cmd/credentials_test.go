@@ -0,0 +1,93 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCredentialsConfigValidate(t *testing.T) {
+	testCases := []struct {
+		config   *credentialsConfigV10
+		isStatic bool
+		success  bool
+	}{
+		{nil, true, true},
+		{&credentialsConfigV10{}, true, true},
+		{&credentialsConfigV10{Source: "static"}, true, true},
+		{&credentialsConfigV10{Source: "env"}, false, true},
+		{&credentialsConfigV10{Source: "aws-profile", Profile: "backup"}, false, true},
+		{&credentialsConfigV10{Source: "iam"}, false, true},
+		{&credentialsConfigV10{Source: "sts-web-identity"}, false, false},
+		{&credentialsConfigV10{Source: "sts-web-identity", Endpoint: "https://sts.example.com"}, false, false},
+		{&credentialsConfigV10{Source: "sts-web-identity", Endpoint: "https://sts.example.com", TokenFile: "/tmp/token"}, false, true},
+		{&credentialsConfigV10{Source: "sts-client-grants", Endpoint: "https://sts.example.com", TokenFile: "/tmp/token"}, false, true},
+		{&credentialsConfigV10{Source: "process"}, false, false},
+		{&credentialsConfigV10{Source: "process", Command: "  "}, false, false},
+		{&credentialsConfigV10{Source: "process", Command: "vault-creds minio"}, false, true},
+		{&credentialsConfigV10{Source: "unknown"}, false, false},
+	}
+
+	for i, testCase := range testCases {
+		if isStatic := testCase.config.isStatic(); isStatic != testCase.isStatic {
+			t.Fatalf("Test %d: expected static %t, got %t", i+1, testCase.isStatic, isStatic)
+		}
+		err := testCase.config.validate()
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: expected to pass, but failed with %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected to fail, but passed", i+1)
+		}
+	}
+}
+
+func TestNewCredentialsSignature(t *testing.T) {
+	testCases := []struct {
+		signature   string
+		credentials *credentialsConfigV10
+		success     bool
+	}{
+		{"S3v4", nil, true},
+		{"S3v2", nil, true},
+		{"S3v4", &credentialsConfigV10{Source: "env"}, true},
+		// Only static keys sign with V2.
+		{"S3v2", &credentialsConfigV10{Source: "env"}, false},
+		{"s3v2", &credentialsConfigV10{Source: "iam"}, false},
+	}
+
+	for i, testCase := range testCases {
+		config := &Config{
+			HostURL:     "https://play.min.io",
+			Signature:   testCase.signature,
+			Credentials: testCase.credentials,
+		}
+		_, err := newCredentials(config)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: expected to pass, but failed with %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected to fail, but passed", i+1)
+		}
+
+		host := hostConfigV10{URL: config.HostURL, API: testCase.signature, Credentials: testCase.credentials}
+		if ok, errs := validateConfigHost(host); ok != testCase.success {
+			t.Fatalf("Test %d: expected valid config %t, got %t: %s", i+1, testCase.success, ok, strings.Join(errs, ", "))
+		}
+	}
+}
@@ -30,7 +30,16 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "checksum",
+			Usage: "compare checksums of objects with the same name and size (md5, sha256, crc32c)",
+		},
+		cli.StringFlag{
+			Name:  "normalize",
+			Usage: "match names which only differ in their Unicode normalization, compared in 'nfc' or 'nfd' form",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -39,7 +48,7 @@ var diffCmd = cli.Command{
 	Usage:  "list differences in object name, size, and date between two buckets",
 	Action: mainDiff,
 	Before: setGlobalsFromContext,
-	Flags:  append(diffFlags, globalFlags...),
+	Flags:  append(append(diffFlags, symlinkFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -50,7 +59,10 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 DESCRIPTION:
-  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents.
+  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents
+  unless --checksum is specified, which compares objects of the same size on both sides with the given checksum
+  algorithm to find silent corruption. Plain MD5 ETags are trusted for md5, otherwise objects are read unless
+  both carry a stored checksum of the algorithm.
 
 LEGEND:
   < - object is only in source.
@@ -63,6 +75,15 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      {{.Prompt}} {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare the content of two buckets on different sites.
+     {{.Prompt}} {{.HelpName}} --checksum md5 siteA/mybucket siteB/mybucket
+
+  4. Compare a folder created on macOS with a bucket uploaded from Linux, matching names with accents.
+     {{.Prompt}} {{.HelpName}} --normalize nfc ~/Music s3/mybucket/Music
+
+  5. Compare a local folder with the copy made with '--preserve-symlinks', without following its symlinks.
+     {{.Prompt}} {{.HelpName}} --preserve-symlinks ~/project s3/mybucket/project
 `,
 }
 
@@ -93,6 +114,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffMetadata", "! "+d.SecondURL)
 	case differInAASourceMTime:
 		msg = console.Colorize("DiffMMSourceMTime", "! "+d.SecondURL)
+	case differInChecksum:
+		msg = console.Colorize("DiffChecksum", "! "+d.SecondURL)
 	default:
 		fatalIf(errDummy().Trace(d.FirstURL, d.SecondURL),
 			"Unhandled difference between `"+d.FirstURL+"` and `"+d.SecondURL+"`.")
@@ -148,8 +171,9 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	}
 }
 
-// doDiffMain runs the diff.
-func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
+// doDiffMain runs the diff, objects similar in name and size are compared
+// by their checksum when a checksum algorithm is set.
+func doDiffMain(ctx context.Context, firstURL, secondURL string, checksum checksumAlgorithm, normalize keyNormalization, encKeyDB map[string][]prefixSSEPair) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -177,12 +201,40 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 	}
 
 	// Diff first and second urls.
-	for diffMsg := range objectDifference(ctx, firstClient, secondClient, firstURL, secondURL, true) {
+	var diffCh chan diffMessage
+	if checksum != "" {
+		// Similar objects are needed as well to compare their checksums.
+		diffCh = difference(ctx, firstClient, secondClient, firstURL, secondURL, true, true, true, DirNone, nil, diffKeyOptions{normalize: normalize})
+	} else {
+		diffCh = objectDifference(ctx, firstClient, secondClient, firstURL, secondURL, true, nil, diffKeyOptions{normalize: normalize})
+	}
+	// Similar objects are also reported right after any difference
+	// found between them, those are not compared again.
+	var lastDiffMsg diffMessage
+	for diffMsg := range diffCh {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
 			continue
 		}
+		if diffMsg.Diff == differInNone {
+			if !diffMsg.firstContent.Type.IsRegular() {
+				continue
+			}
+			if lastDiffMsg.FirstURL == diffMsg.FirstURL && lastDiffMsg.SecondURL == diffMsg.SecondURL {
+				continue
+			}
+			same, err := checksumEqual(ctx, firstAlias, diffMsg.firstContent, secondAlias, diffMsg.secondContent, checksum, encKeyDB)
+			if err != nil {
+				errorIf(err, "Unable to compare checksums of `"+diffMsg.FirstURL+"` and `"+diffMsg.SecondURL+"`.")
+				continue
+			}
+			if same {
+				continue
+			}
+			diffMsg.Diff = differInChecksum
+		}
+		lastDiffMsg = diffMsg
 		printMsg(diffMsg)
 	}
 
@@ -198,9 +250,20 @@ func mainDiff(cliCtx *cli.Context) error {
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	// Parse the symlink policy, local folders are checked with it.
+	symlinks, err := getSymlinkPolicy(cliCtx)
+	fatalIf(err, "Unable to parse the symlink options, only one of --follow-symlinks, --skip-symlinks and --preserve-symlinks can be set.")
+	ctx = withSymlinkPolicy(ctx, symlinks, "")
+
 	// check 'diff' cli arguments.
 	checkDiffSyntax(ctx, cliCtx, encKeyDB)
 
+	normalize, err := parseKeyNormalization(cliCtx.String("normalize"))
+	fatalIf(err, "Unable to parse the normalization form, valid values are nfc and nfd.")
+
+	checksum, err := parseChecksumAlgorithm(cliCtx.String("checksum"))
+	fatalIf(err, "Unsupported checksum algorithm, valid values are md5, sha256 and crc32c.")
+
 	// Additional command specific theme customization.
 	console.SetColor("DiffMessage", color.New(color.FgGreen, color.Bold))
 	console.SetColor("DiffOnlyInFirst", color.New(color.FgRed))
@@ -209,10 +272,11 @@ func mainDiff(cliCtx *cli.Context) error {
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMetadata", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMMSourceMTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffChecksum", color.New(color.FgRed, color.Bold))
 
 	URLs := cliCtx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(ctx, firstURL, secondURL)
+	return doDiffMain(ctx, firstURL, secondURL, checksum, normalize, encKeyDB)
 }
@@ -18,15 +18,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	// golang does not support flat keys for path matching, find does
 
+	"github.com/minio/mc/pkg/ioutils"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v6"
-	"golang.org/x/text/unicode/norm"
 )
 
 // differType difference in type.
@@ -40,6 +43,7 @@ const (
 	differInFirst                           // only in source (FIRST)
 	differInSecond                          // only in target (SECOND)
 	differInAASourceMTime                   // differs in active-active source modtime
+	differInChecksum                        // differs in content checksum
 )
 
 func (d differType) String() string {
@@ -58,6 +62,8 @@ func (d differType) String() string {
 		return "only-in-first"
 	case differInSecond:
 		return "only-in-second"
+	case differInChecksum:
+		return "checksum"
 	}
 	return "unknown"
 }
@@ -157,19 +163,120 @@ func metadataEqual(m1, m2 map[string]string) bool {
 	return true
 }
 
-func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool) (diffCh chan diffMessage) {
-	return difference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, isMetadata, true, false, DirNone)
+// diffKeyOptions describes how the keys of the source and the target
+// are matched.
+type diffKeyOptions struct {
+	normalize keyNormalization
+	// Paths of a filesystem source or target are escaped with fsEscapeKey.
+	escaped bool
+}
+
+func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, filter *listFilter, keys diffKeyOptions) (diffCh chan diffMessage) {
+	return difference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, isMetadata, true, false, DirNone, filter, keys)
 }
 
 func dirDifference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, false, false, true, DirFirst)
+	return difference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, false, false, true, DirFirst, nil, diffKeyOptions{})
+}
+
+// listingSortChunkSize is the number of entries of a listing sorted in
+// memory at once, larger listings are spilled to temporary files.
+const listingSortChunkSize = 50000
+
+// sortListing returns the listing of ch sorted by key, the listing is
+// read entirely first and sorted with bounded memory. A listing error
+// is returned alone.
+func sortListing(ctx context.Context, ch <-chan *ClientContent, key func(*ClientContent) string) <-chan *ClientContent {
+	sortedCh := make(chan *ClientContent)
+	go func() {
+		defer close(sortedCh)
+		send := func(content *ClientContent) bool {
+			select {
+			case sortedCh <- content:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		fail := func(err *probe.Error) {
+			send(&ClientContent{Err: err})
+			// Unblock the lister.
+			for range ch {
+			}
+		}
+
+		sorted := ioutils.NewExternalSort(listingSortChunkSize)
+		defer sorted.Close()
+		for content := range ch {
+			if content.Err != nil {
+				fail(content.Err)
+				return
+			}
+			data, e := json.Marshal(content)
+			if e != nil {
+				fail(probe.NewError(e))
+				return
+			}
+			if e = sorted.Add(key(content), data); e != nil {
+				fail(probe.NewError(e))
+				return
+			}
+		}
+		for {
+			_, data, e := sorted.Next()
+			if e == io.EOF {
+				return
+			}
+			if e != nil {
+				send(&ClientContent{Err: probe.NewError(e)})
+				return
+			}
+			content := &ClientContent{}
+			if e = json.Unmarshal(data, content); e != nil {
+				send(&ClientContent{Err: probe.NewError(e)})
+				return
+			}
+			if !send(content) {
+				return
+			}
+		}
+	}()
+	return sortedCh
 }
 
-func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, diffCh chan<- diffMessage) *probe.Error {
+func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, filter *listFilter, keys diffKeyOptions, diffCh chan<- diffMessage) *probe.Error {
 	// Set default values for listing.
 	isIncomplete := false // we will not compare any incomplete objects.
-	srcCh := sourceClnt.List(ctx, isRecursive, isIncomplete, isMetadata, dirOpt)
-	tgtCh := targetClnt.List(ctx, isRecursive, isIncomplete, isMetadata, dirOpt)
+	// Source and target listings are filtered the same way.
+	srcCh := filterListing(ctx, filter, sourceClnt.GetURL(), sourceClnt.List(ctx, isRecursive, isIncomplete, isMetadata, dirOpt))
+	tgtCh := filterListing(ctx, filter, targetClnt.GetURL(), targetClnt.List(ctx, isRecursive, isIncomplete, isMetadata, dirOpt))
+
+	unescapeSource := keys.escaped && sourceClnt.GetURL().Type == fileSystem
+	unescapeTarget := keys.escaped && targetClnt.GetURL().Type == fileSystem
+	sourceSuffix := func(content *ClientContent) string {
+		suffix := strings.TrimPrefix(content.URL.String(), sourceURL)
+		if unescapeSource {
+			suffix = fsUnescapeKey(filepath.ToSlash(suffix))
+		}
+		return suffix
+	}
+	targetSuffix := func(content *ClientContent) string {
+		suffix := strings.TrimPrefix(content.URL.String(), targetURL)
+		if unescapeTarget {
+			suffix = fsUnescapeKey(filepath.ToSlash(suffix))
+		}
+		return suffix
+	}
+	if keys.normalize != normalizeDefault || unescapeSource || unescapeTarget {
+		// The listings are merged in the order of the keys they are
+		// compared with.
+		srcCh = sortListing(ctx, srcCh, func(content *ClientContent) string {
+			return keys.normalize.String(urlJoinPath(targetURL, sourceSuffix(content)))
+		})
+		tgtCh = sortListing(ctx, tgtCh, func(content *ClientContent) string {
+			return keys.normalize.String(urlJoinPath(targetURL, targetSuffix(content)))
+		})
+	}
 
 	srcCtnt, srcOk := <-srcCh
 	tgtCtnt, tgtOk := <-tgtCh
@@ -217,8 +324,8 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sour
 			continue
 		}
 
-		srcSuffix := strings.TrimPrefix(srcCtnt.URL.String(), sourceURL)
-		tgtSuffix := strings.TrimPrefix(tgtCtnt.URL.String(), targetURL)
+		srcSuffix := sourceSuffix(srcCtnt)
+		tgtSuffix := targetSuffix(tgtCtnt)
 
 		current := urlJoinPath(targetURL, srcSuffix)
 		expected := urlJoinPath(targetURL, tgtSuffix)
@@ -239,8 +346,8 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sour
 		// Normalize to avoid situations where multiple byte representations are possible.
 		// e.g. 'ä' can be represented as precomposed U+00E4 (UTF-8 0xc3a4) or decomposed
 		// U+0061 U+0308 (UTF-8 0x61cc88).
-		normalizedCurrent := norm.NFC.String(current)
-		normalizedExpected := norm.NFC.String(expected)
+		normalizedCurrent := keys.normalize.String(current)
+		normalizedExpected := keys.normalize.String(expected)
 
 		if normalizedExpected > normalizedCurrent {
 			diffCh <- diffMessage{
@@ -326,7 +433,7 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sour
 
 // objectDifference function finds the difference between all objects
 // recursively in sorted order from source and target.
-func difference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
+func difference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, filter *listFilter, keys diffKeyOptions) (diffCh chan diffMessage) {
 	diffCh = make(chan diffMessage, 10000)
 
 	go func() {
@@ -337,7 +444,7 @@ func difference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, t
 
 		for range newRetryTimerContinous(retryCtx, time.Second, time.Second*30, minio.MaxJitter) {
 			err := differenceInternal(retryCtx, sourceClnt, targetClnt, sourceURL, targetURL,
-				isMetadata, isRecursive, returnSimilar, dirOpt, diffCh)
+				isMetadata, isRecursive, returnSimilar, dirOpt, filter, keys, diffCh)
 			if err != nil {
 				// handle this specifically for filesystem related errors.
 				switch err.ToGoError().(type) {
@@ -17,7 +17,12 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
 )
 
 var testCases = []struct {
@@ -46,3 +51,48 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestSortListing(t *testing.T) {
+	listing := func(keys ...string) <-chan *ClientContent {
+		ch := make(chan *ClientContent, len(keys))
+		for _, key := range keys {
+			ch <- &ClientContent{
+				URL:      *newClientURL("/" + key),
+				Size:     int64(len(key)),
+				Time:     time.Unix(1600000000, 0).UTC(),
+				Metadata: map[string]string{"Content-Type": "text/plain"},
+			}
+		}
+		close(ch)
+		return ch
+	}
+	key := func(content *ClientContent) string { return content.URL.Path }
+
+	var keys []string
+	for content := range sortListing(context.Background(), listing("c", "a", "b"), key) {
+		if content.Err != nil {
+			t.Fatal(content.Err)
+		}
+		if content.Size != int64(len(content.URL.Path)-1) || content.Metadata["Content-Type"] != "text/plain" ||
+			!content.Time.Equal(time.Unix(1600000000, 0)) {
+			t.Fatalf("Unexpected content %+v", content)
+		}
+		keys = append(keys, content.URL.Path)
+	}
+	if len(keys) != 3 || keys[0] != "/a" || keys[1] != "/b" || keys[2] != "/c" {
+		t.Fatalf("Unexpected order %v", keys)
+	}
+
+	// A listing error is returned alone.
+	errCh := make(chan *ClientContent, 2)
+	errCh <- &ClientContent{URL: *newClientURL("/a")}
+	errCh <- &ClientContent{Err: probe.NewError(errors.New("listing failed"))}
+	close(errCh)
+	var contents []*ClientContent
+	for content := range sortListing(context.Background(), errCh, key) {
+		contents = append(contents, content)
+	}
+	if len(contents) != 1 || contents[0].Err == nil {
+		t.Fatalf("Expected a single error, got %d contents", len(contents))
+	}
+}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -49,7 +50,7 @@ var duCmd = cli.Command{
 	Usage:  "summarize disk usage recursively",
 	Action: mainDu,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(duFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(duFlags, filterFlags...), symlinkFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -68,6 +69,12 @@ EXAMPLES:
 
    2. Summarize disk usage of 'louis' prefix in 'jazz-songs' bucket upto two levels.
       {{.Prompt}} {{.HelpName}} --depth=2 s3/jazz-songs/louis/
+
+   3. Summarize disk usage of the '.mp3' objects of 'jazz-songs' bucket recursively.
+      {{.Prompt}} {{.HelpName}} --include "*.mp3" s3/jazz-songs
+
+   4. Summarize disk usage of a local folder recursively, including the folders it links to.
+      {{.Prompt}} {{.HelpName}} --recursive --follow-symlinks ~/Music
 `,
 }
 
@@ -93,7 +100,7 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, error) {
+func du(ctx context.Context, urlStr string, depth int, filter *listFilter, encKeyDB map[string][]prefixSSEPair) (int64, error) {
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 	if !strings.HasSuffix(targetURL, "/") {
 		targetURL += "/"
@@ -107,7 +114,7 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 
 	isRecursive := false
 	isIncomplete := false
-	contentCh := clnt.List(globalContext, isRecursive, isIncomplete, false, DirFirst)
+	contentCh := filterListing(ctx, filter, clnt.GetURL(), clnt.List(ctx, isRecursive, isIncomplete, false, DirFirst))
 	size := int64(0)
 	for content := range contentCh {
 		if content.Err != nil {
@@ -136,7 +143,10 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, err := du(subDirAlias, depth, encKeyDB)
+			// Sub-folders are filtered relative to the summarized URL.
+			subDirURL := clnt.GetURL()
+			subDirFilter := filter.withPrefix(listRelativePath(subDirURL.Path, content.URL.Path, subDirURL.Separator))
+			used, err := du(ctx, subDirAlias, depth, subDirFilter, encKeyDB)
 			if err != nil {
 				return 0, err
 			}
@@ -187,12 +197,19 @@ func mainDu(ctx *cli.Context) error {
 		}
 	}
 
+	// Filter the summarized objects.
+	filter, err := getListFilter(ctx)
+	fatalIf(err, "Unable to parse the filter options.")
+	symlinks, err := getSymlinkPolicy(ctx)
+	fatalIf(err, "Unable to parse the symlink options, only one of --follow-symlinks, --skip-symlinks and --preserve-symlinks can be set.")
+	duCtx := withSymlinkPolicy(globalContext, symlinks, "")
+
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
 	var duErr error
 	for _, urlStr := range ctx.Args() {
-		if _, err := du(urlStr, depth, encKeyDB); duErr == nil {
+		if _, err := du(duCtx, urlStr, depth, filter, encKeyDB); duErr == nil {
 			duErr = err
 		}
 	}
@@ -54,6 +54,10 @@ var globalFlags = []cli.Flag{
 		Name:  "insecure",
 		Usage: "disable SSL certificate verification",
 	},
+	cli.BoolFlag{
+		Name:  "archive",
+		Usage: "address entries of tar and zip archives after a '#' in the URL",
+	},
 }
 
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
@@ -64,6 +68,18 @@ var ioFlags = []cli.Flag{
 	},
 }
 
+// Flags limiting the transfer rate of commands copying data.
+var limitFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "limit-upload",
+		Usage: "limits uploads to a maximum rate in KiB/s, MiB/s, GiB/s (default: unlimited)",
+	},
+	cli.StringFlag{
+		Name:  "limit-download",
+		Usage: "limits downloads to a maximum rate in KiB/s, MiB/s, GiB/s (default: unlimited)",
+	},
+}
+
 // registerCmd registers a cli command
 func registerCmd(cmd cli.Command) {
 	commands = append(commands, cmd)
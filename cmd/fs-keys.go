@@ -0,0 +1,156 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Object keys may hold path segments which cannot be written below a
+// local directory: empty segments from a leading slash or '//', the
+// '.' and '..' segments, and a trailing slash on an object with data.
+// When writing to a filesystem target with escaping, each segment of a
+// key is mapped to a file name as follows, the mapping is reversible:
+//
+//   - '%' is written as '%25' and '\' as '%5C'
+//   - an empty segment is written as '%2F'
+//   - a '.' segment is written as '%2E' and '..' as '%2E%2E'
+//
+// e.g. the key '/logs//../a%b' is written as '%2F/logs/%2F/%2E%2E/a%25b'.
+var (
+	fsKeySegmentEscaper   = strings.NewReplacer("%", "%25", `\`, "%5C")
+	fsKeySegmentUnescaper = strings.NewReplacer("%25", "%", "%5C", `\`, "%2E", ".")
+)
+
+// fsEscapeKey returns the relative path an object key is written to.
+func fsEscapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		switch segment {
+		case "":
+			segments[i] = "%2F"
+		case ".":
+			segments[i] = "%2E"
+		case "..":
+			segments[i] = "%2E%2E"
+		default:
+			segments[i] = fsKeySegmentEscaper.Replace(segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// fsUnescapeKey returns the object key written to the relative path
+// escapedPath by fsEscapeKey.
+func fsUnescapeKey(escapedPath string) string {
+	segments := strings.Split(escapedPath, "/")
+	for i, segment := range segments {
+		if segment == "%2F" {
+			segments[i] = ""
+			continue
+		}
+		segments[i] = fsKeySegmentUnescaper.Replace(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// fsKeyEscapesDir returns true if the key, joined to a directory,
+// resolves to a path outside of that directory.
+func fsKeyEscapesDir(key string) bool {
+	var depth int
+	segments := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+	for _, segment := range segments {
+		switch segment {
+		case ".":
+		case "..":
+			depth--
+			if depth < 0 {
+				return true
+			}
+		default:
+			depth++
+		}
+	}
+	return false
+}
+
+// joinTargetKey joins the key of a source object to targetURL. Keys
+// written to a filesystem, which has no alias, are escaped with
+// fsEscapeKey when escape is set, otherwise keys which would be written
+// outside of targetURL are rejected.
+func joinTargetKey(targetAlias, targetURL, key string, escape bool) (string, *probe.Error) {
+	if targetAlias != "" {
+		return urlJoinPath(targetURL, key), nil
+	}
+	if escape {
+		return urlJoinPath(targetURL, fsEscapeKey(key)), nil
+	}
+	if fsKeyEscapesDir(key) {
+		return "", probe.NewError(UnsafeObjectKey{Key: key})
+	}
+	return urlJoinPath(targetURL, key), nil
+}
+
+// sourceKey returns the object key of suffix, the path of a source
+// object relative to the source URL. Paths of a filesystem source, which
+// has no alias, are unescaped with fsUnescapeKey when escape is set.
+func sourceKey(sourceAlias, suffix string, escape bool) string {
+	if sourceAlias != "" || !escape {
+		return suffix
+	}
+	return fsUnescapeKey(filepath.ToSlash(suffix))
+}
+
+// keyNormalization is the Unicode normalization form object keys are
+// compared in.
+type keyNormalization int
+
+const (
+	// Keys are compared in NFC in the order of the listings.
+	normalizeDefault keyNormalization = iota
+	// Listings are sorted by the NFC or NFD form of their keys, keys
+	// which differ only by their normalization are matched.
+	normalizeNFC
+	normalizeNFD
+)
+
+// parseKeyNormalization parses the value of --normalize.
+func parseKeyNormalization(value string) (keyNormalization, *probe.Error) {
+	switch strings.ToLower(value) {
+	case "":
+		return normalizeDefault, nil
+	case "nfc":
+		return normalizeNFC, nil
+	case "nfd":
+		return normalizeNFD, nil
+	}
+	return normalizeDefault, errInvalidArgument().Trace(value)
+}
+
+// String returns s in the normalization form of n.
+func (n keyNormalization) String(s string) string {
+	if n == normalizeNFD {
+		return norm.NFD.String(s)
+	}
+	return norm.NFC.String(s)
+}
@@ -0,0 +1,113 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestFSEscapeKey(c *C) {
+	testCases := []struct {
+		key     string
+		path    string
+		escapes bool
+	}{
+		{"a/b.txt", "a/b.txt", false},
+		{"/etc/passwd", "%2F/etc/passwd", false},
+		{"logs//a.txt", "logs/%2F/a.txt", false},
+		{"dir/", "dir/%2F", false},
+		{"../a.txt", "%2E%2E/a.txt", true},
+		{"a/../../b", "a/%2E%2E/%2E%2E/b", true},
+		{"a/../b", "a/%2E%2E/b", false},
+		{"./a", "%2E/a", false},
+		{"100%/a%2E", "100%25/a%252E", false},
+		{`..\a`, "..%5Ca", true},
+	}
+	for _, testCase := range testCases {
+		c.Assert(fsEscapeKey(testCase.key), Equals, testCase.path)
+		c.Assert(fsUnescapeKey(testCase.path), Equals, testCase.key)
+		c.Assert(fsKeyEscapesDir(testCase.key), Equals, testCase.escapes)
+		c.Assert(fsKeyEscapesDir(fsEscapeKey(testCase.key)), Equals, false)
+	}
+
+	_, err := joinTargetKey("", "/backup/", "../a.txt", false)
+	c.Assert(err, NotNil)
+	target, err := joinTargetKey("", "/backup/", "../a.txt", true)
+	c.Assert(err, IsNil)
+	c.Assert(target, Equals, "/backup/%2E%2E/a.txt")
+	_, err = joinTargetKey("s3", "https://s3.amazonaws.com/bucket/", "../a.txt", false)
+	c.Assert(err, IsNil)
+}
+
+func (s *TestSuite) TestFSEscapeKeyRoundTrip(c *C) {
+	const (
+		folder = "/backup/"
+		bucket = "https://s3.amazonaws.com/bucket/"
+	)
+	for _, key := range []string{"a/b.txt", "/etc/passwd", "logs//a.txt", "dir/", "../a.txt", "./a", "100%/a%2E", `..\a`} {
+		// Mirror the object to a folder and the file back to a bucket.
+		path, err := joinTargetKey("", folder, key, true)
+		c.Assert(err, IsNil)
+		suffix := sourceKey("", strings.TrimPrefix(path, folder), true)
+		object, err := joinTargetKey("s3", bucket, suffix, true)
+		c.Assert(err, IsNil)
+		c.Assert(object, Equals, urlJoinPath(bucket, key))
+		c.Assert(suffix, Equals, key)
+	}
+	// Object storage sources are never unescaped.
+	c.Assert(sourceKey("s3", "100%25/a", true), Equals, "100%25/a")
+}
+
+func (s *TestSuite) TestDifferenceNormalize(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "difference-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	// 'ä' decomposed on the first side and precomposed on the second.
+	first := filepath.Join(root, "first")
+	second := filepath.Join(root, "second")
+	for dir, names := range map[string][]string{
+		first:  {"a\u0308.txt", "b.txt"},
+		second: {"\u00e4.txt", "b.txt"},
+	} {
+		c.Assert(os.MkdirAll(dir, 0700), IsNil)
+		for _, name := range names {
+			c.Assert(ioutil.WriteFile(filepath.Join(dir, name), []byte("data"), 0600), IsNil)
+		}
+	}
+
+	firstClnt, err := fsNew(first)
+	c.Assert(err, IsNil)
+	secondClnt, err := fsNew(second)
+	c.Assert(err, IsNil)
+
+	for _, normalize := range []keyNormalization{normalizeNFC, normalizeNFD} {
+		var diffs []diffMessage
+		for diffMsg := range objectDifference(context.Background(), firstClnt, secondClnt,
+			first+string(filepath.Separator), second+string(filepath.Separator), false, nil, diffKeyOptions{normalize: normalize}) {
+			c.Assert(diffMsg.Error, IsNil)
+			diffs = append(diffs, diffMsg)
+		}
+		c.Assert(diffs, HasLen, 0)
+	}
+}
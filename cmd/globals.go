@@ -26,7 +26,7 @@ import (
 )
 
 const (
-	globalMCConfigVersion = "9"
+	globalMCConfigVersion = "10"
 
 	globalMCConfigFile = "config.json"
 	globalMCCertsDir   = "certs"
@@ -50,6 +50,7 @@ var (
 	globalDebug    = false // Debug flag set via command line
 	globalNoColor  = false // No Color flag set via command line
 	globalInsecure = false // Insecure flag set via command line
+	globalArchive  = false // Archive flag set via command line
 
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
@@ -63,12 +64,13 @@ var (
 )
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
-func setGlobals(quiet, debug, json, noColor, insecure bool) {
+func setGlobals(quiet, debug, json, noColor, insecure, archive bool) {
 	globalQuiet = globalQuiet || quiet
 	globalDebug = globalDebug || debug
 	globalJSON = globalJSON || json
 	globalNoColor = globalNoColor || noColor
 	globalInsecure = globalInsecure || insecure
+	globalArchive = globalArchive || archive
 
 	// Enable debug messages if requested.
 	if globalDebug {
@@ -88,6 +90,7 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	json := ctx.IsSet("json")
 	noColor := ctx.IsSet("no-color")
 	insecure := ctx.IsSet("insecure")
-	setGlobals(quiet, debug, json, noColor, insecure)
+	archive := ctx.IsSet("archive")
+	setGlobals(quiet, debug, json, noColor, insecure, archive)
 	return nil
 }
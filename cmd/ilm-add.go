@@ -60,6 +60,17 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --id "Docs" --prefix "doc/" \
           --expiry-days "200" \
           --tags "docformat=docx&plaintextformat=txt&exportFormat=pdf" s3/testbucket
+
+  4. Add rule for versioned testbucket on alias 'myminio'. Noncurrent versions are transitioned after 30 days
+     and removed after 90 days.
+     {{.Prompt}} {{.HelpName}} --id "Versions" --noncurrentversion-transition-days "30" \
+          --noncurrentversion-transition-storage-class "WARM" \
+          --noncurrentversion-expiration-days "90" myminio/testbucket
+
+  5. Add rule for testbucket on alias 'myminio' to remove expired object delete markers and abort
+     incomplete multipart uploads after 7 days.
+     {{.Prompt}} {{.HelpName}} --id "Cleanup" --expired-object-delete-marker \
+          --abort-incomplete-mpu-days "7" myminio/testbucket
 `,
 }
 
@@ -100,6 +111,26 @@ var ilmAddFlags = []cli.Flag{
 		Name:  "disable",
 		Usage: "disable the rule",
 	},
+	cli.BoolFlag{
+		Name:  "expired-object-delete-marker",
+		Usage: "remove delete markers with no noncurrent versions",
+	},
+	cli.StringFlag{
+		Name:  "noncurrentversion-expiration-days",
+		Usage: "the number of days to remove noncurrent versions",
+	},
+	cli.StringFlag{
+		Name:  "noncurrentversion-transition-days",
+		Usage: "the number of days to transition noncurrent versions",
+	},
+	cli.StringFlag{
+		Name:  "noncurrentversion-transition-storage-class",
+		Usage: "storage class for noncurrent versions transition",
+	},
+	cli.StringFlag{
+		Name:  "abort-incomplete-mpu-days",
+		Usage: "the number of days to abort incomplete multipart uploads",
+	},
 }
 
 type ilmAddMessage struct {
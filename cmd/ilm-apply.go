@@ -0,0 +1,168 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/ilm"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var ilmApplyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "yes, y",
+		Usage: "apply the changes without prompting for confirmation",
+	},
+}
+
+var ilmApplyCmd = cli.Command{
+	Name:   "apply",
+	Usage:  "apply a lifecycle configuration file to a bucket if it differs",
+	Action: mainILMApply,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmApplyFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE TARGET
+
+FILE:
+  A lifecycle configuration in JSON format, as written by 'ilm export'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Compare the rules of FILE with the lifecycle configuration of TARGET by rule ID, print the
+  added, changed and removed rules and set the lifecycle configuration of TARGET to FILE once
+  confirmed. Nothing is modified when they do not differ. When there are changes to apply, the
+  confirmation is mandatory with '--yes' when STDIN is not a terminal or with '--json'.
+
+EXAMPLES:
+  1. Apply 'lifecycle.json' to 'testbucket', prompting for confirmation.
+     {{.Prompt}} {{.HelpName}} lifecycle.json myminio/testbucket
+
+  2. Apply 'lifecycle.json' to 'testbucket' without prompting, e.g. from a CI pipeline.
+     {{.Prompt}} {{.HelpName}} --yes lifecycle.json myminio/testbucket
+`,
+}
+
+// ilmApplyMessage container for the result of an ilm apply.
+type ilmApplyMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Applied int    `json:"applied"`
+}
+
+func (i ilmApplyMessage) String() string {
+	if i.Applied == 0 {
+		return console.Colorize(ilmThemeResultFailure, "Lifecycle configuration of `"+i.Target+"` not modified.")
+	}
+	return console.Colorize(ilmThemeResultSuccess, fmt.Sprintf("Applied %d rule change(s) to `%s` successfully.", i.Applied, i.Target))
+}
+
+func (i ilmApplyMessage) JSON() string {
+	i.Status = "success"
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkILMApplySyntax - validate arguments passed by user
+func checkILMApplySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "apply", globalErrorExitStatus)
+	}
+}
+
+// canPromptILMApply returns true if the confirmation of changes can be
+// prompted for.
+func canPromptILMApply() bool {
+	return !globalJSON && terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirmILMApply prompts for the confirmation of the changes.
+func confirmILMApply(target string, changes int) bool {
+	fmt.Printf("%s", console.Colorize(ilmThemeResultFailure,
+		fmt.Sprintf("Apply %d rule change(s) to `%s`? [y/N]: ", changes, target)))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func mainILMApply(cliCtx *cli.Context) error {
+	ctx, cancelILMApply := context.WithCancel(globalContext)
+	defer cancelILMApply()
+
+	checkILMApplySyntax(cliCtx)
+	setILMDisplayColorScheme()
+
+	args := cliCtx.Args()
+	filename, urlStr := args.Get(0), args.Get(1)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr+".")
+
+	desired, changes, err := getILMDiff(ctx, client, filename)
+	fatalIf(err.Trace(args...), "Unable to compare lifecycle configuration")
+
+	// Rules added or changed by the file are checked like `ilm add` checks them.
+	for _, change := range changes {
+		if change.Desired != nil {
+			fatalIf(ilm.ValidateRule(*change.Desired).Trace(filename), "Invalid lifecycle rule `"+change.Desired.ID+"`")
+		}
+	}
+
+	printMsg(ilmDiffMessage{
+		Target:  urlStr,
+		Changes: changes,
+	})
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !cliCtx.Bool("yes") {
+		if !canPromptILMApply() {
+			fatalIf(errInvalidArgument().Trace(urlStr), "Confirmation cannot be prompted for, please use --yes.")
+		}
+		if !confirmILMApply(urlStr, len(changes)) {
+			printMsg(ilmApplyMessage{Target: urlStr})
+			return nil
+		}
+	}
+
+	fatalIf(client.SetLifecycle(ctx, desired).Trace(urlStr), "Unable to set lifecycle rules")
+
+	printMsg(ilmApplyMessage{
+		Target:  urlStr,
+		Applied: len(changes),
+	})
+	return nil
+}
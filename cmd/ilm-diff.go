@@ -0,0 +1,153 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/ilm"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var ilmDiffCmd = cli.Command{
+	Name:   "diff",
+	Usage:  "show the differences between a lifecycle configuration file and a bucket",
+	Action: mainILMDiff,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} FILE TARGET
+
+FILE:
+  A lifecycle configuration in JSON format, as written by 'ilm export'.
+
+DESCRIPTION:
+  Compare the rules of FILE with the lifecycle configuration of TARGET by rule ID and print the
+  added, changed and removed rules. Nothing is modified on the server, use 'ilm apply' to do so.
+
+EXAMPLES:
+  1. Show the differences between 'lifecycle.json' and the lifecycle configuration of 'testbucket'.
+     {{.Prompt}} {{.HelpName}} lifecycle.json myminio/testbucket
+`,
+}
+
+// ilmDiffMessage container for the rule changes of a lifecycle configuration.
+type ilmDiffMessage struct {
+	Status  string           `json:"status"`
+	Target  string           `json:"target"`
+	Changes []ilm.RuleChange `json:"changes"`
+}
+
+func (i ilmDiffMessage) String() string {
+	if len(i.Changes) == 0 {
+		return console.Colorize(ilmThemeResultSuccess, "Lifecycle configuration of `"+i.Target+"` is up to date.")
+	}
+	var lines []string
+	for _, change := range i.Changes {
+		var current, desired string
+		if change.Current != nil {
+			current = ilm.RuleJSON(*change.Current)
+		}
+		if change.Desired != nil {
+			desired = ilm.RuleJSON(*change.Desired)
+		}
+		switch change.Op {
+		case ilm.RuleAdded:
+			lines = append(lines, console.Colorize(ilmThemeDiffAdd, "+ rule `"+change.ID+"`"))
+		case ilm.RuleChanged:
+			lines = append(lines, console.Colorize(ilmThemeDiffChange, "~ rule `"+change.ID+"`"))
+		case ilm.RuleRemoved:
+			lines = append(lines, console.Colorize(ilmThemeDiffRemove, "- rule `"+change.ID+"`"))
+		}
+		for _, line := range ilm.DiffLines(current, desired) {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				line = console.Colorize(ilmThemeDiffAdd, line)
+			case strings.HasPrefix(line, "-"):
+				line = console.Colorize(ilmThemeDiffRemove, line)
+			default:
+				line = console.Colorize(ilmThemeRow, line)
+			}
+			lines = append(lines, "    "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (i ilmDiffMessage) JSON() string {
+	i.Status = "success"
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// getILMDiff compares the lifecycle configuration in a file with the one
+// of a bucket, a bucket without lifecycle configuration has no rules.
+func getILMDiff(ctx context.Context, client Client, filename string) (ilm.LifecycleConfiguration, []ilm.RuleChange, *probe.Error) {
+	desired, err := readILMConfigFile(filename)
+	if err != nil {
+		return desired, nil, err.Trace(filename)
+	}
+
+	current, err := client.GetLifecycle(ctx)
+	if err != nil && !isBucketConfigNotSet(err) {
+		return desired, nil, err.Trace()
+	}
+
+	changes, e := ilm.Diff(current, desired)
+	if e != nil {
+		return desired, nil, probe.NewError(e).Trace(filename)
+	}
+	return desired, changes, nil
+}
+
+// checkILMDiffSyntax - validate arguments passed by user
+func checkILMDiffSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "diff", globalErrorExitStatus)
+	}
+}
+
+func mainILMDiff(cliCtx *cli.Context) error {
+	ctx, cancelILMDiff := context.WithCancel(globalContext)
+	defer cancelILMDiff()
+
+	checkILMDiffSyntax(cliCtx)
+	setILMDisplayColorScheme()
+
+	args := cliCtx.Args()
+	filename, urlStr := args.Get(0), args.Get(1)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr+".")
+
+	_, changes, err := getILMDiff(ctx, client, filename)
+	fatalIf(err.Trace(args...), "Unable to compare lifecycle configuration")
+
+	printMsg(ilmDiffMessage{
+		Target:  urlStr,
+		Changes: changes,
+	})
+	return nil
+}
@@ -35,6 +35,9 @@ var ilmCmd = cli.Command{
 		ilmRemoveCmd,
 		ilmExportCmd,
 		ilmImportCmd,
+		ilmSimulateCmd,
+		ilmDiffCmd,
+		ilmApplyCmd,
 	},
 }
 
@@ -46,6 +49,9 @@ const (
 	ilmThemeExpiry        string = "Row-Expiry"
 	ilmThemeResultSuccess string = "SuccessOp"
 	ilmThemeResultFailure string = "FailureOp"
+	ilmThemeDiffAdd       string = "Diff-Add"
+	ilmThemeDiffChange    string = "Diff-Change"
+	ilmThemeDiffRemove    string = "Diff-Remove"
 )
 
 func mainILM(ctx *cli.Context) error {
@@ -62,4 +68,7 @@ func setILMDisplayColorScheme() {
 	console.SetColor(ilmThemeExpiry, color.New(color.BlinkRapid, color.FgGreen))
 	console.SetColor(ilmThemeResultSuccess, color.New(color.FgGreen, color.Bold))
 	console.SetColor(ilmThemeResultFailure, color.New(color.FgHiYellow, color.Bold))
+	console.SetColor(ilmThemeDiffAdd, color.New(color.FgGreen))
+	console.SetColor(ilmThemeDiffChange, color.New(color.FgYellow))
+	console.SetColor(ilmThemeDiffRemove, color.New(color.FgRed))
 }
@@ -0,0 +1,313 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/ilm"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/tags"
+	"github.com/minio/minio/pkg/console"
+)
+
+// ilmSimulateSampleKeys is the number of sample keys reported per rule.
+const ilmSimulateSampleKeys = 5
+
+var ilmSimulateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "config",
+		Usage: "lifecycle configuration file in JSON format, defaults to the configuration of the bucket",
+	},
+	cli.StringFlag{
+		Name:  "at",
+		Usage: "evaluate the rules at this date in YYYY-MM-DD or RFC3339 format, defaults to now",
+	},
+}
+
+var ilmSimulateCmd = cli.Command{
+	Name:   "simulate",
+	Usage:  "preview objects a lifecycle configuration would expire or transition",
+	Action: mainILMSimulate,
+	Before: setGlobalsFromContext,
+	Flags:  append(ilmSimulateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Lists the bucket and evaluates the filter and the thresholds of each lifecycle rule
+  on every object client-side. Nothing is modified on the server.
+
+EXAMPLES:
+  1. Preview the objects the current lifecycle configuration of 'testbucket' expires or transitions today.
+     {{.Prompt}} {{.HelpName}} myminio/testbucket
+
+  2. Preview the rules in 'lifecycle.json' on 'testbucket' before importing them.
+     {{.Prompt}} {{.HelpName}} --config lifecycle.json myminio/testbucket
+
+  3. Preview the objects the current lifecycle configuration of 'testbucket' acts on by 1st January 2021.
+     {{.Prompt}} {{.HelpName}} --at 2021-01-01 myminio/testbucket
+
+  4. Print every object acted on in JSON format.
+     {{.Prompt}} {{.HelpName}} --json myminio/testbucket
+`,
+}
+
+// ilmSimulateObjectMessage is an object a rule acts on, printed only in JSON format.
+type ilmSimulateObjectMessage struct {
+	Status       string           `json:"status"`
+	Rule         string           `json:"rule"`
+	Action       ilm.ObjectAction `json:"action"`
+	StorageClass string           `json:"storageClass,omitempty"`
+	Key          string           `json:"key"`
+	Size         int64            `json:"size"`
+	LastModified time.Time        `json:"lastModified"`
+}
+
+func (i ilmSimulateObjectMessage) String() string {
+	return ""
+}
+
+func (i ilmSimulateObjectMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// ilmSimulateRuleMessage summarizes an action of a rule.
+type ilmSimulateRuleMessage struct {
+	Status       string           `json:"status"`
+	Rule         string           `json:"rule"`
+	Action       ilm.ObjectAction `json:"action,omitempty"`
+	StorageClass string           `json:"storageClass,omitempty"`
+	Objects      int64            `json:"objects"`
+	Size         int64            `json:"size"`
+	SampleKeys   []string         `json:"sampleKeys,omitempty"`
+}
+
+func (i ilmSimulateRuleMessage) String() string {
+	if i.Objects == 0 {
+		return console.Colorize(ilmThemeRow, "Rule `"+i.Rule+"`: no objects affected.")
+	}
+	action := string(i.Action)
+	if i.StorageClass != "" {
+		action += " to " + i.StorageClass
+	}
+	msg := console.Colorize(ilmThemeResultSuccess, fmt.Sprintf("Rule `%s`: %s %d object(s), %s",
+		i.Rule, action, i.Objects, humanize.IBytes(uint64(i.Size))))
+	for _, key := range i.SampleKeys {
+		msg += "\n   " + console.Colorize(ilmThemeRow, key)
+	}
+	if i.Objects > int64(len(i.SampleKeys)) {
+		msg += "\n   " + console.Colorize(ilmThemeRow, "...")
+	}
+	return msg
+}
+
+func (i ilmSimulateRuleMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// readILMConfigFile reads a lifecycle configuration in JSON format from a file.
+func readILMConfigFile(filename string) (ilm.LifecycleConfiguration, *probe.Error) {
+	var ilmCfg ilm.LifecycleConfiguration
+	f, e := os.Open(filename)
+	if e != nil {
+		return ilmCfg, probe.NewError(e)
+	}
+	defer f.Close()
+	if e = json.NewDecoder(f).Decode(&ilmCfg); e != nil {
+		return ilmCfg, probe.NewError(e)
+	}
+	return ilmCfg, nil
+}
+
+// parseILMSimulateDate parses the date of --at, a day or an RFC3339 time.
+func parseILMSimulateDate(value string) (time.Time, *probe.Error) {
+	if value == "" {
+		return UTCNow(), nil
+	}
+	if t, e := time.Parse("2006-01-02", value); e == nil {
+		return t, nil
+	}
+	t, e := time.Parse(time.RFC3339, value)
+	if e != nil {
+		return t, probe.NewError(e)
+	}
+	return t.UTC(), nil
+}
+
+// checkILMSimulateSyntax - validate arguments passed by user
+func checkILMSimulateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "simulate", globalErrorExitStatus)
+	}
+	if _, err := parseILMSimulateDate(ctx.String("at")); err != nil {
+		fatalIf(err.Trace(ctx.String("at")), "Unable to parse --at date.")
+	}
+}
+
+// ilmSimulateStats aggregates the objects a rule acts on.
+type ilmSimulateStats struct {
+	action       ilm.ObjectAction
+	storageClass string
+	objects      int64
+	size         int64
+	sampleKeys   []string
+}
+
+func mainILMSimulate(cliCtx *cli.Context) error {
+	ctx, cancelILMSimulate := context.WithCancel(globalContext)
+	defer cancelILMSimulate()
+
+	checkILMSimulateSyntax(cliCtx)
+	setILMDisplayColorScheme()
+
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+	at, _ := parseILMSimulateDate(cliCtx.String("at"))
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr+".")
+
+	var ilmCfg ilm.LifecycleConfiguration
+	if filename := cliCtx.String("config"); filename != "" {
+		ilmCfg, err = readILMConfigFile(filename)
+		fatalIf(err.Trace(filename), "Unable to read ILM configuration")
+	} else {
+		ilmCfg, err = client.GetLifecycle(ctx)
+		fatalIf(err.Trace(urlStr), "Unable to get lifecycle configuration")
+	}
+	if len(ilmCfg.Rules) == 0 {
+		fatalIf(probe.NewError(errors.New("lifecycle configuration not set")).Trace(urlStr),
+			"Unable to simulate lifecycle configuration")
+	}
+
+	// Object tags are only fetched if a rule filters on them.
+	var needTags bool
+	for _, rule := range ilmCfg.Rules {
+		if len(rule.FilterTags()) > 0 {
+			needTags = true
+		}
+	}
+
+	alias, _, _ := mustExpandAlias(urlStr)
+	bucket := bucketFromURL(urlStr)
+	stats := make([][]*ilmSimulateStats, len(ilmCfg.Rules))
+
+	var cErr error
+	for content := range client.List(ctx, true, false, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(urlStr), "Unable to list folder.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		key := strings.TrimPrefix(filepath.ToSlash(content.URL.Path), "/")
+		key = strings.TrimPrefix(key, bucket+"/")
+
+		var objTags map[string]string
+		if needTags {
+			objClient, err := newClientFromAlias(alias, content.URL.String())
+			if err == nil {
+				var t *tags.Tags
+				if t, err = objClient.GetTags(ctx); err == nil {
+					objTags = t.ToMap()
+				}
+			}
+			if err != nil {
+				errorIf(err.Trace(content.URL.String()), "Unable to get object tags.")
+				cErr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+		}
+
+		for i, rule := range ilmCfg.Rules {
+			if !rule.Matches(key, objTags) {
+				continue
+			}
+			action, storageClass := rule.Action(content.Time, at)
+			if action == ilm.NoneAction {
+				continue
+			}
+			var s *ilmSimulateStats
+			for _, ruleStats := range stats[i] {
+				if ruleStats.action == action && ruleStats.storageClass == storageClass {
+					s = ruleStats
+				}
+			}
+			if s == nil {
+				s = &ilmSimulateStats{action: action, storageClass: storageClass}
+				stats[i] = append(stats[i], s)
+			}
+			s.objects++
+			s.size += content.Size
+			if len(s.sampleKeys) < ilmSimulateSampleKeys {
+				s.sampleKeys = append(s.sampleKeys, key)
+			}
+			if globalJSON {
+				printMsg(ilmSimulateObjectMessage{
+					Status:       "success",
+					Rule:         rule.ID,
+					Action:       action,
+					StorageClass: storageClass,
+					Key:          key,
+					Size:         content.Size,
+					LastModified: content.Time,
+				})
+			}
+		}
+	}
+
+	for i, rule := range ilmCfg.Rules {
+		if len(stats[i]) == 0 {
+			printMsg(ilmSimulateRuleMessage{
+				Status: "success",
+				Rule:   rule.ID,
+			})
+			continue
+		}
+		for _, s := range stats[i] {
+			printMsg(ilmSimulateRuleMessage{
+				Status:       "success",
+				Rule:         rule.ID,
+				Action:       s.action,
+				StorageClass: s.storageClass,
+				Objects:      s.objects,
+				Size:         s.size,
+				SampleKeys:   s.sampleKeys,
+			})
+		}
+	}
+	return cErr
+}
@@ -28,11 +28,17 @@ type AbortIncompleteMultipartUpload struct {
 	DaysAfterInitiation int64    `xml:"DaysAfterInitiation,omitempty" json:"DaysAfterInitiation,omitempty"`
 }
 
+// NoncurrentVersionExpiration structure - days after which noncurrent versions are removed
+type NoncurrentVersionExpiration struct {
+	XMLName        xml.Name `xml:"NoncurrentVersionExpiration,omitempty" json:"-"`
+	NoncurrentDays int      `xml:"NoncurrentDays,omitempty" json:"NoncurrentDays,omitempty"`
+}
+
 // NoncurrentVersionTransition structure
 type NoncurrentVersionTransition struct {
 	XMLName          xml.Name `xml:"NoncurrentVersionTransition,omitempty"  json:"-"`
 	StorageClass     string   `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
-	TransitionInDays int      `xml:"TransitionInDays,omitempty" json:"TransitionInDays,omitempty"`
+	TransitionInDays int      `xml:"NoncurrentDays,omitempty" json:"TransitionInDays,omitempty"`
 }
 
 // LifecycleTag structure key/value pair representing an object tag to apply lifecycle configuration
@@ -72,9 +78,10 @@ type LifecycleRuleFilter struct {
 
 // LifecycleExpiration structure - expiration details of lifecycle configuration
 type LifecycleExpiration struct {
-	XMLName          xml.Name   `xml:"Expiration,omitempty" json:"-"`
-	ExpirationDate   *time.Time `xml:"Date,omitempty" json:"Date,omitempty"`
-	ExpirationInDays int        `xml:"Days,omitempty" json:"Days,omitempty"`
+	XMLName                   xml.Name   `xml:"Expiration,omitempty" json:"-"`
+	ExpirationDate            *time.Time `xml:"Date,omitempty" json:"Date,omitempty"`
+	ExpirationInDays          int        `xml:"Days,omitempty" json:"Days,omitempty"`
+	ExpiredObjectDeleteMarker bool       `xml:"ExpiredObjectDeleteMarker,omitempty" json:"ExpiredObjectDeleteMarker,omitempty"`
 }
 
 // IsSet - is LifecycleExpiration set?
@@ -89,6 +96,7 @@ type LifecycleRule struct {
 	Expiration                     *LifecycleExpiration            `xml:"Expiration,omitempty" json:"Expiration,omitempty"`
 	ID                             string                          `xml:"ID" json:"ID"`
 	RuleFilter                     *LifecycleRuleFilter            `xml:"Filter,omitempty" json:"Filter,omitempty"`
+	NoncurrentVersionExpiration    *NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty" json:"NoncurrentVersionExpiration,omitempty"`
 	NoncurrentVersionTransition    *NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty" json:"NoncurrentVersionTransition,omitempty"`
 	NoncurrentVersionTransitions   []NoncurrentVersionTransition   `xml:"NoncurrentVersionTransitions,omitempty" json:"NoncurrentVersionTransitions,omitempty"`
 	Prefix                         string                          `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
@@ -0,0 +1,164 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ilm
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Rule change operations.
+const (
+	RuleAdded   = "add"
+	RuleChanged = "change"
+	RuleRemoved = "remove"
+)
+
+// RuleChange is a difference between two lifecycle configurations for
+// a rule ID.
+type RuleChange struct {
+	Op      string         `json:"op"`
+	ID      string         `json:"id"`
+	Current *LifecycleRule `json:"current,omitempty"`
+	Desired *LifecycleRule `json:"desired,omitempty"`
+}
+
+// normalizeRule drops empty filters so that a rule read back from the
+// server compares equal to the rule it was created from.
+func normalizeRule(rule LifecycleRule) LifecycleRule {
+	if f := rule.RuleFilter; f != nil {
+		filter := *f
+		if filter.And != nil && filter.And.Prefix == "" && len(filter.And.Tags) == 0 {
+			filter.And = nil
+		}
+		if filter.Tag != nil && filter.Tag.Key == "" {
+			filter.Tag = nil
+		}
+		rule.RuleFilter = &filter
+		if filter.And == nil && filter.Prefix == "" && filter.Tag == nil {
+			rule.RuleFilter = nil
+		}
+	}
+	if rule.Expiration != nil && !rule.Expiration.IsSet() && !rule.Expiration.ExpiredObjectDeleteMarker {
+		rule.Expiration = nil
+	}
+	if rule.Transition != nil && !rule.Transition.IsSet() {
+		rule.Transition = nil
+	}
+	return rule
+}
+
+// RuleJSON returns the rule in indented JSON format.
+func RuleJSON(rule LifecycleRule) string {
+	ruleBytes, e := json.MarshalIndent(normalizeRule(rule), "", " ")
+	if e != nil {
+		return ""
+	}
+	return string(ruleBytes)
+}
+
+// Diff compares the rules of two lifecycle configurations by ID, the
+// added and changed rules are returned in the order of desired followed
+// by the removed rules in the order of current.
+func Diff(current, desired LifecycleConfiguration) ([]RuleChange, error) {
+	currentRules := make(map[string]LifecycleRule, len(current.Rules))
+	for _, rule := range current.Rules {
+		currentRules[rule.ID] = rule
+	}
+
+	var changes []RuleChange
+	desiredIDs := make(map[string]bool, len(desired.Rules))
+	for i := range desired.Rules {
+		rule := desired.Rules[i]
+		if rule.ID == "" {
+			return nil, errors.New("lifecycle rule ID cannot be empty")
+		}
+		if desiredIDs[rule.ID] {
+			return nil, errors.New("duplicate lifecycle rule ID `" + rule.ID + "`")
+		}
+		desiredIDs[rule.ID] = true
+
+		currentRule, ok := currentRules[rule.ID]
+		switch {
+		case !ok:
+			changes = append(changes, RuleChange{Op: RuleAdded, ID: rule.ID, Desired: &rule})
+		case RuleJSON(currentRule) != RuleJSON(rule):
+			changes = append(changes, RuleChange{Op: RuleChanged, ID: rule.ID, Current: &currentRule, Desired: &rule})
+		}
+	}
+	for i := range current.Rules {
+		rule := current.Rules[i]
+		if !desiredIDs[rule.ID] {
+			changes = append(changes, RuleChange{Op: RuleRemoved, ID: rule.ID, Current: &rule})
+		}
+	}
+	return changes, nil
+}
+
+// DiffLines returns a line diff turning a into b, each line is prefixed
+// with "  ", "- " or "+ ".
+func DiffLines(a, b string) []string {
+	var x, y []string
+	if a != "" {
+		x = strings.Split(a, "\n")
+	}
+	if b != "" {
+		y = strings.Split(b, "\n")
+	}
+
+	// Longest common subsequence of the lines.
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			lines = append(lines, "  "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "- "+x[i])
+			i++
+		default:
+			lines = append(lines, "+ "+y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		lines = append(lines, "- "+x[i])
+	}
+	for ; j < len(y); j++ {
+		lines = append(lines, "+ "+y[j])
+	}
+	return lines
+}
@@ -0,0 +1,91 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ilm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	expire := func(id string, days int) LifecycleRule {
+		return LifecycleRule{ID: id, Status: "Enabled", Expiration: &LifecycleExpiration{ExpirationInDays: days}}
+	}
+
+	testCases := []struct {
+		current    []LifecycleRule
+		desired    []LifecycleRule
+		shouldPass bool
+		ops        []string
+	}{
+		// Identical configurations.
+		{[]LifecycleRule{expire("a", 1)}, []LifecycleRule{expire("a", 1)}, true, nil},
+		// Empty filters are not a change.
+		{
+			[]LifecycleRule{func() LifecycleRule {
+				rule := expire("a", 1)
+				rule.RuleFilter = &LifecycleRuleFilter{}
+				return rule
+			}()},
+			[]LifecycleRule{expire("a", 1)}, true, nil,
+		},
+		// Added, changed and removed rules.
+		{
+			[]LifecycleRule{expire("a", 1), expire("b", 1)},
+			[]LifecycleRule{expire("c", 1), expire("a", 2)}, true,
+			[]string{RuleAdded, RuleChanged, RuleRemoved},
+		},
+		// Missing rule ID.
+		{nil, []LifecycleRule{expire("", 1)}, false, nil},
+		// Duplicate rule ID.
+		{nil, []LifecycleRule{expire("a", 1), expire("a", 2)}, false, nil},
+	}
+
+	for i, testCase := range testCases {
+		changes, e := Diff(LifecycleConfiguration{Rules: testCase.current}, LifecycleConfiguration{Rules: testCase.desired})
+		if testCase.shouldPass && e != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, e)
+		}
+		if !testCase.shouldPass && e == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		var ops []string
+		for _, change := range changes {
+			ops = append(ops, change.Op)
+		}
+		if !reflect.DeepEqual(ops, testCase.ops) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.ops, ops)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		lines []string
+	}{
+		{"", "x\ny", []string{"+ x", "+ y"}},
+		{"x\ny", "", []string{"- x", "- y"}},
+		{"x\ny\nz", "x\nw\nz", []string{"  x", "- y", "+ w", "  z"}},
+	}
+
+	for i, testCase := range testCases {
+		if lines := DiffLines(testCase.a, testCase.b); !reflect.DeepEqual(lines, testCase.lines) {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.lines, lines)
+		}
+	}
+}
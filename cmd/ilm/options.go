@@ -103,6 +103,12 @@ type lifecycleOptions struct {
 	TransitionDate string
 	TransitionDays string
 	StorageClass   string
+
+	ExpiredObjectDeleteMarker               bool
+	NoncurrentVersionExpirationDays         string
+	NoncurrentVersionTransitionDays         string
+	NoncurrentVersionTransitionStorageClass string
+	AbortIncompleteMPUDays                  string
 }
 
 func (opts lifecycleOptions) ToConfig(lfcCfg LifecycleConfiguration) (LifecycleConfiguration, *probe.Error) {
@@ -116,6 +122,22 @@ func (opts lifecycleOptions) ToConfig(lfcCfg LifecycleConfiguration) (LifecycleC
 		return lfcCfg, err.Trace(opts.StorageClass, opts.TransitionDate, opts.TransitionDays)
 	}
 
+	ilmNoncurrentExpiration, err := parseNoncurrentVersionExpiration(opts.NoncurrentVersionExpirationDays)
+	if err != nil {
+		return lfcCfg, err.Trace(opts.NoncurrentVersionExpirationDays)
+	}
+
+	ilmNoncurrentTransition, err := parseNoncurrentVersionTransition(opts.NoncurrentVersionTransitionStorageClass,
+		opts.NoncurrentVersionTransitionDays)
+	if err != nil {
+		return lfcCfg, err.Trace(opts.NoncurrentVersionTransitionStorageClass, opts.NoncurrentVersionTransitionDays)
+	}
+
+	ilmAbortMPU, err := parseAbortIncompleteMultipartUpload(opts.AbortIncompleteMPUDays)
+	if err != nil {
+		return lfcCfg, err.Trace(opts.AbortIncompleteMPUDays)
+	}
+
 	andVal := LifecycleAndOperator{
 		Tags: extractILMTags(opts.Tags),
 	}
@@ -132,7 +154,8 @@ func (opts lifecycleOptions) ToConfig(lfcCfg LifecycleConfiguration) (LifecycleC
 	if ilmTransition.IsSet() {
 		transP = &ilmTransition
 	}
-	if ilmExpiry.IsSet() {
+	ilmExpiry.ExpiredObjectDeleteMarker = opts.ExpiredObjectDeleteMarker
+	if ilmExpiry.IsSet() || ilmExpiry.ExpiredObjectDeleteMarker {
 		expP = &ilmExpiry
 	}
 
@@ -147,6 +170,10 @@ func (opts lifecycleOptions) ToConfig(lfcCfg LifecycleConfiguration) (LifecycleC
 		}(),
 		Expiration: expP,
 		Transition: transP,
+
+		NoncurrentVersionExpiration:    ilmNoncurrentExpiration,
+		NoncurrentVersionTransition:    ilmNoncurrentTransition,
+		AbortIncompleteMultipartUpload: ilmAbortMPU,
 	}
 
 	if err := validateILMRule(newRule); err != nil {
@@ -181,6 +208,12 @@ func getLifecycleOptions(ctx *cli.Context) lifecycleOptions {
 		TransitionDate: ctx.String("transition-date"),
 		TransitionDays: ctx.String("transition-days"),
 		StorageClass:   ctx.String("storage-class"),
+
+		ExpiredObjectDeleteMarker:               ctx.Bool("expired-object-delete-marker"),
+		NoncurrentVersionExpirationDays:         ctx.String("noncurrentversion-expiration-days"),
+		NoncurrentVersionTransitionDays:         ctx.String("noncurrentversion-transition-days"),
+		NoncurrentVersionTransitionStorageClass: ctx.String("noncurrentversion-transition-storage-class"),
+		AbortIncompleteMPUDays:                  ctx.String("abort-incomplete-mpu-days"),
 	}
 }
 
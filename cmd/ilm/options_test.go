@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ilm
+
+import (
+	"testing"
+)
+
+func TestToConfigNoncurrentVersion(t *testing.T) {
+	testCases := []struct {
+		opts       lifecycleOptions
+		shouldPass bool
+	}{
+		// Noncurrent version transition and expiration.
+		{lifecycleOptions{ID: "one", NoncurrentVersionExpirationDays: "90",
+			NoncurrentVersionTransitionDays: "30", NoncurrentVersionTransitionStorageClass: "warm"}, true},
+		// Noncurrent version transition without storage class.
+		{lifecycleOptions{ID: "one", NoncurrentVersionTransitionDays: "30"}, false},
+		// Noncurrent version transition after expiration.
+		{lifecycleOptions{ID: "one", NoncurrentVersionExpirationDays: "30",
+			NoncurrentVersionTransitionDays: "90", NoncurrentVersionTransitionStorageClass: "WARM"}, false},
+		// Invalid noncurrent version expiration days.
+		{lifecycleOptions{ID: "one", NoncurrentVersionExpirationDays: "0"}, false},
+		// Expired object delete marker alone.
+		{lifecycleOptions{ID: "one", ExpiredObjectDeleteMarker: true}, true},
+		// Expired object delete marker along with expiry days.
+		{lifecycleOptions{ID: "one", ExpiredObjectDeleteMarker: true, ExpiryDays: "10"}, false},
+		// Expired object delete marker with tags.
+		{lifecycleOptions{ID: "one", ExpiredObjectDeleteMarker: true, Tags: "k=v"}, false},
+		// Abort incomplete multipart uploads.
+		{lifecycleOptions{ID: "one", AbortIncompleteMPUDays: "7"}, true},
+		// Abort incomplete multipart uploads with tags.
+		{lifecycleOptions{ID: "one", AbortIncompleteMPUDays: "7", Tags: "k=v"}, false},
+		// No action.
+		{lifecycleOptions{ID: "one"}, false},
+	}
+
+	for i, testCase := range testCases {
+		cfg, err := testCase.opts.ToConfig(LifecycleConfiguration{})
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if testCase.shouldPass && len(cfg.Rules) != 1 {
+			t.Fatalf("Test %d: expected 1 rule, got %d", i+1, len(cfg.Rules))
+		}
+	}
+
+	cfg, err := testCases[0].opts.ToConfig(LifecycleConfiguration{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rule := cfg.Rules[0]
+	if rule.NoncurrentVersionTransition.StorageClass != "WARM" || rule.NoncurrentVersionTransition.TransitionInDays != 30 ||
+		rule.NoncurrentVersionExpiration.NoncurrentDays != 90 {
+		t.Fatalf("Unexpected rule %+v", rule)
+	}
+}
@@ -84,13 +84,44 @@ func validateTranDays(rule LifecycleRule) error {
 func validateRuleAction(rule LifecycleRule) error {
 	expirySet := (rule.Expiration != nil)
 	transitionSet := (rule.Transition != nil)
-	if !expirySet && !transitionSet {
-		errMsg := "At least one action (Expiry or Transition) needs to be specified in a rule."
+	noncurrentSet := (rule.NoncurrentVersionExpiration != nil) || (rule.NoncurrentVersionTransition != nil)
+	abortMPUSet := (rule.AbortIncompleteMultipartUpload != nil)
+	if !expirySet && !transitionSet && !noncurrentSet && !abortMPUSet {
+		errMsg := "At least one action (Expiry, Transition, Noncurrent version expiry/transition or Abort incomplete multipart upload) needs to be specified in a rule."
 		return errors.New(errMsg)
 	}
 	return nil
 }
 
+// Expired object delete markers cannot be removed along with objects expiring
+// by date/days, nor selected by tags.
+func validateExpiredObjectDeleteMarker(rule LifecycleRule) error {
+	if rule.Expiration == nil || !rule.Expiration.ExpiredObjectDeleteMarker {
+		return nil
+	}
+	if rule.Expiration.IsSet() {
+		return errors.New("Expired object delete marker cannot be set along with expiry date/days")
+	}
+	if len(getTagArr(rule)) > 0 {
+		return errors.New("Expired object delete marker cannot be set for a rule with tags")
+	}
+	return nil
+}
+
+// Noncurrent versions have to transition before they expire. Incomplete
+// multipart uploads cannot be selected by tags.
+func validateNoncurrentVersion(rule LifecycleRule) error {
+	expiration := rule.NoncurrentVersionExpiration
+	transition := rule.NoncurrentVersionTransition
+	if expiration != nil && transition != nil && transition.TransitionInDays >= expiration.NoncurrentDays {
+		return errors.New("Noncurrent version transition should happen before noncurrent version expiration")
+	}
+	if rule.AbortIncompleteMultipartUpload != nil && len(getTagArr(rule)) > 0 {
+		return errors.New("Abort incomplete multipart upload cannot be set for a rule with tags")
+	}
+	return nil
+}
+
 // Check if any date is before than cur date
 func validateTranExpCurdate(rule LifecycleRule) error {
 	var err error
@@ -126,9 +157,21 @@ func validateILMRule(rule LifecycleRule) *probe.Error {
 	if e := validateTranDays(rule); e != nil {
 		return probe.NewError(e)
 	}
+	if e := validateExpiredObjectDeleteMarker(rule); e != nil {
+		return probe.NewError(e)
+	}
+	if e := validateNoncurrentVersion(rule); e != nil {
+		return probe.NewError(e)
+	}
 	return nil
 }
 
+// ValidateRule runs the checks of a rule added with `ilm add` on a rule
+// coming from elsewhere, e.g. a lifecycle configuration file.
+func ValidateRule(rule LifecycleRule) *probe.Error {
+	return validateILMRule(rule).Trace(rule.ID)
+}
+
 // Returns valid lifecycleTransition to be included in lifecycleRule
 func parseTransition(storageClass, transitionDateStr, transitionDayStr string) (transition LifecycleTransition, err *probe.Error) {
 	storageClass = strings.ToUpper(storageClass) // Just-in-case the user has entered lower case characters.
@@ -180,3 +223,55 @@ func parseExpiry(expiryDateStr, expiryDayStr string) (lfcExp LifecycleExpiration
 
 	return lfcExp, nil
 }
+
+// Returns the number of days of a lifecycle action, which must be positive.
+func parseDays(daysStr, action string) (int, *probe.Error) {
+	days, e := strconv.Atoi(daysStr)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if days <= 0 {
+		return 0, probe.NewError(errors.New(action + " days must be a positive number"))
+	}
+	return days, nil
+}
+
+// Returns noncurrentVersionExpiration to be included in lifecycleRule
+func parseNoncurrentVersionExpiration(daysStr string) (*NoncurrentVersionExpiration, *probe.Error) {
+	if daysStr == "" {
+		return nil, nil
+	}
+	days, err := parseDays(daysStr, "noncurrent version expiration")
+	if err != nil {
+		return nil, err.Trace(daysStr)
+	}
+	return &NoncurrentVersionExpiration{NoncurrentDays: days}, nil
+}
+
+// Returns noncurrentVersionTransition to be included in lifecycleRule
+func parseNoncurrentVersionTransition(storageClass, daysStr string) (*NoncurrentVersionTransition, *probe.Error) {
+	storageClass = strings.ToUpper(storageClass)
+	if storageClass == "" && daysStr == "" {
+		return nil, nil
+	}
+	if storageClass == "" || daysStr == "" {
+		return nil, probe.NewError(errors.New("both noncurrent version transition days and storage class must be set"))
+	}
+	days, err := parseDays(daysStr, "noncurrent version transition")
+	if err != nil {
+		return nil, err.Trace(daysStr)
+	}
+	return &NoncurrentVersionTransition{StorageClass: storageClass, TransitionInDays: days}, nil
+}
+
+// Returns abortIncompleteMultipartUpload to be included in lifecycleRule
+func parseAbortIncompleteMultipartUpload(daysStr string) (*AbortIncompleteMultipartUpload, *probe.Error) {
+	if daysStr == "" {
+		return nil, nil
+	}
+	days, err := parseDays(daysStr, "abort incomplete multipart upload")
+	if err != nil {
+		return nil, err.Trace(daysStr)
+	}
+	return &AbortIncompleteMultipartUpload{DaysAfterInitiation: int64(days)}, nil
+}
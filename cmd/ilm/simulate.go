@@ -0,0 +1,142 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ilm
+
+import (
+	"strings"
+	"time"
+)
+
+// ObjectAction is the action a lifecycle rule takes on an object.
+type ObjectAction string
+
+const (
+	// NoneAction - the rule does not act on the object.
+	NoneAction ObjectAction = ""
+	// ExpireAction - the object is removed.
+	ExpireAction ObjectAction = "expire"
+	// TransitionAction - the object is moved to another storage class.
+	TransitionAction ObjectAction = "transition"
+)
+
+// ExpectedExpiryTime returns the time an object modified at modTime is
+// days old, rounded up to the next midnight UTC as done by S3.
+func ExpectedExpiryTime(modTime time.Time, days int) time.Time {
+	t := modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour)
+	return t.Truncate(24 * time.Hour)
+}
+
+// FilterPrefix returns the key prefix selected by the rule.
+func (rule LifecycleRule) FilterPrefix() string {
+	if rule.RuleFilter != nil {
+		if rule.RuleFilter.And != nil {
+			return rule.RuleFilter.And.Prefix
+		}
+		if rule.RuleFilter.Prefix != "" {
+			return rule.RuleFilter.Prefix
+		}
+	}
+	return rule.Prefix
+}
+
+// FilterTags returns the object tags selected by the rule.
+func (rule LifecycleRule) FilterTags() []LifecycleTag {
+	var tags []LifecycleTag
+	if rule.RuleFilter != nil {
+		if rule.RuleFilter.And != nil {
+			tags = append(tags, rule.RuleFilter.And.Tags...)
+		}
+		if rule.RuleFilter.Tag != nil && rule.RuleFilter.Tag.Key != "" {
+			tags = append(tags, *rule.RuleFilter.Tag)
+		}
+	}
+	return append(tags, rule.TagFilters...)
+}
+
+// MatchesPrefix reports if an object key is selected by the prefix
+// filter of the rule.
+func (rule LifecycleRule) MatchesPrefix(key string) bool {
+	return strings.HasPrefix(key, rule.FilterPrefix())
+}
+
+// Matches reports if an object is selected by the filter of the rule,
+// all of the prefix and the tags must match.
+func (rule LifecycleRule) Matches(key string, tags map[string]string) bool {
+	if !rule.MatchesPrefix(key) {
+		return false
+	}
+	for _, tag := range rule.FilterTags() {
+		if value, ok := tags[tag.Key]; !ok || value != tag.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// transitionDue reports if a transition is due at 'at' for an object
+// modified at modTime.
+func transitionDue(transition LifecycleTransition, modTime, at time.Time) bool {
+	if transition.TransitionDate != nil && !transition.TransitionDate.IsZero() {
+		return !at.Before(*transition.TransitionDate)
+	}
+	if transition.TransitionInDays > 0 {
+		return !at.Before(ExpectedExpiryTime(modTime, transition.TransitionInDays))
+	}
+	return false
+}
+
+// Action returns the action the rule takes at 'at' on a current object
+// modified at modTime, and the storage class of a transition. The
+// filter of the rule is not evaluated, expiration wins over transition.
+func (rule LifecycleRule) Action(modTime, at time.Time) (ObjectAction, string) {
+	if rule.Status != "Enabled" {
+		return NoneAction, ""
+	}
+	if exp := rule.Expiration; exp != nil && exp.IsSet() {
+		if exp.ExpirationDate != nil && !exp.ExpirationDate.IsZero() {
+			if !at.Before(*exp.ExpirationDate) {
+				return ExpireAction, ""
+			}
+		} else if !at.Before(ExpectedExpiryTime(modTime, exp.ExpirationInDays)) {
+			return ExpireAction, ""
+		}
+	}
+
+	transitions := rule.Transitions
+	if rule.Transition != nil {
+		transitions = append([]LifecycleTransition{*rule.Transition}, transitions...)
+	}
+	// The latest due transition decides the storage class.
+	var storageClass string
+	var dueAt time.Time
+	for _, transition := range transitions {
+		if !transition.IsSet() || !transitionDue(transition, modTime, at) {
+			continue
+		}
+		transitionAt := ExpectedExpiryTime(modTime, transition.TransitionInDays)
+		if transition.TransitionDate != nil && !transition.TransitionDate.IsZero() {
+			transitionAt = *transition.TransitionDate
+		}
+		if storageClass == "" || transitionAt.After(dueAt) {
+			storageClass, dueAt = transition.StorageClass, transitionAt
+		}
+	}
+	if storageClass != "" {
+		return TransitionAction, storageClass
+	}
+	return NoneAction, ""
+}
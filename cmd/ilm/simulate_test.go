@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ilm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuleMatches(t *testing.T) {
+	testCases := []struct {
+		rule    LifecycleRule
+		key     string
+		tags    map[string]string
+		matches bool
+	}{
+		// Empty filter matches every object.
+		{LifecycleRule{}, "docs/a.txt", nil, true},
+		// Prefix filter.
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{Prefix: "docs/"}}, "docs/a.txt", nil, true},
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{Prefix: "docs/"}}, "logs/a.txt", nil, false},
+		// Tag filter.
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{Tag: &LifecycleTag{Key: "k", Value: "v"}}}, "a.txt", map[string]string{"k": "v"}, true},
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{Tag: &LifecycleTag{Key: "k", Value: "v"}}}, "a.txt", map[string]string{"k": "x"}, false},
+		// And operator, all of prefix and tags must match.
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{And: &LifecycleAndOperator{
+			Prefix: "docs/",
+			Tags:   []LifecycleTag{{Key: "k1", Value: "v1"}, {Key: "k2", Value: "v2"}},
+		}}}, "docs/a.txt", map[string]string{"k1": "v1", "k2": "v2"}, true},
+		{LifecycleRule{RuleFilter: &LifecycleRuleFilter{And: &LifecycleAndOperator{
+			Prefix: "docs/",
+			Tags:   []LifecycleTag{{Key: "k1", Value: "v1"}, {Key: "k2", Value: "v2"}},
+		}}}, "docs/a.txt", map[string]string{"k1": "v1"}, false},
+	}
+
+	for i, testCase := range testCases {
+		if matches := testCase.rule.Matches(testCase.key, testCase.tags); matches != testCase.matches {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.matches, matches)
+		}
+	}
+}
+
+func TestRuleAction(t *testing.T) {
+	modTime := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	date := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		rule         LifecycleRule
+		at           time.Time
+		action       ObjectAction
+		storageClass string
+	}{
+		// Disabled rule.
+		{LifecycleRule{Status: "Disabled", Expiration: &LifecycleExpiration{ExpirationInDays: 1}}, date, NoneAction, ""},
+		// Expiration in days, rounded up to midnight.
+		{LifecycleRule{Status: "Enabled", Expiration: &LifecycleExpiration{ExpirationInDays: 1}},
+			time.Date(2020, 1, 2, 23, 0, 0, 0, time.UTC), NoneAction, ""},
+		{LifecycleRule{Status: "Enabled", Expiration: &LifecycleExpiration{ExpirationInDays: 1}},
+			time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), ExpireAction, ""},
+		// Expiration date.
+		{LifecycleRule{Status: "Enabled", Expiration: &LifecycleExpiration{ExpirationDate: &date}},
+			date.Add(-time.Hour), NoneAction, ""},
+		{LifecycleRule{Status: "Enabled", Expiration: &LifecycleExpiration{ExpirationDate: &date}}, date, ExpireAction, ""},
+		// Transition in days.
+		{LifecycleRule{Status: "Enabled", Transition: &LifecycleTransition{TransitionInDays: 30, StorageClass: "WARM"}},
+			date, TransitionAction, "WARM"},
+		// Latest due transition wins.
+		{LifecycleRule{Status: "Enabled", Transitions: []LifecycleTransition{
+			{TransitionInDays: 30, StorageClass: "WARM"},
+			{TransitionInDays: 90, StorageClass: "COLD"},
+			{TransitionInDays: 365, StorageClass: "GLACIER"},
+		}}, date, TransitionAction, "COLD"},
+		// Expiration wins over transition.
+		{LifecycleRule{
+			Status:     "Enabled",
+			Expiration: &LifecycleExpiration{ExpirationInDays: 60},
+			Transition: &LifecycleTransition{TransitionInDays: 30, StorageClass: "WARM"},
+		}, date, ExpireAction, ""},
+	}
+
+	for i, testCase := range testCases {
+		action, storageClass := testCase.rule.Action(modTime, testCase.at)
+		if action != testCase.action || storageClass != testCase.storageClass {
+			t.Fatalf("Test %d: expected %q %q, got %q %q", i+1, testCase.action, testCase.storageClass, action, storageClass)
+		}
+	}
+}
@@ -39,6 +39,16 @@ const (
 	transitionDateColumnWidth int = 18
 	// StorageClassColumnWidth column width in table output
 	storageClassColumnWidth int = 18
+	// NoncurrentExpiryColumnWidth column width in table output
+	noncurrentExpiryColumnWidth int = 12
+	// NoncurrentTransitionColumnWidth column width in table output
+	noncurrentTransitionColumnWidth int = 16
+	// NoncurrentStorageClassColumnWidth column width in table output
+	noncurrentStorageClassColumnWidth int = 18
+	// DeleteMarkerColumnWidth column width in table output
+	deleteMarkerColumnWidth int = 12
+	// AbortMPUColumnWidth column width in table output
+	abortMPUColumnWidth int = 12
 )
 
 const (
@@ -58,6 +68,12 @@ const (
 	transitionLabel     string = "Transition"
 	transitionDateLabel string = "Date/Days "
 	storageClassLabel   string = "Storage-Class "
+
+	noncurrentExpiryLabel       string = "NC-Expiry "
+	noncurrentTransitionLabel   string = "NC-Transition "
+	noncurrentStorageClassLabel string = "NC-Storage-Class"
+	deleteMarkerLabel           string = "Del-Marker"
+	abortMPULabel               string = "Abort-MPU "
 )
 
 // Keys to be used in map structure which stores the columns to be displayed.
@@ -66,6 +82,12 @@ const (
 	storageClassLabelKey    string = "Storage-Class"
 	expiryDatesLabelKey     string = "Expiry-Dates"
 	transitionDatesLabelKey string = "Transition-Date"
+
+	noncurrentExpiryLabelKey       string = "NC-Expiry"
+	noncurrentTransitionLabelKey   string = "NC-Transition"
+	noncurrentStorageClassLabelKey string = "NC-Storage-Class"
+	deleteMarkerLabelKey           string = "Del-Marker"
+	abortMPULabelKey               string = "Abort-MPU"
 )
 
 // Some cell values
@@ -148,6 +170,11 @@ func getILMColumnWidthTable() map[string]int {
 	colWidth[transitionDatesLabelKey] = transitionDateColumnWidth
 	colWidth[storageClassLabelKey] = storageClassColumnWidth
 	colWidth[tagLabel] = tagsColumnWidth
+	colWidth[noncurrentExpiryLabelKey] = noncurrentExpiryColumnWidth
+	colWidth[noncurrentTransitionLabelKey] = noncurrentTransitionColumnWidth
+	colWidth[noncurrentStorageClassLabelKey] = noncurrentStorageClassColumnWidth
+	colWidth[deleteMarkerLabelKey] = deleteMarkerColumnWidth
+	colWidth[abortMPULabelKey] = abortMPUColumnWidth
 
 	return colWidth
 }
@@ -275,6 +302,46 @@ func getStorageClassName(rule LifecycleRule) string {
 	return storageClass
 }
 
+// Days after which noncurrent versions expire.
+func getNoncurrentExpiryDays(rule LifecycleRule) string {
+	if rule.NoncurrentVersionExpiration == nil || rule.NoncurrentVersionExpiration.NoncurrentDays <= 0 {
+		return blankCell
+	}
+	return strconv.Itoa(rule.NoncurrentVersionExpiration.NoncurrentDays) + " day(s)"
+}
+
+// Days after which noncurrent versions transition.
+func getNoncurrentTransitionDays(rule LifecycleRule) string {
+	if rule.NoncurrentVersionTransition == nil || rule.NoncurrentVersionTransition.TransitionInDays <= 0 {
+		return blankCell
+	}
+	return strconv.Itoa(rule.NoncurrentVersionTransition.TransitionInDays) + " day(s)"
+}
+
+// Storage class name for noncurrent version transition.
+func getNoncurrentStorageClassName(rule LifecycleRule) string {
+	if rule.NoncurrentVersionTransition == nil || rule.NoncurrentVersionTransition.StorageClass == "" {
+		return blankCell
+	}
+	return rule.NoncurrentVersionTransition.StorageClass
+}
+
+// Cross-tick if expired object delete markers are not removed.
+func getDeleteMarkerTick(rule LifecycleRule) string {
+	if rule.Expiration == nil || !rule.Expiration.ExpiredObjectDeleteMarker {
+		return crossTickCell
+	}
+	return tickCell
+}
+
+// Days after which incomplete multipart uploads are aborted.
+func getAbortMPUDays(rule LifecycleRule) string {
+	if rule.AbortIncompleteMultipartUpload == nil || rule.AbortIncompleteMultipartUpload.DaysAfterInitiation <= 0 {
+		return blankCell
+	}
+	return strconv.FormatInt(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation, 10) + " day(s)"
+}
+
 // Array of Tag strings, each in key:value format
 func getTagArr(rule LifecycleRule) []string {
 	tagArr := rule.TagFilters
@@ -307,6 +374,20 @@ func checkAddTableCell(rowArr *[]string, rowCheck map[string]int, cellInfo table
 	}
 }
 
+// Add the noncurrent version, delete marker and multipart upload cells of a row.
+func checkAddNoncurrentTableCells(rowArr *[]string, rowCheck map[string]int, rule LifecycleRule) {
+	checkAddTableCell(rowArr, rowCheck,
+		tableCellInfo{label: getNoncurrentExpiryDays(rule), labelKey: noncurrentExpiryLabelKey, columnWidth: noncurrentExpiryColumnWidth, align: centerAlign})
+	checkAddTableCell(rowArr, rowCheck,
+		tableCellInfo{label: getNoncurrentTransitionDays(rule), labelKey: noncurrentTransitionLabelKey, columnWidth: noncurrentTransitionColumnWidth, align: centerAlign})
+	checkAddTableCell(rowArr, rowCheck,
+		tableCellInfo{label: getNoncurrentStorageClassName(rule), labelKey: noncurrentStorageClassLabelKey, columnWidth: noncurrentStorageClassColumnWidth, align: centerAlign})
+	checkAddTableCell(rowArr, rowCheck,
+		tableCellInfo{label: getDeleteMarkerTick(rule), labelKey: deleteMarkerLabelKey, columnWidth: deleteMarkerColumnWidth, align: centerAlign})
+	checkAddTableCell(rowArr, rowCheck,
+		tableCellInfo{label: getAbortMPUDays(rule), labelKey: abortMPULabelKey, columnWidth: abortMPUColumnWidth, align: centerAlign})
+}
+
 // GetILMShowDataWithoutTags - Without tags
 func getILMShowDataWithoutTags(cellInfo *[][]string, rowCheck map[string]int, info LifecycleConfiguration, showOpts showDetails) {
 	*cellInfo = make([][]string, 0)
@@ -343,6 +424,7 @@ func getILMShowDataWithoutTags(cellInfo *[][]string, rowCheck map[string]int, in
 			tableCellInfo{label: getTransitionDate(rule), labelKey: transitionDatesLabelKey, columnWidth: transitionDateColumnWidth, align: centerAlign})
 		checkAddTableCell(&((*cellInfo)[count]), rowCheck,
 			tableCellInfo{label: getStorageClassName(rule), labelKey: storageClassLabelKey, columnWidth: storageClassColumnWidth, align: centerAlign})
+		checkAddNoncurrentTableCells(&((*cellInfo)[count]), rowCheck, rule)
 		checkAddTableCell(&((*cellInfo)[count]), rowCheck,
 			tableCellInfo{label: blankCell, labelKey: tagLabel, columnWidth: tagsColumnWidth, align: centerAlign})
 		count++
@@ -384,6 +466,7 @@ func getILMShowDataWithTags(cellInfo *[][]string, newRows map[string][]string, r
 			tableCellInfo{label: getTransitionDate(rule), labelKey: transitionDatesLabelKey, columnWidth: transitionDateColumnWidth, align: centerAlign})
 		checkAddTableCell(&((*cellInfo)[count]), rowCheck,
 			tableCellInfo{label: getStorageClassName(rule), labelKey: storageClassLabelKey, columnWidth: storageClassColumnWidth, align: centerAlign})
+		checkAddNoncurrentTableCells(&((*cellInfo)[count]), rowCheck, rule)
 		checkAddTableCellRows(&((*cellInfo)[count]), rowCheck, showOpts,
 			tableCellInfo{multLabels: getTagArr(rule), label: "", labelKey: tagLabel, columnWidth: tagsColumnWidth, align: leftAlign},
 			rule.ID, newRows)
@@ -444,10 +527,28 @@ func showTags(rule LifecycleRule, showOpts showDetails) bool {
 	return tagSet
 }
 
+// Noncurrent version, delete marker and multipart upload columns are only
+// shown if a rule of the configuration sets them.
+func showNoncurrentColumns(info LifecycleConfiguration, showOpts showDetails) (ncExpiry, ncTransition, deleteMarker, abortMPU bool) {
+	if showOpts.minimum {
+		return false, false, false, false
+	}
+	for _, rule := range info.Rules {
+		ncExpiry = ncExpiry || rule.NoncurrentVersionExpiration != nil
+		ncTransition = ncTransition || rule.NoncurrentVersionTransition != nil
+		deleteMarker = deleteMarker || (rule.Expiration != nil && rule.Expiration.ExpiredObjectDeleteMarker)
+		abortMPU = abortMPU || rule.AbortIncompleteMultipartUpload != nil
+	}
+	showExpiry := showOpts.allAvailable || showOpts.expiry
+	showTransition := showOpts.allAvailable || showOpts.transition
+	return ncExpiry && showExpiry, ncTransition && showTransition, deleteMarker && showExpiry, abortMPU && showOpts.allAvailable
+}
+
 func getColumns(info LifecycleConfiguration, rowCheck map[string]int, alignedHdrLabels *[]string, showOpts showDetails) {
 	tagIn := false // Keep tag in the end
 	colIdx := 0
 	colWidthTbl := getILMColumnWidthTable()
+	showNCExpiry, showNCTransition, showDeleteMarker, showAbortMPU := showNoncurrentColumns(info, showOpts)
 	incColIdx := func() int {
 		if tagIn {
 			colIdx = rowCheck[tagLabel]
@@ -500,6 +601,28 @@ func getColumns(info LifecycleConfiguration, rowCheck map[string]int, alignedHdr
 			rowCheck[storageClassLabelKey] = incColIdx()
 			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(storageClassLabel, centerAlign, colWidthTbl[storageClassLabelKey]))
 		}
+		_, ok = rowCheck[noncurrentExpiryLabelKey]
+		if !ok && showNCExpiry {
+			rowCheck[noncurrentExpiryLabelKey] = incColIdx()
+			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(noncurrentExpiryLabel, centerAlign, colWidthTbl[noncurrentExpiryLabelKey]))
+		}
+		_, ok = rowCheck[noncurrentTransitionLabelKey]
+		if !ok && showNCTransition {
+			rowCheck[noncurrentTransitionLabelKey] = incColIdx()
+			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(noncurrentTransitionLabel, centerAlign, colWidthTbl[noncurrentTransitionLabelKey]))
+			rowCheck[noncurrentStorageClassLabelKey] = incColIdx()
+			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(noncurrentStorageClassLabel, centerAlign, colWidthTbl[noncurrentStorageClassLabelKey]))
+		}
+		_, ok = rowCheck[deleteMarkerLabelKey]
+		if !ok && showDeleteMarker {
+			rowCheck[deleteMarkerLabelKey] = incColIdx()
+			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(deleteMarkerLabel, centerAlign, colWidthTbl[deleteMarkerLabelKey]))
+		}
+		_, ok = rowCheck[abortMPULabelKey]
+		if !ok && showAbortMPU {
+			rowCheck[abortMPULabelKey] = incColIdx()
+			(*alignedHdrLabels) = append((*alignedHdrLabels), getAlignedText(abortMPULabel, centerAlign, colWidthTbl[abortMPULabelKey]))
+		}
 		_, ok = rowCheck[tagLabel]
 		if !ok && showTags(rule, showOpts) {
 			rowCheck[tagLabel] = incColIdx()
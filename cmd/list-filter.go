@@ -0,0 +1,331 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Filter flags shared by all commands listing objects recursively.
+var filterFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "include",
+		Usage: "include only object(s) matching this wildcard pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "exclude object(s) matching this wildcard pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "include-regex",
+		Usage: "include only object(s) matching this regular expression",
+	},
+	cli.StringFlag{
+		Name:  "exclude-from",
+		Usage: "exclude object(s) matching the gitignore-style rules of a file, such as '.mcignore'",
+	},
+}
+
+// ignoreRule is a rule of a gitignore-style file.
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// listFilter selects the objects returned by listings. Paths are
+// relative to the listed URL and use '/' as separator.
+type listFilter struct {
+	include      []string
+	exclude      []string
+	includeRegex []*regexp.Regexp
+	ignoreRules  []ignoreRule
+	// Path of the listed URL relative to the filtered URL.
+	prefix string
+}
+
+// newListFilter returns the filter built from the patterns, nil if
+// there is nothing to filter.
+func newListFilter(include, exclude, includeRegex []string, excludeFrom string) (*listFilter, *probe.Error) {
+	f := &listFilter{
+		include: include,
+		exclude: exclude,
+	}
+	for _, expr := range includeRegex {
+		re, e := regexp.Compile(expr)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(expr)
+		}
+		f.includeRegex = append(f.includeRegex, re)
+	}
+	if excludeFrom != "" {
+		file, e := os.Open(excludeFrom)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(excludeFrom)
+		}
+		defer file.Close()
+		rules, err := parseIgnoreRules(file)
+		if err != nil {
+			return nil, err.Trace(excludeFrom)
+		}
+		f.ignoreRules = rules
+	}
+	if len(f.include) == 0 && len(f.exclude) == 0 && len(f.includeRegex) == 0 && len(f.ignoreRules) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// getListFilter returns the filter requested by the filter flags, nil
+// if none was set.
+func getListFilter(ctx *cli.Context) (*listFilter, *probe.Error) {
+	return newListFilter(ctx.StringSlice("include"), ctx.StringSlice("exclude"),
+		ctx.StringSlice("include-regex"), ctx.String("exclude-from"))
+}
+
+// parseIgnoreRules parses gitignore-style rules: one pattern per line,
+// '#' starts a comment, '!' negates a pattern, a trailing '/' matches
+// only folders and a pattern containing '/' is relative to the root.
+func parseIgnoreRules(r io.Reader) ([]ignoreRule, *probe.Error) {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			// Escaped leading '#' or '!'.
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		re, e := regexp.Compile(ignorePatternToRegexp(line))
+		if e != nil {
+			return nil, probe.NewError(e).Trace(line)
+		}
+		rule.pattern = re
+		rules = append(rules, rule)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return rules, nil
+}
+
+// ignorePatternToRegexp converts a gitignore-style pattern to a regular
+// expression matching a whole relative path.
+func ignorePatternToRegexp(pattern string) string {
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				re.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				re.WriteString(".*")
+				i++
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		case '[':
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				class := pattern[i+1 : i+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				re.WriteString("[" + class + "]")
+				i += end
+			} else {
+				re.WriteString(`\[`)
+			}
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return re.String()
+}
+
+// isIgnored returns true if the ignore rules exclude relPath, the last
+// matching rule wins.
+func (f *listFilter) isIgnored(relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range f.ignoreRules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.pattern.MatchString(relPath) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// match returns true if the object or folder at relPath is kept.
+func (f *listFilter) match(relPath string, isDir bool) bool {
+	if f == nil {
+		return true
+	}
+	relPath = strings.Trim(relPath, "/")
+	if relPath == "" {
+		return true
+	}
+
+	// Objects in an ignored folder are ignored, as with git.
+	elems := strings.Split(relPath, "/")
+	for i := 1; i < len(elems); i++ {
+		if f.isIgnored(strings.Join(elems[:i], "/"), true) {
+			return false
+		}
+	}
+	if f.isIgnored(relPath, isDir) {
+		return false
+	}
+
+	if matchExcludeOptions(f.exclude, relPath) {
+		return false
+	}
+
+	// Include patterns only select objects, folders are kept so
+	// that their content can be matched.
+	if isDir || (len(f.include) == 0 && len(f.includeRegex) == 0) {
+		return true
+	}
+	if matchExcludeOptions(f.include, relPath) {
+		return true
+	}
+	for _, re := range f.includeRegex {
+		if re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// withPrefix returns the filter matching the paths of listings after
+// prefix, used when listing a sub-folder of the filtered URL.
+func (f *listFilter) withPrefix(prefix string) *listFilter {
+	if f == nil {
+		return nil
+	}
+	scoped := *f
+	scoped.prefix = strings.Trim(f.prefix+"/"+strings.Trim(prefix, "/"), "/")
+	return &scoped
+}
+
+// listRelativePath returns contentPath relative to the listed basePath,
+// with '/' as separator.
+func listRelativePath(basePath, contentPath string, separator rune) string {
+	sep := string(separator)
+	if !strings.HasSuffix(basePath, sep) {
+		if strings.HasPrefix(contentPath, basePath+sep) {
+			basePath += sep
+		} else {
+			// Listing of a prefix, paths are relative to its folder.
+			basePath = basePath[:strings.LastIndex(basePath, sep)+1]
+		}
+	}
+	relPath := strings.TrimPrefix(contentPath, basePath)
+	if sep != "/" {
+		relPath = strings.Replace(relPath, sep, "/", -1)
+	}
+	return relPath
+}
+
+// matchListFilter returns true if content listed from baseURL passes
+// filter, a nil filter keeps everything.
+func matchListFilter(filter *listFilter, baseURL ClientURL, content *ClientContent) bool {
+	if filter == nil || content.Err != nil {
+		return true
+	}
+	relPath := listRelativePath(baseURL.Path, content.URL.Path, baseURL.Separator)
+	if filter.prefix != "" {
+		relPath = filter.prefix + "/" + relPath
+	}
+	return filter.match(relPath, content.Type.IsDir())
+}
+
+// filterListing applies filter, if any, to a listing of baseURL. When
+// ctx is canceled the filtered listing ends and the rest of contentCh
+// is drained so that the lister does not block forever.
+func filterListing(ctx context.Context, filter *listFilter, baseURL ClientURL, contentCh <-chan *ClientContent) <-chan *ClientContent {
+	if filter == nil {
+		return contentCh
+	}
+	filteredCh := make(chan *ClientContent)
+	go func() {
+		defer func() {
+			for range contentCh {
+			}
+		}()
+		defer close(filteredCh)
+		for {
+			var content *ClientContent
+			var ok bool
+			select {
+			case content, ok = <-contentCh:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			if !matchListFilter(filter, baseURL, content) {
+				continue
+			}
+			select {
+			case filteredCh <- content:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return filteredCh
+}
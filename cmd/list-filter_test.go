@@ -0,0 +1,194 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIgnoreRules(t *testing.T) {
+	rules, err := parseIgnoreRules(strings.NewReader(`
+# build artifacts
+*.o
+/vendor
+logs/
+!important.o
+\#notes
+docs/**/*.tmp
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	filter := &listFilter{ignoreRules: rules}
+
+	testCases := []struct {
+		relPath string
+		isDir   bool
+		ignored bool
+	}{
+		{"main.o", false, true},
+		{"src/main.o", false, true},
+		{"important.o", false, false},
+		{"vendor", true, true},
+		{"src/vendor", true, false},
+		{"logs", true, true},
+		{"logs", false, false},
+		{"#notes", false, true},
+		{"docs/a.tmp", false, true},
+		{"docs/a/b/c.tmp", false, true},
+		{"src/a.tmp", false, false},
+	}
+	for i, testCase := range testCases {
+		if ignored := filter.isIgnored(testCase.relPath, testCase.isDir); ignored != testCase.ignored {
+			t.Fatalf("Test %d: expected %t for `%s`, got %t", i+1, testCase.ignored, testCase.relPath, ignored)
+		}
+	}
+}
+
+func TestListFilterMatch(t *testing.T) {
+	rules, err := parseIgnoreRules(strings.NewReader("tmp/\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		filter  *listFilter
+		relPath string
+		isDir   bool
+		match   bool
+	}{
+		{nil, "a.txt", false, true},
+		{&listFilter{include: []string{"*.txt"}}, "a.txt", false, true},
+		{&listFilter{include: []string{"*.txt"}}, "a.jpg", false, false},
+		{&listFilter{include: []string{"*.txt"}}, "photos", true, true},
+		{&listFilter{exclude: []string{"*.jpg"}}, "photos/a.jpg", false, false},
+		{&listFilter{exclude: []string{"*.jpg"}}, "photos/a.png", false, true},
+		{&listFilter{include: []string{"*.jpg"}, exclude: []string{"raw/*"}}, "raw/a.jpg", false, false},
+		{&listFilter{ignoreRules: rules}, "tmp/a/b.txt", false, false},
+		{&listFilter{ignoreRules: rules}, "tmp", true, false},
+		{&listFilter{ignoreRules: rules}, "src/a.txt", false, true},
+	}
+	for i, testCase := range testCases {
+		if match := testCase.filter.match(testCase.relPath, testCase.isDir); match != testCase.match {
+			t.Fatalf("Test %d: expected %t for `%s`, got %t", i+1, testCase.match, testCase.relPath, match)
+		}
+	}
+}
+
+func TestNewListFilter(t *testing.T) {
+	filter, err := newListFilter(nil, nil, nil, "")
+	if err != nil || filter != nil {
+		t.Fatalf("Test 1: expected no filter, got %v, %v", filter, err)
+	}
+	if _, err = newListFilter(nil, nil, []string{"("}, ""); err == nil {
+		t.Fatalf("Test 2: expected an error for an invalid regular expression")
+	}
+	filter, err = newListFilter(nil, nil, []string{`^2020/.*\.csv$`}, "")
+	if err != nil {
+		t.Fatalf("Test 3: unexpected error: %s", err)
+	}
+	if !filter.match("2020/a.csv", false) || filter.match("2019/a.csv", false) {
+		t.Fatalf("Test 3: unexpected regular expression match")
+	}
+}
+
+func TestListRelativePath(t *testing.T) {
+	testCases := []struct {
+		basePath    string
+		contentPath string
+		separator   rune
+		relPath     string
+	}{
+		{"/bucket/dir/", "/bucket/dir/a/b.txt", '/', "a/b.txt"},
+		{"/bucket/dir", "/bucket/dir/a/b.txt", '/', "a/b.txt"},
+		{"/bucket/di", "/bucket/dir/a/b.txt", '/', "dir/a/b.txt"},
+		{`C:\data\`, `C:\data\a\b.txt`, '\\', "a/b.txt"},
+	}
+	for i, testCase := range testCases {
+		if relPath := listRelativePath(testCase.basePath, testCase.contentPath, testCase.separator); relPath != testCase.relPath {
+			t.Fatalf("Test %d: expected `%s`, got `%s`", i+1, testCase.relPath, relPath)
+		}
+	}
+}
+
+func TestFilterListing(t *testing.T) {
+	baseURL := *newClientURL("/bucket/dir/")
+	newContent := func(path string) *ClientContent {
+		return &ClientContent{URL: *newClientURL(path), Type: 0644}
+	}
+
+	filter := &listFilter{exclude: []string{"sub/*.jpg"}}
+	contentCh := make(chan *ClientContent, 3)
+	contentCh <- newContent("/bucket/dir/a.jpg")
+	contentCh <- newContent("/bucket/dir/sub/b.jpg")
+	contentCh <- newContent("/bucket/dir/sub/c.txt")
+	close(contentCh)
+
+	var listed []string
+	for content := range filterListing(context.Background(), filter, baseURL, contentCh) {
+		listed = append(listed, content.URL.Path)
+	}
+	if strings.Join(listed, ",") != "/bucket/dir/a.jpg,/bucket/dir/sub/c.txt" {
+		t.Fatalf("Unexpected filtered listing %v", listed)
+	}
+
+	// Listings of a sub-folder are matched relative to the filtered URL.
+	subDirURL := *newClientURL("/bucket/dir/sub/")
+	if matchListFilter(filter.withPrefix("sub"), subDirURL, newContent("/bucket/dir/sub/b.jpg")) {
+		t.Fatalf("Expected `sub/b.jpg` to be excluded")
+	}
+	if !matchListFilter(filter, subDirURL, newContent("/bucket/dir/sub/b.jpg")) {
+		t.Fatalf("Expected `b.jpg` to be kept")
+	}
+
+	// The filtered listing stops when its context is canceled and the
+	// lister is not left blocked.
+	ctx, cancel := context.WithCancel(context.Background())
+	listerCh := make(chan *ClientContent)
+	listerDone := make(chan struct{})
+	go func() {
+		defer close(listerDone)
+		defer close(listerCh)
+		for i := 0; i < 100; i++ {
+			listerCh <- newContent(fmt.Sprintf("/bucket/dir/%d.txt", i))
+		}
+	}()
+	filteredCh := filterListing(ctx, filter, baseURL, listerCh)
+	<-filteredCh
+	cancel()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-filteredCh:
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatalf("Filtered listing did not stop after cancel")
+		}
+		break
+	}
+	select {
+	case <-listerDone:
+	case <-timeout:
+		t.Fatalf("Lister blocked after the filtered listing was canceled")
+	}
+}
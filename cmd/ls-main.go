@@ -36,6 +36,18 @@ var (
 			Name:  "incomplete, I",
 			Usage: "list incomplete uploads",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "list all versions and delete markers",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "list objects as they were at a given time or duration ago",
+		},
+		cli.BoolFlag{
+			Name:  "replication",
+			Usage: "list the replication status of each object",
+		},
 	}
 )
 
@@ -45,7 +57,7 @@ var lsCmd = cli.Command{
 	Usage:  "list buckets and objects",
 	Action: mainList,
 	Before: setGlobalsFromContext,
-	Flags:  append(lsFlags, globalFlags...),
+	Flags:  append(append(lsFlags, ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -55,6 +67,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ENVIRONMENT VARIABLES:
+  OSS_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
 EXAMPLES:
   1. List buckets on Amazon S3 cloud storage.
      {{.Prompt}} {{.HelpName}} s3
@@ -73,6 +88,24 @@ EXAMPLES:
 
   6. List incomplete (previously failed) uploads of objects on Amazon S3.
      {{.Prompt}} {{.HelpName}} --incomplete s3/mybucket
+
+  7. List all versions and delete markers of objects in mybucket.
+     {{.Prompt}} {{.HelpName}} --versions s3/mybucket
+
+  8. List the contents of mybucket as they were one week ago.
+     {{.Prompt}} {{.HelpName}} --rewind 7d s3/mybucket
+
+  9. List the contents of mybucket as they were at a given point in time.
+     {{.Prompt}} {{.HelpName}} --rewind "2020-06-01T12:00:00Z" s3/mybucket
+
+ 10. List the contents of mybucket with the replication status of each object.
+     {{.Prompt}} {{.HelpName}} --replication s3/mybucket
+
+ 11. List the contents of a zip archive stored in mybucket.
+     {{.Prompt}} {{.HelpName}} --archive --recursive s3/mybucket/batch.zip#
+
+ 12. List the replication status of objects encrypted with a customer provided key.
+     {{.Prompt}} {{.HelpName}} --replication --encrypt-key "s3/personal-docs/=32byteslongsecretkeymustbegiven1" s3/personal-docs/
 `,
 }
 
@@ -91,6 +124,10 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) {
 	URLs := cliCtx.Args()
 	isIncomplete := cliCtx.Bool("incomplete")
 
+	if isIncomplete && (cliCtx.Bool("versions") || cliCtx.String("rewind") != "" || cliCtx.Bool("replication")) {
+		fatalIf(errInvalidArgument().Trace(args...), "Incomplete uploads cannot be listed with `--versions`, `--rewind` or `--replication`.")
+	}
+
 	for _, url := range URLs {
 		_, _, err := url2Stat(ctx, url, false, nil)
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
@@ -116,6 +153,10 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("VersionID", color.New(color.FgHiBlue))
+	console.SetColor("Latest", color.New(color.FgGreen, color.Bold))
+	console.SetColor("DeleteMarker", color.New(color.FgRed, color.Bold))
+	console.SetColor("Replication", color.New(color.FgMagenta))
 
 	// check 'ls' cliCtx arguments.
 	checkListSyntax(ctx, cliCtx)
@@ -123,6 +164,13 @@ func mainList(cliCtx *cli.Context) error {
 	// Set command flags from context.
 	isRecursive := cliCtx.Bool("recursive")
 	isIncomplete := cliCtx.Bool("incomplete")
+	withOlderVersions := cliCtx.Bool("versions")
+	withReplication := cliCtx.Bool("replication")
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
 
 	args := cliCtx.Args()
 	// mimic operating system tool behavior.
@@ -144,7 +192,8 @@ func mainList(cliCtx *cli.Context) error {
 			}
 		}
 
-		if e := doList(ctx, clnt, isRecursive, isIncomplete); e != nil {
+		alias, _, _ := mustExpandAlias(targetURL)
+		if e := doList(ctx, clnt, alias, isRecursive, isIncomplete, timeRef, withOlderVersions, withReplication, encKeyDB); e != nil {
 			cErr = e
 		}
 	}
@@ -44,12 +44,32 @@ type contentMessage struct {
 	Key      string    `json:"key"`
 	ETag     string    `json:"etag"`
 	URL      string    `json:"url,omitempty"`
+
+	VersionID      string `json:"versionId,omitempty"`
+	IsLatest       bool   `json:"isLatest,omitempty"`
+	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
 	message := console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(printDate)))
 	message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
+	if c.VersionID != "" {
+		message = message + console.Colorize("VersionID", c.VersionID+" ")
+		switch {
+		case c.IsDeleteMarker:
+			message = message + console.Colorize("DeleteMarker", "DEL ")
+		case c.IsLatest:
+			message = message + console.Colorize("Latest", "PUT ")
+		default:
+			message = message + "PUT "
+		}
+	}
+	if c.ReplicationStatus != "" {
+		message = message + console.Colorize("Replication", fmt.Sprintf("%-9s ", c.ReplicationStatus))
+	}
 	message = func() string {
 		if c.Filetype == "folder" {
 			return message + console.Colorize("Dir", c.Key)
@@ -87,6 +107,10 @@ func parseContent(c *ClientContent) contentMessage {
 	content.ETag = md5sum
 	// Convert OS Type to match console file printing style.
 	content.Key = getKey(c)
+	content.VersionID = c.VersionID
+	content.IsLatest = c.IsLatest
+	content.IsDeleteMarker = c.IsDeleteMarker
+	content.ReplicationStatus = c.ReplicationStatus
 	return content
 }
 
@@ -106,16 +130,47 @@ func getKey(c *ClientContent) string {
 	return c.URL.Path
 }
 
-// doList - list all entities inside a folder.
-func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete bool) error {
+// getReplicationStatus returns the replication status of a listed object.
+// Listings with metadata carry it, objects listed without any metadata
+// are stat'ed with the encryption keys of encKeyDB.
+func getReplicationStatus(ctx context.Context, alias string, content *ClientContent, encKeyDB map[string][]prefixSSEPair) (string, *probe.Error) {
+	if content.ReplicationStatus != "" || len(content.UserMetadata) > 0 {
+		return content.ReplicationStatus, nil
+	}
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return "", err.Trace(content.URL.String())
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(alias, content.URL.Path)), encKeyDB[alias])
+	st, err := clnt.StatVersion(ctx, content.VersionID, sse)
+	if err != nil {
+		return "", err.Trace(content.URL.String())
+	}
+	return st.ReplicationStatus, nil
+}
+
+// doList - list all entities inside a folder. With a non zero timeRef or
+// withOlderVersions object versions are listed instead of the objects.
+// With withReplication the replication status of each object, found
+// with the host config of alias and the keys of encKeyDB, is listed too.
+func doList(ctx context.Context, clnt Client, alias string, isRecursive, isIncomplete bool, timeRef time.Time, withOlderVersions, withReplication bool, encKeyDB map[string][]prefixSSEPair) error {
 	prefixPath := clnt.GetURL().Path
 	separator := string(clnt.GetURL().Separator)
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
 
+	var contentCh <-chan *ClientContent
+	if withOlderVersions || !timeRef.IsZero() {
+		contentCh = clnt.ListVersions(ctx, isRecursive, timeRef, withOlderVersions)
+	} else {
+		// The replication status is listed along with the metadata
+		// of objects where supported.
+		contentCh = clnt.List(ctx, isRecursive, isIncomplete, withReplication, DirNone)
+	}
+
 	var cErr error
-	for content := range clnt.List(ctx, isRecursive, isIncomplete, false, DirNone) {
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -141,6 +196,16 @@ func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete bool) er
 			continue
 		}
 
+		if withReplication && content.Type.IsRegular() && !content.IsDeleteMarker {
+			status, err := getReplicationStatus(ctx, alias, content, encKeyDB)
+			if err != nil {
+				errorIf(err, "Unable to get the replication status.")
+				cErr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+			content.ReplicationStatus = status
+		}
+
 		// Convert any os specific delimiters to "/".
 		contentURL := filepath.ToSlash(content.URL.Path)
 		prefixPath = filepath.ToSlash(prefixPath)
@@ -319,6 +319,8 @@ var appCmds = []cli.Command{
 	rbCmd,
 	cpCmd,
 	mirrorCmd,
+	syncCmd,
+	sessionCmd,
 	catCmd,
 	headCmd,
 	pipeCmd,
@@ -336,9 +338,13 @@ var appCmds = []cli.Command{
 	rmCmd,
 	eventCmd,
 	ilmCmd,
+	replicateCmd,
+	bucketCmd,
+	archiveCmd,
 	watchCmd,
 	policyCmd,
 	tagCmd,
+	versionCmd,
 	adminCmd,
 	configCmd,
 	updateCmd,
@@ -55,6 +55,22 @@ var (
 			Name:  "watch, w",
 			Usage: "watch and synchronize changes",
 		},
+		cli.StringFlag{
+			Name:  "poll-interval",
+			Usage: "with --watch, list the source at this interval to detect changes, for sources without notifications",
+		},
+		cli.StringFlag{
+			Name:  "normalize",
+			Usage: "match object names which only differ in their Unicode normalization, compared in 'nfc' or 'nfd' form",
+		},
+		cli.BoolFlag{
+			Name:  "escape-keys",
+			Usage: "escape object names which are not valid paths on a local target, unescape them from a local source",
+		},
+		cli.StringFlag{
+			Name:  "debounce",
+			Usage: "with --watch, merge the events of a local source over this window and mirror files once stable",
+		},
 		cli.BoolFlag{
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
@@ -72,6 +88,10 @@ var (
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
 		},
+		cli.StringFlag{
+			Name:  "verify",
+			Usage: "verify mirrored object(s) against a streaming checksum of the source (md5, sha256, crc32c)",
+		},
 		cli.BoolFlag{
 			Name:   "multi-master",
 			Usage:  "enable multi-master multi-site setup",
@@ -85,10 +105,6 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
-		cli.StringSliceFlag{
-			Name:  "exclude",
-			Usage: "exclude object(s) that match specified object name pattern",
-		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than L days, M hours and N minutes",
@@ -109,6 +125,14 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for all objects",
 		},
+		cli.BoolFlag{
+			Name:  "session",
+			Usage: "save progress in a session, resume it by running the same command again or with 'mc session resume'",
+		},
+		cli.BoolFlag{
+			Name:  "session-index",
+			Usage: "also keep an index of all mirrored object(s) in the session",
+		},
 	}
 )
 
@@ -118,7 +142,7 @@ var mirrorCmd = cli.Command{
 	Usage:  "synchronize object(s) to a remote site",
 	Action: mainMirror,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(append(append(append(mirrorFlags, filterFlags...), symlinkFlags...), limitFlags...), multipartFlags...), workerFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -132,6 +156,13 @@ ENVIRONMENT VARIABLES:
    OSS_ENCRYPT:      list of comma delimited prefixes
    OSS_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+OBJECT NAMES ON LOCAL TARGETS:
+  Object names resolving outside of a local target folder are rejected. With '--escape-keys', each
+  path segment of an object name is written as: '%' as '%25', '\' as '%5C', an empty segment, from a
+  leading or a double slash, as '%2F', '.' as '%2E' and '..' as '%2E%2E'. The escaping is reversed
+  when comparing the target with the source, and for the file names of a local source mirrored with
+  '--escape-keys' to object storage.
+
 EXAMPLES:
   01. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} play/photos/2014 s3/backup-photos
@@ -182,6 +213,37 @@ EXAMPLES:
   15. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --watch --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --watch --active-active siteB siteA
+
+  16. Mirror a local folder to MinIO cloud storage while limiting uploads to 50MiB/s.
+      {{.Prompt}} {{.HelpName}} --limit-upload 50MiB backup/ myminio/backup
+
+  17. Mirror a bucket to Amazon S3 cloud storage and verify each object with a CRC32C checksum.
+      {{.Prompt}} {{.HelpName}} --verify crc32c myminio/photos s3/photos
+
+  18. Mirror only JPEG images of a local folder, skipping paths listed in its '.mcignore' file.
+      {{.Prompt}} {{.HelpName}} --include "*.jpg" --exclude-from ~/photos/.mcignore ~/photos myminio/photos
+
+  19. Mirror a bucket to Amazon S3 cloud storage in a session, an interrupted mirror resumes where it stopped
+      when the same command is run again.
+      {{.Prompt}} {{.HelpName}} --session myminio/archive s3/archive
+
+  20. Mirror a local folder to a remote MinIO cloud storage with at most 32 object(s) in flight and
+      part sizes tuned from the measured throughput.
+      {{.Prompt}} {{.HelpName}} --max-workers 32 --part-size auto backup/ myminio/backup
+
+  21. Continuously mirror an Amazon S3 bucket, which does not notify about events, by listing it every 5 minutes.
+      {{.Prompt}} {{.HelpName}} --watch --poll-interval 5m s3/photos myminio/photos
+
+  22. Continuously mirror a local build folder, uploading files once they were left unchanged for 2 seconds.
+      {{.Prompt}} {{.HelpName}} --watch --debounce 2s ~/build myminio/builds
+
+  23. Mirror a bucket written from macOS to a local folder on Linux, without copying again objects
+      whose names only differ in their Unicode normalization, and escaping names like '../x'.
+      {{.Prompt}} {{.HelpName}} --normalize nfc --escape-keys s3/music ~/music
+
+  24. Mirror a local folder to MinIO cloud storage following its symlinks to folders, links looping back
+      to a parent folder are reported and not followed.
+      {{.Prompt}} {{.HelpName}} --follow-symlinks ~/data myminio/data
 `,
 }
 
@@ -359,7 +421,9 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 	})
 	sURLs.MD5 = mj.opts.md5
 	sURLs.DisableMultipart = mj.opts.disableMultipart
-	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.opts.encKeyDB, mj.opts.isMetadata)
+	sURLs.Verify = mj.opts.verify
+	return uploadSourceToTargetURL(withTransferOptions(ctx, mj.opts.transfer), sURLs,
+		mj.opts.limits.progressHook(sURLs, mj.status), mj.opts.encKeyDB, mj.opts.isMetadata)
 }
 
 // Update progress status
@@ -393,6 +457,11 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 		}
 
 		if sURLs.SourceContent != nil {
+			if mj.opts.journal != nil {
+				mirrored := sURLs.Error == nil || isErrIgnored(sURLs.Error)
+				errorIf(mj.opts.journal.finish(sURLs.SourceContent, mirrored),
+					"Unable to save mirror session progress.")
+			}
 		} else if sURLs.TargetContent != nil {
 			// Construct user facing message and path.
 			targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
@@ -433,15 +502,28 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 		// build target path, it is the relative of the eventPath with the sourceUrl
 		// joined to the targetURL.
 		sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
-		//Skip the object, if it matches the Exclude options provided
-		if matchExcludeOptions(mj.opts.excludeOptions, sourceSuffix) {
+		//Skip the object, if it is excluded by the filter options provided
+		if !mj.opts.filter.match(filepath.ToSlash(sourceSuffix), false) {
 			continue
 		}
 
-		targetPath := urlJoinPath(mj.targetURL, sourceSuffix)
+		key := filepath.ToSlash(sourceSuffix)
+		if !strings.HasSuffix(filepath.ToSlash(sourceURLFull), "/") {
+			// The suffix starts with the separator after the source.
+			key = strings.TrimPrefix(key, "/")
+		}
+		key = sourceKey(sourceAlias, key, mj.opts.escapeKeys)
+		targetAlias, _, _ := mustExpandAlias(mj.targetURL)
+		targetPath, err := joinTargetKey(targetAlias, mj.targetURL, key, mj.opts.escapeKeys)
+		if err != nil {
+			mj.queueCh <- func() URLs {
+				return URLs{Error: err.Trace(eventPath)}
+			}
+			continue
+		}
 
 		// newClient needs the unexpanded  path, newCLientURL needs the expanded path
-		targetAlias, expandedTargetPath, _ := mustExpandAlias(targetPath)
+		_, expandedTargetPath, _ := mustExpandAlias(targetPath)
 		targetURL := newClientURL(expandedTargetPath)
 		tgtSSE := getSSE(targetPath, mj.opts.encKeyDB[targetAlias])
 
@@ -518,8 +600,11 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, stopParallel func()) {
 			switch err.ToGoError().(type) {
 			case APINotImplemented:
 				errorIf(err.Trace(),
-					"Unable to Watch on source, perhaps source doesn't support Watching for events")
+					"Unable to Watch on source, perhaps source doesn't support Watching for events, try --poll-interval")
 				return
+			case WatchEventsDropped:
+				errorIf(err.Trace(), "Some changes of the source were not mirrored.")
+				continue
 			}
 			if err != nil {
 				mj.queueCh <- func() URLs {
@@ -534,7 +619,12 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, stopParallel func()) {
 }
 
 func (mj *mirrorJob) watchURL(ctx context.Context, sourceClient Client) *probe.Error {
-	return mj.watcher.Join(ctx, sourceClient, true)
+	return mj.watcher.Join(ctx, sourceClient, WatchOptions{
+		Recursive:    true,
+		Events:       []string{"put", "delete"},
+		PollInterval: mj.opts.pollInterval,
+		Debounce:     mj.opts.debounce,
+	})
 }
 
 // Fetch urls that need to be mirrored
@@ -566,6 +656,13 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 				}
 			}
 
+			if sURLs.SourceContent != nil && mj.opts.journal != nil {
+				// Skip object(s) mirrored by previous runs of the session.
+				if !mj.opts.journal.dispatch(sURLs.SourceContent) {
+					continue
+				}
+			}
+
 			if sURLs.SourceContent != nil {
 				mj.status.Add(sURLs.SourceContent.Size)
 			}
@@ -651,14 +748,19 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 		watcher:   NewWatcher(UTCNow()),
 	}
 
-	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh)
+	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh, opts.maxWorkers)
+	if opts.transfer.autoPartSize {
+		// The status reports the transferred bytes to the parallel
+		// manager, which samples the throughput.
+		mj.opts.transfer.throughput = mj.parallel
+	}
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
-	if globalQuiet {
-		mj.status = NewQuietStatus(mj.parallel)
+	if globalQuiet || opts.isQuiet {
+		mj.status = NewQuietStatus(mj.parallel, opts.limits)
 	} else if globalJSON {
-		mj.status = NewQuietStatus(mj.parallel)
+		mj.status = NewQuietStatus(mj.parallel, opts.limits)
 	} else {
 		mj.status = NewProgressStatus(mj.parallel)
 	}
@@ -717,8 +819,9 @@ func getEventPathURLWin(srcURL, eventPath string) string {
 	return eventPath
 }
 
-// runMirror - mirrors all buckets to another S3 server
-func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
+// runMirror - mirrors all buckets to another S3 server, progress is
+// saved in journal if not nil.
+func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dstURL string, cli flagValues, encKeyDB map[string][]prefixSSEPair, journal *mirrorJournal) bool {
 	// This is kept for backward compatibility, `--force` means
 	// --overwrite.
 	isOverwrite := cli.Bool("force")
@@ -734,43 +837,127 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		fatalIf(err, "Unable to parse attribute %v", cli.String("attr"))
 	}
 
-	srcClt, err := newClient(srcURL)
-	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
+	limits, err := parseBandwidthLimits(cli.String("limit-upload"), cli.String("limit-download"))
+	fatalIf(err, "Unable to parse bandwidth limits.")
 
-	dstClt, err := newClient(dstURL)
-	fatalIf(err, "Unable to initialize `"+dstURL+"`.")
+	verify, err := parseChecksumAlgorithm(cli.String("verify"))
+	fatalIf(err, "Unsupported checksum algorithm, valid values are md5, sha256 and crc32c.")
 
-	mirrorAllBuckets := (dstClt.GetURL().Type == objectStorage &&
-		dstClt.GetURL().Path == string(dstClt.GetURL().Separator)) &&
-		(srcClt.GetURL().Type == objectStorage &&
-			srcClt.GetURL().Path == string(srcClt.GetURL().Separator))
+	transfer, err := getTransferOptions(cli)
+	fatalIf(err, "Unable to parse multipart options.")
 
-	// Check if we are only trying to mirror one bucket from source.
-	if dstClt.GetURL().Type == objectStorage &&
-		dstClt.GetURL().Path == string(dstClt.GetURL().Separator) && !mirrorAllBuckets {
-		dstURL = urlJoinPath(dstURL, srcClt.GetURL().Path)
+	pollInterval, err := parseWatchDuration(cli.String("poll-interval"))
+	fatalIf(err, "Unable to parse the poll interval.")
 
-		dstClt, err = newClient(dstURL)
-		fatalIf(err, "Unable to initialize `"+dstURL+"`.")
+	debounce, err := parseWatchDuration(cli.String("debounce"))
+	fatalIf(err, "Unable to parse the debounce window.")
+	if debounce > 0 {
+		// Only notifications of a local source are debounced.
+		_, expandedSrcURL, _ := mustExpandAlias(srcURL)
+		if pollInterval > 0 {
+			errorIf(errInvalidArgument().Trace(srcURL), "--debounce is ignored, changes are detected by listing the source every --poll-interval.")
+		} else if newClientURL(expandedSrcURL).Type != fileSystem {
+			errorIf(errInvalidArgument().Trace(srcURL), "--debounce is ignored, `"+srcURL+"` is not a local folder.")
+		}
 	}
 
-	// Create a new mirror job and execute it
-	mj := newMirrorJob(srcURL, dstURL, mirrorOptions{
+	normalize, err := parseKeyNormalization(cli.String("normalize"))
+	fatalIf(err, "Unable to parse the normalization form, valid values are nfc and nfd.")
+
+	maxWorkers, err := getMaxWorkers(cli)
+	fatalIf(err, "Unable to parse the maximum number of workers.")
+
+	filter, err := newListFilter(cli.StringSlice("include"), cli.StringSlice("exclude"),
+		cli.StringSlice("include-regex"), cli.String("exclude-from"))
+	fatalIf(err, "Unable to parse filter options.")
+
+	symlinks, err := getSymlinkPolicy(cli)
+	fatalIf(err, "Unable to parse the symlink options, only one of --follow-symlinks, --skip-symlinks and --preserve-symlinks can be set.")
+
+	mj, errorDetected, failure := mirrorWithOptions(withSymlinkPolicy(ctx, symlinks, dstURL), cancelMirror, srcURL, dstURL, cli.String("region"), mirrorOptions{
 		isFake:           cli.Bool("fake"),
 		isRemove:         cli.Bool("remove"),
 		isOverwrite:      isOverwrite,
 		isWatch:          cli.Bool("watch") || cli.Bool("multi-master") || cli.Bool("active-active"),
-		isMetadata:       cli.Bool("a") || cli.Bool("multi-master") || cli.Bool("active-active") || len(userMetadata) > 0,
+		isMetadata:       cli.Bool("preserve") || cli.Bool("multi-master") || cli.Bool("active-active") || len(userMetadata) > 0,
 		md5:              cli.Bool("md5"),
 		disableMultipart: cli.Bool("disable-multipart"),
-		excludeOptions:   cli.StringSlice("exclude"),
+		filter:           filter,
 		olderThan:        cli.String("older-than"),
 		newerThan:        cli.String("newer-than"),
 		storageClass:     cli.String("storage-class"),
 		userMetadata:     userMetadata,
 		encKeyDB:         encKeyDB,
 		activeActive:     cli.Bool("multi-master") || cli.Bool("active-active"),
+		limits:           limits,
+		verify:           verify,
+		transfer:         transfer,
+		maxWorkers:       maxWorkers,
+		pollInterval:     pollInterval,
+		debounce:         debounce,
+		normalize:        normalize,
+		escapeKeys:       cli.Bool("escape-keys"),
+		journal:          journal,
 	})
+	if failure != nil {
+		if mj != nil {
+			mj.status.fatalIf(failure.err, failure.msg)
+		}
+		fatalIf(failure.err, failure.msg)
+	}
+	return errorDetected
+}
+
+// mirrorFailure is an error which prevented a mirror from starting.
+type mirrorFailure struct {
+	err *probe.Error
+	msg string
+}
+
+func (f *mirrorFailure) String() string {
+	return f.msg + " " + f.err.ToGoError().Error()
+}
+
+// mirrorWithOptions - mirrors srcURL to dstURL, returns the mirror job,
+// true if an error was detected and the failure which prevented the
+// mirror from starting, if any. The mirror job is nil when the URLs
+// could not be initialized.
+func mirrorWithOptions(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dstURL, region string, opts mirrorOptions) (*mirrorJob, bool, *mirrorFailure) {
+	srcClt, err := newClient(srcURL)
+	if err != nil {
+		return nil, true, &mirrorFailure{err, "Unable to initialize `" + srcURL + "`."}
+	}
+
+	dstClt, err := newClient(dstURL)
+	if err != nil {
+		return nil, true, &mirrorFailure{err, "Unable to initialize `" + dstURL + "`."}
+	}
+
+	mirrorAllBuckets := (dstClt.GetURL().Type == objectStorage &&
+		dstClt.GetURL().Path == string(dstClt.GetURL().Separator)) &&
+		(srcClt.GetURL().Type == objectStorage &&
+			srcClt.GetURL().Path == string(srcClt.GetURL().Separator))
+
+	// Check if we are only trying to mirror one bucket from source.
+	if dstClt.GetURL().Type == objectStorage &&
+		dstClt.GetURL().Path == string(dstClt.GetURL().Separator) && !mirrorAllBuckets {
+		dstURL = urlJoinPath(dstURL, srcClt.GetURL().Path)
+
+		dstClt, err = newClient(dstURL)
+		if err != nil {
+			return nil, true, &mirrorFailure{err, "Unable to initialize `" + dstURL + "`."}
+		}
+	}
+
+	if opts.journal != nil {
+		opts.journal.setSource(srcClt.GetURL())
+		// The listings are sorted as compared by objectDifference.
+		opts.journal.setKeyOrder(opts.normalize, opts.normalize != normalizeDefault ||
+			(opts.escapeKeys && (srcClt.GetURL().Type == fileSystem || dstClt.GetURL().Type == fileSystem)))
+	}
+
+	// Create a new mirror job and execute it
+	mj := newMirrorJob(srcURL, dstURL, opts)
 
 	if mirrorAllBuckets {
 		// Synchronize buckets using dirDifference function
@@ -778,9 +965,9 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 			if d.Error != nil {
 				if mj.opts.activeActive {
 					errorIf(d.Error, "Failed to start mirroring.. retrying")
-					return true
+					return mj, true, nil
 				}
-				mj.status.fatalIf(d.Error, "Failed to start mirroring.")
+				return mj, true, &mirrorFailure{d.Error, "Failed to start mirroring."}
 			}
 			if d.Diff == differInSecond {
 				// Ignore buckets that only exist in target instance
@@ -801,7 +988,7 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 					withLock = true
 				}
 				// Bucket only exists in the source, create the same bucket in the destination
-				if err := newDstClt.MakeBucket(ctx, cli.String("region"), false, withLock); err != nil {
+				if err := newDstClt.MakeBucket(ctx, region, false, withLock); err != nil {
 					errorIf(err, "Unable to create bucket at `"+newTgtURL+"`.")
 					continue
 				}
@@ -810,7 +997,7 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 					errorIf(newDstClt.SetObjectLockConfig(ctx, mode, validity, unit),
 						"Unable to set object lock config in `"+newTgtURL+"`.")
 				}
-				errorIf(copyBucketPolicies(ctx, newSrcClt, newDstClt, isOverwrite),
+				errorIf(copyBucketPolicies(ctx, newSrcClt, newDstClt, mj.opts.isOverwrite),
 					"Unable to copy bucket policies to `"+newDstClt.GetURL().String()+"`.")
 			}
 
@@ -820,9 +1007,9 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 				if err := mj.watchURL(ctx, newSrcClt); err != nil {
 					if mj.opts.activeActive {
 						errorIf(err, "Failed to start monitoring.. retrying")
-						return true
+						return mj, true, nil
 					}
-					mj.status.fatalIf(err, "Failed to start monitoring.")
+					return mj, true, &mirrorFailure{err, "Failed to start monitoring."}
 				}
 			}
 		}
@@ -836,14 +1023,13 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		// Create bucket if it doesn't exist at destination.
 		// ignore if already exists.
 		if mj.opts.activeActive {
-			err = dstClt.MakeBucket(ctx, cli.String("region"), true, withLock)
+			err = dstClt.MakeBucket(ctx, region, true, withLock)
 			errorIf(err, "Unable to create bucket at `"+dstURL+"`.")
 			if err != nil {
-				return true
+				return mj, true, nil
 			}
-		} else {
-			mj.status.fatalIf(dstClt.MakeBucket(ctx, cli.String("region"), true, withLock),
-				"Unable to create bucket at `"+dstURL+"`.")
+		} else if err = dstClt.MakeBucket(ctx, region, true, withLock); err != nil {
+			return mj, true, &mirrorFailure{err, "Unable to create bucket at `" + dstURL + "`."}
 		}
 
 		// object lock configuration set on bucket
@@ -851,14 +1037,14 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 			err = dstClt.SetObjectLockConfig(ctx, mode, validity, unit)
 			errorIf(err, "Unable to set object lock config in `"+dstURL+"`.")
 			if err != nil && mj.opts.activeActive {
-				return true
+				return mj, true, nil
 			}
 		}
 
-		err = copyBucketPolicies(ctx, srcClt, dstClt, isOverwrite)
+		err = copyBucketPolicies(ctx, srcClt, dstClt, mj.opts.isOverwrite)
 		errorIf(err, "Unable to copy bucket policies to `"+dstClt.GetURL().String()+"`.")
 		if err != nil && mj.opts.activeActive {
-			return true
+			return mj, true, nil
 		}
 	}
 
@@ -868,12 +1054,12 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		if err := mj.watchURL(ctx, srcClt); err != nil {
 			if mj.opts.activeActive {
 				errorIf(err, "Failed to start monitoring.. retrying")
-				return true
+				return mj, true, nil
 			}
-			mj.status.fatalIf(err, "Failed to start monitoring.")
+			return mj, true, &mirrorFailure{err, "Failed to start monitoring."}
 		}
 	}
-	return mj.mirror(ctx, cancelMirror)
+	return mj, mj.mirror(ctx, cancelMirror), nil
 }
 
 // Main entry point for mirror command.
@@ -905,12 +1091,27 @@ func mainMirror(cliCtx *cli.Context) error {
 		}
 	}
 
+	if cliCtx.Bool("session") {
+		var session *sessionV8
+		sessionID := getHash("mirror", cliCtx.Args())
+		if isSessionExists(sessionID) {
+			session, err = loadSessionV8(sessionID)
+			fatalIf(err.Trace(sessionID), "Unable to load session.")
+		} else {
+			session = newMirrorSession(cliCtx, sessionID, srcURL, tgtURL)
+		}
+		if errorDetected := runMirrorSession(ctx, cancelMirror, session); errorDetected {
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return exitStatus(globalErrorExitStatus)
 		default:
-			if errorDetected := runMirror(ctx, cancelMirror, srcURL, tgtURL, cliCtx, encKeyDB); errorDetected {
+			if errorDetected := runMirror(ctx, cancelMirror, srcURL, tgtURL, cliCtx, encKeyDB, nil); errorDetected {
 				if cliCtx.Bool("multi-master") || cliCtx.Bool("active-active") {
 					time.Sleep(2 * time.Second)
 					continue
@@ -0,0 +1,454 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Mirror flags saved in a mirror session, restored on resume.
+var (
+	mirrorSessionBoolFlags = []string{
+		"fake", "remove", "overwrite", "preserve", "md5", "disable-multipart", "session-index",
+		"follow-symlinks", "skip-symlinks", "preserve-symlinks", "escape-keys",
+	}
+	mirrorSessionStringFlags = []string{
+		"region", "older-than", "newer-than", "storage-class", "attr", "verify",
+		"limit-upload", "limit-download", "exclude-from", "part-size", "normalize",
+	}
+	mirrorSessionIntFlags = []string{
+		"parallel-parts", "max-workers",
+	}
+	// Multiple values of these flags are saved separated by newlines.
+	mirrorSessionSliceFlags = []string{
+		"include", "exclude", "include-regex",
+	}
+)
+
+// How often the progress of a mirror session is synced to disk.
+const mirrorSessionSyncInterval = time.Second
+
+// newMirrorSession creates a new mirror session saving the arguments
+// and the flags of the mirror command.
+func newMirrorSession(cliCtx *cli.Context, sessionID, srcURL, tgtURL string) *sessionV8 {
+	session := newSessionV8(sessionID)
+	session.Header.CommandType = "mirror"
+	session.Header.CommandArgs = []string{srcURL, tgtURL}
+	session.Header.CommandBoolFlags["session"] = true
+	for _, name := range mirrorSessionBoolFlags {
+		session.Header.CommandBoolFlags[name] = cliCtx.Bool(name)
+	}
+	// This is kept for backward compatibility, `--force` means
+	// --overwrite.
+	if cliCtx.Bool("force") {
+		session.Header.CommandBoolFlags["overwrite"] = true
+	}
+	for _, name := range mirrorSessionIntFlags {
+		session.Header.CommandIntFlags[name] = cliCtx.Int(name)
+	}
+	for _, name := range mirrorSessionStringFlags {
+		session.Header.CommandStringFlags[name] = cliCtx.String(name)
+	}
+	for _, name := range mirrorSessionSliceFlags {
+		session.Header.CommandStringFlags[name] = strings.Join(cliCtx.StringSlice(name), "\n")
+	}
+
+	sseKeys := os.Getenv("OSS_ENCRYPT_KEY")
+	if key := cliCtx.String("encrypt-key"); key != "" {
+		sseKeys = key
+	}
+	if sseKeys != "" {
+		var err *probe.Error
+		sseKeys, err = getDecodedKey(sseKeys)
+		if err != nil {
+			session.Delete()
+			fatalIf(err, "Unable to parse encryption keys.")
+		}
+	}
+	sse := os.Getenv("OSS_ENCRYPT")
+	if prefix := cliCtx.String("encrypt"); prefix != "" {
+		sse = prefix
+	}
+	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
+	session.Header.CommandStringFlags["encrypt"] = sse
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+	return session
+}
+
+// runMirrorSession mirrors with the arguments and flags saved in the
+// session, skipping the objects already mirrored by previous runs. The
+// session is removed once everything was mirrored.
+func runMirrorSession(ctx context.Context, cancelMirror context.CancelFunc, session *sessionV8) bool {
+	if len(session.Header.CommandArgs) != 2 {
+		fatalIf(errInvalidArgument().Trace(session.SessionID), "Invalid arguments in mirror session.")
+	}
+
+	flags := sessionFlagValues{session.Header}
+	encKeyDB, err := parseAndValidateEncryptionKeys(flags.String("encrypt-key"), flags.String("encrypt"))
+	fatalIf(err.Trace(session.SessionID), "Unable to parse encryption keys.")
+
+	journal, err := newMirrorJournal(session)
+	fatalIf(err.Trace(session.SessionID), "Unable to load mirror session progress.")
+
+	errorDetected := runMirror(ctx, cancelMirror, session.Header.CommandArgs[0], session.Header.CommandArgs[1],
+		flags, encKeyDB, journal)
+	if err = journal.Close(); err != nil {
+		errorIf(err.Trace(session.SessionID), "Unable to save mirror session progress.")
+		errorDetected = true
+	}
+
+	// The mirror context is always cancelled once done, an interrupted
+	// mirror is told by the global context.
+	if errorDetected || globalContext.Err() != nil {
+		session.Close()
+		console.Infoln("Mirror session `" + session.SessionID + "` saved. Run `mc session resume " +
+			session.SessionID + "` to resume it.")
+		return true
+	}
+
+	errorIf(session.Delete().Trace(session.SessionID), "Unable to remove mirror session.")
+	return false
+}
+
+// mirrorJournalRecord is a line of the mirror session data file, it
+// records the new watermark of a prefix.
+type mirrorJournalRecord struct {
+	Prefix  string `json:"prefix"`
+	LastKey string `json:"lastKey"`
+}
+
+// mirrorJournalEntry is an object dispatched for mirroring.
+type mirrorJournalEntry struct {
+	key  string
+	size int64
+	done bool
+}
+
+// mirrorJournalPrefix tracks the objects of a prefix in listing order.
+type mirrorJournalPrefix struct {
+	// All the objects of the prefix up to lastKey are mirrored.
+	lastKey string
+	// Objects dispatched after lastKey, in listing order.
+	pending []*mirrorJournalEntry
+}
+
+// mirrorJournal persists the progress of a mirror session. Objects are
+// grouped by the first element of their path relative to the source,
+// for each prefix the key up to which all objects were mirrored is
+// appended to the session data file whenever it moves forward. When the
+// session index is enabled, every mirrored key is also appended to the
+// index file, so that objects mirrored past a failed or interrupted one
+// are skipped as well on resume.
+type mirrorJournal struct {
+	mutex sync.Mutex
+
+	session  *sessionV8
+	base     ClientURL
+	prefixes map[string]*mirrorJournalPrefix
+
+	// Objects are listed in the order of their keys in the normalize
+	// form when sorted, in the order of their keys otherwise.
+	normalize keyNormalization
+	sorted    bool
+
+	inflight map[string]*mirrorJournalEntry
+
+	index   map[string]struct{}
+	indexFP *os.File
+
+	lastSave time.Time
+}
+
+// newMirrorJournal loads the progress saved in the session data file
+// and the session index, if enabled.
+func newMirrorJournal(session *sessionV8) (*mirrorJournal, *probe.Error) {
+	j := &mirrorJournal{
+		session:  session,
+		prefixes: make(map[string]*mirrorJournalPrefix),
+		inflight: make(map[string]*mirrorJournalEntry),
+		index:    make(map[string]struct{}),
+		lastSave: UTCNow(),
+	}
+	if err := j.load(session.NewDataReader()); err != nil {
+		return nil, err.Trace(session.SessionID)
+	}
+	if err := j.compact(); err != nil {
+		return nil, err.Trace(session.SessionID)
+	}
+
+	// Objects dispatched but not mirrored by the previous run are
+	// accounted again.
+	session.Header.TotalBytes = session.Header.DoneBytes
+	session.Header.TotalObjects = session.Header.DoneObjects
+
+	if !session.Header.CommandBoolFlags["session-index"] {
+		return j, nil
+	}
+	indexFile, err := getSessionIndexFile(session.SessionID)
+	if err != nil {
+		return nil, err.Trace(session.SessionID)
+	}
+	indexData, e := ioutil.ReadFile(indexFile)
+	if e != nil && !os.IsNotExist(e) {
+		return nil, probe.NewError(e).Trace(indexFile)
+	}
+	j.loadIndex(indexData)
+	if j.indexFP, e = os.OpenFile(indexFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600); e != nil {
+		return nil, probe.NewError(e).Trace(indexFile)
+	}
+	return j, nil
+}
+
+// load replays the watermarks recorded in r. A torn last record, left
+// by a crash, is ignored.
+func (j *mirrorJournal) load(r io.Reader) *probe.Error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var record mirrorJournalRecord
+		if e := json.Unmarshal(scanner.Bytes(), &record); e != nil {
+			break
+		}
+		j.prefixes[record.Prefix] = &mirrorJournalPrefix{lastKey: record.LastKey}
+	}
+	if e := scanner.Err(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// loadIndex loads the keys of the session index, one per line. A torn
+// last line, left by a crash, is ignored.
+func (j *mirrorJournal) loadIndex(data []byte) {
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	} else {
+		return
+	}
+	for _, key := range strings.Split(string(data), "\n") {
+		j.index[key] = struct{}{}
+	}
+}
+
+// compact rewrites the session data file with a single record per
+// prefix. The new file replaces the old one only once fully written.
+func (j *mirrorJournal) compact() *probe.Error {
+	dataFile := j.session.DataFP.Name()
+	tmpFile := dataFile + ".tmp"
+
+	fp, e := os.OpenFile(tmpFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if e != nil {
+		return probe.NewError(e).Trace(tmpFile)
+	}
+	prefixes := make([]string, 0, len(j.prefixes))
+	for prefix := range j.prefixes {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		if err := writeMirrorJournalRecord(fp, prefix, j.prefixes[prefix].lastKey); err != nil {
+			fp.Close()
+			return err.Trace(tmpFile)
+		}
+	}
+	if e = fp.Sync(); e != nil {
+		fp.Close()
+		return probe.NewError(e).Trace(tmpFile)
+	}
+	fp.Close()
+
+	if e = os.Rename(tmpFile, dataFile); e != nil {
+		return probe.NewError(e).Trace(tmpFile, dataFile)
+	}
+	j.session.DataFP.Close()
+	if fp, e = os.OpenFile(dataFile, os.O_RDWR|os.O_APPEND, 0600); e != nil {
+		return probe.NewError(e).Trace(dataFile)
+	}
+	j.session.DataFP = &sessionDataFP{false, fp}
+	return nil
+}
+
+func writeMirrorJournalRecord(w io.Writer, prefix, lastKey string) *probe.Error {
+	recordBytes, e := json.Marshal(mirrorJournalRecord{Prefix: prefix, LastKey: lastKey})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = w.Write(append(recordBytes, '\n')); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// setSource sets the source URL, keys are relative to it.
+func (j *mirrorJournal) setSource(base ClientURL) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.base = base
+}
+
+// setKeyOrder sets the order objects are listed in, sorted when the
+// listings are sorted by their normalized keys.
+func (j *mirrorJournal) setKeyOrder(normalize keyNormalization, sorted bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.normalize = normalize
+	j.sorted = sorted
+}
+
+// key returns the key of a source object.
+func (j *mirrorJournal) key(content *ClientContent) string {
+	return listRelativePath(j.base.Path, content.URL.Path, j.base.Separator)
+}
+
+// mirrorJournalKeyPrefix returns the prefix a key belongs to, its first
+// path element.
+func mirrorJournalKeyPrefix(key string) string {
+	if i := strings.Index(key, "/"); i >= 0 {
+		return key[:i+1]
+	}
+	return ""
+}
+
+// isMirrored returns true if the key was mirrored by a previous run.
+func (j *mirrorJournal) isMirrored(key string) bool {
+	if _, ok := j.index[key]; ok {
+		return true
+	}
+	p, ok := j.prefixes[mirrorJournalKeyPrefix(key)]
+	if !ok || p.lastKey == "" {
+		return false
+	}
+	if !j.sorted {
+		return key <= p.lastKey
+	}
+	return j.normalize.String(key) <= j.normalize.String(p.lastKey)
+}
+
+// dispatch records a source object queued for mirroring, it returns
+// false if the object was already mirrored and must be skipped.
+func (j *mirrorJournal) dispatch(content *ClientContent) bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	key := j.key(content)
+	if j.isMirrored(key) {
+		return false
+	}
+
+	prefix := mirrorJournalKeyPrefix(key)
+	p, ok := j.prefixes[prefix]
+	if !ok {
+		p = &mirrorJournalPrefix{}
+		j.prefixes[prefix] = p
+	}
+	entry := &mirrorJournalEntry{key: key, size: content.Size}
+	p.pending = append(p.pending, entry)
+	j.inflight[key] = entry
+
+	j.session.Header.TotalBytes += content.Size
+	j.session.Header.TotalObjects++
+	return true
+}
+
+// finish records the outcome of a dispatched object. The watermark of
+// its prefix moves forward over all the mirrored objects following it,
+// a failed object holds it back.
+func (j *mirrorJournal) finish(content *ClientContent, mirrored bool) *probe.Error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	key := j.key(content)
+	entry, ok := j.inflight[key]
+	if !ok {
+		return nil
+	}
+	delete(j.inflight, key)
+	if !mirrored {
+		return nil
+	}
+	entry.done = true
+	j.session.Header.DoneBytes += entry.size
+	j.session.Header.DoneObjects++
+
+	if j.indexFP != nil {
+		if _, e := j.indexFP.WriteString(key + "\n"); e != nil {
+			return probe.NewError(e).Trace(j.indexFP.Name())
+		}
+	}
+
+	prefix := mirrorJournalKeyPrefix(key)
+	p := j.prefixes[prefix]
+	lastKey := p.lastKey
+	for len(p.pending) > 0 && p.pending[0].done {
+		p.lastKey = p.pending[0].key
+		p.pending = p.pending[1:]
+	}
+	if p.lastKey != lastKey {
+		if err := writeMirrorJournalRecord(j.session.DataFP, prefix, p.lastKey); err != nil {
+			return err.Trace(j.session.DataFP.Name())
+		}
+	}
+
+	if time.Since(j.lastSave) < mirrorSessionSyncInterval {
+		return nil
+	}
+	return j.save()
+}
+
+// save syncs the progress to disk.
+func (j *mirrorJournal) save() *probe.Error {
+	j.lastSave = UTCNow()
+	if j.indexFP != nil {
+		if e := j.indexFP.Sync(); e != nil {
+			return probe.NewError(e).Trace(j.indexFP.Name())
+		}
+	}
+	return j.session.Save()
+}
+
+// Close syncs the progress to disk and closes the session index.
+func (j *mirrorJournal) Close() *probe.Error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	err := j.save()
+	if j.indexFP != nil {
+		j.indexFP.Close()
+		j.indexFP = nil
+	}
+	return err
+}
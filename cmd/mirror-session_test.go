@@ -0,0 +1,156 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestMirrorJournal(t *testing.T, dataFile *os.File) *mirrorJournal {
+	j := &mirrorJournal{
+		session: &sessionV8{
+			Header: &sessionV8Header{},
+			DataFP: &sessionDataFP{false, dataFile},
+		},
+		base:     *newClientURL("/source"),
+		prefixes: make(map[string]*mirrorJournalPrefix),
+		inflight: make(map[string]*mirrorJournalEntry),
+		index:    make(map[string]struct{}),
+		lastSave: UTCNow(),
+	}
+	if err := j.load(j.session.NewDataReader()); err != nil {
+		t.Fatalf("Unable to load journal: %s", err)
+	}
+	return j
+}
+
+func TestMirrorJournal(t *testing.T) {
+	dataFile, e := ioutil.TempFile("", "mirror-journal")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.Remove(dataFile.Name())
+	defer dataFile.Close()
+
+	j := newTestMirrorJournal(t, dataFile)
+	content := func(key string) *ClientContent {
+		return &ClientContent{URL: *newClientURL("/source/" + key), Size: 1}
+	}
+	for _, key := range []string{"docs/a", "docs/b", "docs/c", "docs/d", "readme"} {
+		if !j.dispatch(content(key)) {
+			t.Fatalf("Unexpected skip of %s", key)
+		}
+	}
+	// docs/c fails, the watermark of docs/ stays at docs/b.
+	outcomes := []struct {
+		key      string
+		mirrored bool
+	}{
+		{"docs/b", true},
+		{"docs/a", true},
+		{"docs/c", false},
+		{"docs/d", true},
+		{"readme", true},
+	}
+	for _, outcome := range outcomes {
+		if err := j.finish(content(outcome.key), outcome.mirrored); err != nil {
+			t.Fatalf("Unable to finish %s: %s", outcome.key, err)
+		}
+	}
+	if j.session.Header.DoneObjects != 4 || j.session.Header.TotalObjects != 5 {
+		t.Fatalf("Unexpected progress %d/%d", j.session.Header.DoneObjects, j.session.Header.TotalObjects)
+	}
+
+	// A torn record is ignored on load.
+	if _, e = dataFile.WriteString(`{"prefix":"docs/","lastK`); e != nil {
+		t.Fatal(e)
+	}
+	j = newTestMirrorJournal(t, dataFile)
+
+	testCases := []struct {
+		key      string
+		mirrored bool
+	}{
+		{"docs/a", true},
+		{"docs/b", true},
+		{"docs/c", false},
+		{"docs/d", false},
+		{"docs/e", false},
+		{"readme", true},
+		{"license", true},
+		{"zebra", false},
+	}
+	for i, testCase := range testCases {
+		if mirrored := j.isMirrored(testCase.key); mirrored != testCase.mirrored {
+			t.Fatalf("Test %d: expected %s mirrored to be %t, got %t", i+1, testCase.key, testCase.mirrored, mirrored)
+		}
+	}
+}
+
+func TestMirrorJournalIndex(t *testing.T) {
+	j := &mirrorJournal{
+		prefixes: make(map[string]*mirrorJournalPrefix),
+		index:    make(map[string]struct{}),
+	}
+	// The last key is torn and ignored.
+	j.loadIndex([]byte("docs/d\nphotos/1.jpg\nphotos/2"))
+
+	testCases := []struct {
+		key      string
+		mirrored bool
+	}{
+		{"docs/d", true},
+		{"photos/1.jpg", true},
+		{"photos/2", false},
+		{"photos/2.jpg", false},
+	}
+	for i, testCase := range testCases {
+		if mirrored := j.isMirrored(testCase.key); mirrored != testCase.mirrored {
+			t.Fatalf("Test %d: expected %s mirrored to be %t, got %t", i+1, testCase.key, testCase.mirrored, mirrored)
+		}
+	}
+}
+
+func TestMirrorJournalKeyOrder(t *testing.T) {
+	j := &mirrorJournal{
+		prefixes: map[string]*mirrorJournalPrefix{"docs/": {lastKey: "docs/f"}},
+		index:    make(map[string]struct{}),
+	}
+
+	// "\u00e9" is e with an acute accent in NFC, "e\u0301" in NFD.
+	testCases := []struct {
+		normalize keyNormalization
+		sorted    bool
+		key       string
+		mirrored  bool
+	}{
+		{normalizeDefault, false, "docs/\u00e9", false},
+		{normalizeDefault, false, "docs/e\u0301", true},
+		{normalizeDefault, true, "docs/e\u0301", false},
+		{normalizeNFC, true, "docs/e\u0301", false},
+		{normalizeNFD, true, "docs/\u00e9", true},
+		{normalizeNFD, true, "docs/g", false},
+	}
+	for i, testCase := range testCases {
+		j.setKeyOrder(testCase.normalize, testCase.sorted)
+		if mirrored := j.isMirrored(testCase.key); mirrored != testCase.mirrored {
+			t.Fatalf("Test %d: expected %q mirrored to be %t, got %t", i+1, testCase.key, testCase.mirrored, mirrored)
+		}
+	}
+}
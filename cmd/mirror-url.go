@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/minio/pkg/wildcard"
@@ -69,6 +70,15 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		}
 	}
 
+	if cliCtx.Bool("session") || cliCtx.Bool("session-index") {
+		if cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--session` cannot be used with `--watch` or `--active-active`.")
+		}
+		if !cliCtx.Bool("session") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--session-index` requires `--session`.")
+		}
+	}
+
 	/****** Generic rules *******/
 	if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
 		_, srcContent, err := url2Stat(ctx, srcURL, false, encKeyDB)
@@ -87,6 +97,7 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 
 }
 
+// matchExcludeOptions returns true if srcSuffix matches one of the wildcard patterns.
 func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	for _, pattern := range excludeOptions {
 		if wildcard.Match(pattern, srcSuffix) {
@@ -126,25 +137,14 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 	}
 
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, opts.isMetadata) {
+	keys := diffKeyOptions{normalize: opts.normalize, escaped: opts.escapeKeys}
+	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, sourceURL, targetURL, opts.isMetadata, opts.filter, keys) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error}
 			continue
 		}
 
-		srcSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-		//Skip the source object if it matches the Exclude options provided
-		if matchExcludeOptions(opts.excludeOptions, srcSuffix) {
-			continue
-		}
-
-		tgtSuffix := strings.TrimPrefix(diffMsg.SecondURL, targetURL)
-		//Skip the target object if it matches the Exclude options provided
-		if matchExcludeOptions(opts.excludeOptions, tgtSuffix) {
-			continue
-		}
-
 		switch diffMsg.Diff {
 		case differInNone:
 			// No difference, continue.
@@ -157,9 +157,13 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 				continue
 			}
 
-			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
+			sourceSuffix := sourceKey(sourceAlias, strings.TrimPrefix(diffMsg.FirstURL, sourceURL), opts.escapeKeys)
 			// Either available only in source or size differs and force is set
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetPath, err := joinTargetKey(targetAlias, targetURL, sourceSuffix, opts.escapeKeys)
+			if err != nil {
+				URLsCh <- URLs{Error: err.Trace(diffMsg.FirstURL)}
+				continue
+			}
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -170,8 +174,12 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			}
 		case differInFirst:
 			// Only in first, always copy.
-			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			sourceSuffix := sourceKey(sourceAlias, strings.TrimPrefix(diffMsg.FirstURL, sourceURL), opts.escapeKeys)
+			targetPath, err := joinTargetKey(targetAlias, targetURL, sourceSuffix, opts.escapeKeys)
+			if err != nil {
+				URLsCh <- URLs{Error: err.Trace(diffMsg.FirstURL)}
+				continue
+			}
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -199,12 +207,22 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 type mirrorOptions struct {
 	isFake, isOverwrite, activeActive bool
 	isWatch, isRemove, isMetadata     bool
-	excludeOptions                    []string
+	isQuiet                           bool
+	filter                            *listFilter
 	encKeyDB                          map[string][]prefixSSEPair
 	md5, disableMultipart             bool
 	olderThan, newerThan              string
 	storageClass                      string
 	userMetadata                      map[string]string
+	limits                            bandwidthLimits
+	verify                            checksumAlgorithm
+	transfer                          transferOptions
+	maxWorkers                        int
+	pollInterval, debounce            time.Duration
+	normalize                         keyNormalization
+	escapeKeys                        bool
+	// Progress of the mirror session, if any.
+	journal *mirrorJournal
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
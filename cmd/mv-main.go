@@ -364,7 +364,7 @@ func mainMove(cliCtx *cli.Context) error {
 		}
 	}
 
-	e := doCopySession(ctx, cancelMove, cliCtx, session, encKeyDB, true)
+	e := doCopySession(ctx, cancelMove, cliCtx, session, nil, encKeyDB, true)
 	if session != nil {
 		session.Delete()
 	}
@@ -24,7 +24,7 @@ import (
 )
 
 const (
-	// Maximum number of parallel workers
+	// Default maximum number of parallel workers
 	maxParallelWorkers = 128
 
 	// Monitor tick to decide to add new workers
@@ -42,12 +42,19 @@ type ParallelManager struct {
 	// aligned at 64bit. See https://github.com/golang/go/issues/599
 	sentBytes int64
 
+	// Last sampled transfer speed in bytes per second, same alignment
+	// requirement as sentBytes.
+	bandwidth int64
+
 	// Synchronize workers
 	wg *sync.WaitGroup
 
 	// Current threads number
 	workersNum uint32
 
+	// Maximum threads number
+	maxWorkers uint32
+
 	// Channel to receive tasks to run
 	queueCh chan func() URLs
 	// Channel to send back results
@@ -58,7 +65,7 @@ type ParallelManager struct {
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -90,10 +97,20 @@ func (p *ParallelManager) Read(b []byte) (n int, err error) {
 	return len(b), nil
 }
 
+// workerBandwidth returns the last sampled transfer speed of a single
+// worker in bytes per second, zero until sampled.
+func (p *ParallelManager) workerBandwidth() int64 {
+	workersNum := atomic.LoadUint32(&p.workersNum)
+	if workersNum == 0 {
+		return 0
+	}
+	return atomic.LoadInt64(&p.bandwidth) / int64(workersNum)
+}
+
 // monitorProgress monitors realtime transfer speed of data
 // and increases threads until it reaches a maximum number of
 // threads or notice there is no apparent enhancement of
-// transfer speed.
+// transfer speed. Transfer speed is sampled until stopped.
 func (p *ParallelManager) monitorProgress() {
 	go func() {
 		ticker := time.NewTicker(monitorPeriod)
@@ -101,6 +118,7 @@ func (p *ParallelManager) monitorProgress() {
 
 		var prevSentBytes, maxBandwidth int64
 		var retry int
+		var settled bool
 
 		for {
 			select {
@@ -112,14 +130,19 @@ func (p *ParallelManager) monitorProgress() {
 				sentBytes := atomic.LoadInt64(&p.sentBytes)
 				bandwidth := sentBytes - prevSentBytes
 				prevSentBytes = sentBytes
+				atomic.StoreInt64(&p.bandwidth, int64(float64(bandwidth)/monitorPeriod.Seconds()))
 
+				if settled {
+					continue
+				}
 				if bandwidth <= maxBandwidth {
 					retry++
 					// We still want to add more workers
 					// until we are sure that it is not
 					// useful to add more of them.
 					if retry > 2 {
-						return
+						settled = true
+						continue
 					}
 				} else {
 					retry = 0
@@ -140,11 +163,16 @@ func (p *ParallelManager) wait() {
 	close(p.stopMonitorCh)
 }
 
-// newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs) {
+// newParallelManager starts new workers waiting for executing tasks,
+// at most maxWorkers of them, zero means maxParallelWorkers.
+func newParallelManager(resultCh chan URLs, maxWorkers int) (*ParallelManager, chan func() URLs) {
+	if maxWorkers <= 0 {
+		maxWorkers = maxParallelWorkers
+	}
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    uint32(maxWorkers),
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan func() URLs),
 		resultCh:      resultCh,
@@ -17,10 +17,12 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"syscall"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -43,7 +45,7 @@ var pipeCmd = cli.Command{
 	Usage:  "stream STDIN to an object",
 	Action: mainPipe,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(pipeFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(pipeFlags, limitFlags...), multipartFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -72,10 +74,16 @@ EXAMPLES:
 
   5. Write contents of stdin to an object on Amazon S3 cloud storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
      {{.Prompt}} {{.HelpName}} --storage-class REDUCED_REDUNDANCY s3/personalbuck/meeting-notes.txt
+
+  6. Stream MySQL database dump to Amazon S3 while limiting uploads to 20MiB/s.
+     {{.Prompt}} mysqldump -u root -p ******* accountsdb | {{.HelpName}} --limit-upload 20MiB s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+  7. Stream a large backup to Amazon S3 uploading 1GiB parts, 8 of them in parallel.
+     {{.Prompt}} tar cz /var/lib/backups | {{.HelpName}} --part-size 1GiB --parallel-parts 8 s3/backups/backup.tar.gz
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass string) *probe.Error {
+func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass string, limits bandwidthLimits, transfer transferOptions) *probe.Error {
 	if targetURL == "" {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
@@ -90,7 +98,12 @@ func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass st
 	if storageClass != "" {
 		metadata = map[string]string{"X-Amz-Storage-Class": storageClass}
 	}
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, sseKey, false, false, metadata)
+	var reader io.Reader = os.Stdin
+	if limits.upload != nil && mustGetHostConfig(alias) != nil {
+		reader = hookreader.NewHook(os.Stdin, limits.upload)
+	}
+	ctx := withTransferOptions(globalContext, transfer)
+	_, err := putTargetStreamWithURL(ctx, targetURL, reader, -1, sseKey, false, false, metadata)
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
@@ -107,6 +120,9 @@ func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
 	}
+	if ctx.String("limit-download") != "" {
+		fatalIf(errInvalidArgument().Trace(ctx.String("limit-download")), "--limit-download does not apply to pipe, which reads STDIN.")
+	}
 }
 
 // mainPipe is the main entry point for pipe command.
@@ -118,13 +134,19 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	limits, err := parseBandwidthLimits(ctx.String("limit-upload"), "")
+	fatalIf(err, "Unable to parse bandwidth limits.")
+
+	transfer, err := getTransferOptions(ctx)
+	fatalIf(err, "Unable to parse multipart options.")
+
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil, ctx.String("storage-class"))
+		err = pipe("", nil, ctx.String("storage-class"), limits, transfer)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB, ctx.String("storage-class"))
+		err = pipe(URLs[0], encKeyDB, ctx.String("storage-class"), limits, transfer)
 		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
 	}
 
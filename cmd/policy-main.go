@@ -30,6 +30,7 @@ import (
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
 
 var (
@@ -38,6 +39,22 @@ var (
 			Name:  "recursive, r",
 			Usage: "list recursively",
 		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: "action of the simulated request, e.g. 's3:GetObject'",
+		},
+		cli.StringFlag{
+			Name:  "resource",
+			Usage: "resource of the simulated request, e.g. 'arn:aws:s3:::mybucket/myobject'",
+		},
+		cli.StringFlag{
+			Name:  "user",
+			Usage: "user sending the simulated request",
+		},
+		cli.BoolFlag{
+			Name:  "fail-on-allow",
+			Usage: "exit with an error status when the simulated request is allowed",
+		},
 	}
 )
 
@@ -57,6 +74,7 @@ USAGE:
   {{.HelpName}} [FLAGS] get TARGET
   {{.HelpName}} [FLAGS] get-json TARGET
   {{.HelpName}} [FLAGS] list TARGET
+  {{.HelpName}} [FLAGS] simulate POLICYFILE|TARGET
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -67,6 +85,11 @@ PERMISSION:
 FILE:
   A valid S3 policy JSON filepath.
 
+POLICYFILE:
+  A bucket policy or IAM policy JSON filepath to simulate a request against, offline.
+  A TARGET bucket is simulated against its bucket policy, a TARGET alias against
+  the IAM policies of the '--user'.
+
 EXAMPLES:
    1. Set bucket to "download" on Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} set download s3/burningman2011
@@ -94,6 +117,12 @@ EXAMPLES:
 
    9. List public object URLs recursively.
       {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Simulate if the IAM policy in a file allows uploads to a prefix.
+      {{.Prompt}} {{.HelpName}} simulate --action s3:PutObject --resource arn:aws:s3:::mybucket/uploads/a.txt /path/to/policy.json
+
+  11. Simulate if user 'foobar' on alias 'myminio' can download an object, fail when it is allowed.
+      {{.Prompt}} {{.HelpName}} simulate --action s3:GetObject --resource arn:aws:s3:::mybucket/private/a.txt --user foobar --fail-on-allow myminio
 `,
 }
 
@@ -230,6 +259,18 @@ func checkPolicySyntax(ctx *cli.Context) {
 		if argsLength != 2 {
 			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
 		}
+	case "simulate":
+		// Always expect a policy file or a target after simulate cmd
+		if argsLength != 2 {
+			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		}
+		if ctx.String("action") == "" || ctx.String("resource") == "" {
+			fatalIf(errInvalidArgument().Trace(), "Both --action and --resource are required to simulate a request.")
+		}
+		if !iampolicy.Action(ctx.String("action")).IsValid() {
+			fatalIf(errInvalidArgument().Trace(ctx.String("action")),
+				"Unrecognized action `"+ctx.String("action")+"`.")
+		}
 	default:
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
 	}
@@ -481,6 +522,8 @@ func mainPolicy(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Policy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyAllow", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyDeny", color.New(color.FgRed, color.Bold))
 
 	switch ctx.Args().First() {
 	case "set", "set-json", "get", "get-json":
@@ -495,6 +538,9 @@ func mainPolicy(ctx *cli.Context) error {
 	case "links":
 		// policy links alias/bucket/prefix
 		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+	case "simulate":
+		// policy simulate --action ACTION --resource RESOURCE [--user USER] POLICYFILE|alias[/bucket]
+		return runPolicySimulateCmd(ctx)
 	default:
 		// Shows command example and exit
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
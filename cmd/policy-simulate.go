@@ -0,0 +1,293 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	bucketpolicy "github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/console"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// s3ResourceARNPrefix is the ARN prefix of S3 resources in policies.
+const s3ResourceARNPrefix = "arn:aws:s3:::"
+
+// policyStatementMatch is the statement deciding a simulated request.
+type policyStatementMatch struct {
+	Index  int    `json:"index"`
+	SID    string `json:"sid,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// policyDecision collects the statements matching a simulated request,
+// an explicit deny always wins over an allow.
+type policyDecision struct {
+	allow *policyStatementMatch
+	deny  *policyStatementMatch
+}
+
+// add records the outcome of a statement. isAllowed is the result of the
+// statement's own evaluation, true for a matching allow statement and
+// false for a matching deny statement.
+func (d *policyDecision) add(index int, sid string, effect bucketpolicy.Effect, isAllowed bool) {
+	isAllow := effect == bucketpolicy.Allow
+	if isAllowed != isAllow {
+		// Statement does not match the request.
+		return
+	}
+	match := &policyStatementMatch{Index: index + 1, SID: sid, Effect: string(effect)}
+	if isAllow && d.allow == nil {
+		d.allow = match
+	}
+	if !isAllow && d.deny == nil {
+		d.deny = match
+	}
+}
+
+// result returns whether the request is allowed and the deciding statement,
+// which is nil for an implicit deny.
+func (d policyDecision) result() (bool, *policyStatementMatch) {
+	if d.deny != nil {
+		return false, d.deny
+	}
+	return d.allow != nil, d.allow
+}
+
+// simulateIAMPolicy evaluates an IAM policy against a request.
+func simulateIAMPolicy(p iampolicy.Policy, args iampolicy.Args) (bool, *policyStatementMatch) {
+	var d policyDecision
+	for i, statement := range p.Statements {
+		d.add(i, string(statement.SID), statement.Effect, statement.IsAllowed(args))
+	}
+	return d.result()
+}
+
+// simulateBucketPolicy evaluates a bucket policy against a request.
+func simulateBucketPolicy(p bucketpolicy.Policy, args bucketpolicy.Args) (bool, *policyStatementMatch) {
+	var d policyDecision
+	for i, statement := range p.Statements {
+		d.add(i, string(statement.SID), statement.Effect, statement.IsAllowed(args))
+	}
+	return d.result()
+}
+
+// policySimulateMessage container for the result of a policy simulation.
+type policySimulateMessage struct {
+	Status    string                `json:"status"`
+	Source    string                `json:"source"`
+	Action    string                `json:"action"`
+	Resource  string                `json:"resource"`
+	User      string                `json:"user,omitempty"`
+	Allowed   bool                  `json:"allowed"`
+	Statement *policyStatementMatch `json:"statement,omitempty"`
+}
+
+// String colorized policy simulate message.
+func (s policySimulateMessage) String() string {
+	decision := console.Colorize("PolicyDeny", "Deny")
+	if s.Allowed {
+		decision = console.Colorize("PolicyAllow", "Allow")
+	}
+	message := decision + ": " + s.Action + " on `" + s.Resource + "`"
+	if s.User != "" {
+		message += " for user `" + s.User + "`"
+	}
+	if s.Statement == nil {
+		return message + " (implicit deny, no statement matched)"
+	}
+	statement := "statement #" + strconv.Itoa(s.Statement.Index)
+	if s.Statement.SID != "" {
+		statement += " `" + s.Statement.SID + "`"
+	}
+	return message + " (" + statement + ")"
+}
+
+// JSON jsonified policy simulate message.
+func (s policySimulateMessage) JSON() string {
+	s.Status = "success"
+	policyJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(policyJSONBytes)
+}
+
+// parseS3Resource splits an S3 resource ARN, or a plain 'bucket/object'
+// resource, into its bucket and object name.
+func parseS3Resource(resource string) (bucket, object string, err *probe.Error) {
+	path := strings.TrimPrefix(resource, s3ResourceARNPrefix)
+	if i := strings.Index(path, "/"); i >= 0 {
+		bucket, object = path[:i], path[i+1:]
+	} else {
+		bucket = path
+	}
+	if bucket == "" || strings.HasPrefix(path, "arn:") {
+		return "", "", errInvalidArgument().Trace(resource)
+	}
+	return bucket, object, nil
+}
+
+// isBucketPolicy reports if a policy document has principals, which
+// only bucket policies have.
+func isBucketPolicy(data []byte) bool {
+	var doc struct {
+		Statement []struct {
+			Principal json.RawMessage `json:"Principal"`
+		} `json:"Statement"`
+	}
+	if e := json.Unmarshal(data, &doc); e != nil {
+		return false
+	}
+	for _, statement := range doc.Statement {
+		if len(statement.Principal) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getUserIAMPolicy fetches and merges the canned policies of a user
+// and of the groups the user is a member of.
+func getUserIAMPolicy(ctx context.Context, aliasedURL, user string) (iampolicy.Policy, *probe.Error) {
+	client, err := newAdminClient(aliasedURL)
+	if err != nil {
+		return iampolicy.Policy{}, err.Trace(aliasedURL)
+	}
+
+	userInfo, e := client.GetUserInfo(ctx, user)
+	if e != nil {
+		return iampolicy.Policy{}, probe.NewError(e).Trace(aliasedURL, user)
+	}
+	policyNames := strings.Split(userInfo.PolicyName, ",")
+	for _, group := range userInfo.MemberOf {
+		groupDesc, e := client.GetGroupDescription(ctx, group)
+		if e != nil {
+			return iampolicy.Policy{}, probe.NewError(e).Trace(aliasedURL, group)
+		}
+		policyNames = append(policyNames, strings.Split(groupDesc.Policy, ",")...)
+	}
+
+	policies, e := client.ListCannedPolicies(ctx)
+	if e != nil {
+		return iampolicy.Policy{}, probe.NewError(e).Trace(aliasedURL)
+	}
+	merged := iampolicy.Policy{Version: iampolicy.DefaultVersion}
+	for _, name := range policyNames {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if p, ok := policies[name]; ok {
+			merged.Statements = append(merged.Statements, p.Statements...)
+		}
+	}
+	return merged, nil
+}
+
+// Run policy simulate command
+func runPolicySimulateCmd(cliCtx *cli.Context) error {
+	ctx, cancelPolicySimulate := context.WithCancel(globalContext)
+	defer cancelPolicySimulate()
+
+	source := cliCtx.Args().Get(1)
+	action := cliCtx.String("action")
+	resource := cliCtx.String("resource")
+	user := cliCtx.String("user")
+
+	bucket, object, err := parseS3Resource(resource)
+	fatalIf(err, "Unable to parse resource `"+resource+"`.")
+
+	conditionValues := map[string][]string{}
+	if user != "" {
+		conditionValues["username"] = []string{user}
+		conditionValues["userid"] = []string{user}
+	}
+
+	msg := policySimulateMessage{
+		Source:   source,
+		Action:   action,
+		Resource: resource,
+		User:     user,
+	}
+
+	fi, e := os.Stat(source)
+	isPolicyFile := e == nil && fi.Mode().IsRegular()
+
+	var iamp *iampolicy.Policy
+	if _, path := url2Alias(source); !isPolicyFile && strings.Trim(path, "/") == "" {
+		// An alias target, simulate against the IAM policies of the user.
+		if user == "" {
+			fatalIf(errInvalidArgument().Trace(source), "A user is required to simulate the IAM policies of `"+source+"`.")
+		}
+		p, err := getUserIAMPolicy(ctx, source, user)
+		fatalIf(err.Trace(source, user), "Unable to get policies of user `"+user+"`.")
+		iamp = &p
+	} else {
+		var policyBytes []byte
+		if isPolicyFile {
+			policyBytes, e = ioutil.ReadFile(source)
+			fatalIf(probe.NewError(e).Trace(source), "Unable to read policy file `"+source+"`.")
+		} else {
+			// A bucket target, simulate against its bucket policy.
+			_, policyStr, err := doGetAccess(ctx, source)
+			fatalIf(err.Trace(source), "Unable to get policy of `"+source+"`.")
+			policyBytes = []byte(policyStr)
+		}
+
+		if !isPolicyFile || isBucketPolicy(policyBytes) {
+			// A bucket without a policy denies everything.
+			p := &bucketpolicy.Policy{}
+			if len(policyBytes) > 0 {
+				p, e = bucketpolicy.ParseConfig(bytes.NewReader(policyBytes), bucket)
+				fatalIf(probe.NewError(e).Trace(source), "Unable to parse the bucket policy of `"+source+"`.")
+			}
+			msg.Allowed, msg.Statement = simulateBucketPolicy(*p, bucketpolicy.Args{
+				AccountName:     user,
+				Action:          bucketpolicy.Action(action),
+				BucketName:      bucket,
+				ConditionValues: conditionValues,
+				ObjectName:      object,
+			})
+		} else {
+			p, e := iampolicy.ParseConfig(bytes.NewReader(policyBytes))
+			fatalIf(probe.NewError(e).Trace(source), "Unable to parse the IAM policy of `"+source+"`.")
+			iamp = p
+		}
+	}
+	if iamp != nil {
+		msg.Allowed, msg.Statement = simulateIAMPolicy(*iamp, iampolicy.Args{
+			AccountName:     user,
+			Action:          iampolicy.Action(action),
+			BucketName:      bucket,
+			ConditionValues: conditionValues,
+			ObjectName:      object,
+		})
+	}
+	printMsg(msg)
+
+	if msg.Allowed && cliCtx.Bool("fail-on-allow") {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+func TestParseS3Resource(t *testing.T) {
+	testCases := []struct {
+		resource   string
+		bucket     string
+		object     string
+		shouldPass bool
+	}{
+		{"arn:aws:s3:::mybucket/prefix/key", "mybucket", "prefix/key", true},
+		{"arn:aws:s3:::mybucket", "mybucket", "", true},
+		{"mybucket/key", "mybucket", "key", true},
+		{"arn:aws:s3:::", "", "", false},
+		{"arn:aws:iam::123456789012:user/foo", "", "", false},
+	}
+
+	for i, testCase := range testCases {
+		bucket, object, err := parseS3Resource(testCase.resource)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if bucket != testCase.bucket || object != testCase.object {
+			t.Fatalf("Test %d: expected %s %s, got %s %s", i+1, testCase.bucket, testCase.object, bucket, object)
+		}
+	}
+}
+
+func TestSimulateIAMPolicy(t *testing.T) {
+	p, e := iampolicy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "uploads", "Effect": "Allow", "Action": ["s3:PutObject", "s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Sid": "private", "Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/private/*"]}
+  ]
+}`))
+	if e != nil {
+		t.Fatalf("Unexpected error: %s", e)
+	}
+	if isBucketPolicy([]byte(`{"Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"]}]}`)) {
+		t.Fatalf("Expected an IAM policy")
+	}
+
+	testCases := []struct {
+		action  string
+		object  string
+		allowed bool
+		sid     string
+	}{
+		{"s3:PutObject", "uploads/a.txt", true, "uploads"},
+		{"s3:GetObject", "uploads/a.txt", true, "uploads"},
+		// Explicit deny wins over the allow.
+		{"s3:GetObject", "private/a.txt", false, "private"},
+		// Implicit deny.
+		{"s3:DeleteObject", "uploads/a.txt", false, ""},
+	}
+
+	for i, testCase := range testCases {
+		allowed, match := simulateIAMPolicy(*p, iampolicy.Args{
+			Action:          iampolicy.Action(testCase.action),
+			BucketName:      "mybucket",
+			ObjectName:      testCase.object,
+			ConditionValues: map[string][]string{},
+		})
+		if allowed != testCase.allowed {
+			t.Fatalf("Test %d: expected allowed %t, got %t", i+1, testCase.allowed, allowed)
+		}
+		if testCase.sid == "" && match != nil {
+			t.Fatalf("Test %d: expected no matching statement, got %+v", i+1, match)
+		}
+		if testCase.sid != "" && (match == nil || match.SID != testCase.sid) {
+			t.Fatalf("Test %d: expected statement %s, got %+v", i+1, testCase.sid, match)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var replicateAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "id for the rule, should be a unique value",
+	},
+	cli.StringFlag{
+		Name:  "arn",
+		Usage: "ARN of the destination bucket",
+	},
+	cli.StringFlag{
+		Name:  "prefix",
+		Usage: "prefix of the objects to replicate",
+	},
+	cli.StringFlag{
+		Name:  "tags",
+		Usage: "format '<key1>=<value1>&<key2>=<value2>&<key3>=<value3>', multiple values allowed for multiple key/value pairs",
+	},
+	cli.IntFlag{
+		Name:  "priority",
+		Usage: "priority of the rule when several rules match an object, should be a unique value",
+	},
+	cli.StringFlag{
+		Name:  "storage-class",
+		Usage: "storage class of the replicas (STANDARD, STANDARD_IA, REDUCED_REDUNDANCY. Etc)",
+	},
+	cli.BoolFlag{
+		Name:  "replicate-delete-marker",
+		Usage: "replicate delete markers",
+	},
+	cli.BoolFlag{
+		Name:  "disable",
+		Usage: "disable the rule",
+	},
+}
+
+var replicateAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "add a replication configuration rule to existing (if any) rule(s) on a bucket",
+	Action: mainReplicateAdd,
+	Before: setGlobalsFromContext,
+	Flags:  append(replicateAddFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Add a rule to the existing set of replication configuration rules,
+  If a rule with ID already exists it is replaced with the new rule.
+  Versioning must be enabled on the source and destination buckets.
+
+EXAMPLES:
+  1. Replicate all objects of 'sourcebucket' on alias 'myminio' to the bucket of the ARN.
+     {{.Prompt}} {{.HelpName}} --id "Everything" --priority 1 \
+          --arn "arn:minio:replication:us-east-1:c5be6b16-769d-432a-9ef1-4567081f3566:destbucket" \
+          myminio/sourcebucket
+
+  2. Replicate the objects with prefix 'docs/' and tagged 'project=apollo', along with delete markers.
+     {{.Prompt}} {{.HelpName}} --id "Apollo" --priority 2 --prefix "docs/" --tags "project=apollo" \
+          --replicate-delete-marker --arn "arn:aws:s3:::destbucket" s3/sourcebucket
+`,
+}
+
+type replicateAddMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+	ID     string `json:"id"`
+}
+
+func (r replicateAddMessage) String() string {
+	return console.Colorize(replicateThemeResultSuccess, "Replication configuration rule added with ID `"+r.ID+"` to "+r.Target+".")
+}
+
+func (r replicateAddMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// Validate user given arguments
+func checkReplicateAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "add", globalErrorExitStatus)
+	}
+
+	if ctx.String("id") == "" {
+		fatalIf(errInvalidArgument(), "ID for replication rule cannot be empty, please refer mc "+ctx.Command.FullName()+" --help for more details")
+	}
+	if ctx.String("arn") == "" {
+		fatalIf(errInvalidArgument(), "ARN of the destination bucket cannot be empty, please refer mc "+ctx.Command.FullName()+" --help for more details")
+	}
+}
+
+func mainReplicateAdd(cliCtx *cli.Context) error {
+	ctx, cancelReplicateAdd := context.WithCancel(globalContext)
+	defer cancelReplicateAdd()
+
+	checkReplicateAddSyntax(cliCtx)
+	setReplicateDisplayColorScheme()
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	// Configuration that is already set.
+	cfg, err := client.GetReplication(ctx)
+	fatalIf(err.Trace(args...), "Unable to fetch replication rules for "+urlStr)
+
+	// A new rule is added or the rule (if existing) is replaced.
+	cfg, err = replication.ApplyNewReplicationConfig(cliCtx, cfg)
+	fatalIf(err.Trace(args...), "Unable to generate new replication rules for the input")
+
+	fatalIf(client.SetReplication(ctx, cfg).Trace(urlStr), "Unable to set new replication rules")
+
+	printMsg(replicateAddMessage{
+		Status: "success",
+		Target: urlStr,
+		ID:     cliCtx.String("id"),
+	})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var replicateExportCmd = cli.Command{
+	Name:   "export",
+	Usage:  "export replication configuration in JSON format",
+	Action: mainReplicateExport,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+DESCRIPTION:
+  Exports replication configuration in JSON format to STDOUT.
+
+EXAMPLES:
+  1. Export replication configuration for 'sourcebucket' to 'replication.json' file.
+     {{.Prompt}} {{.HelpName}} myminio/sourcebucket > replication.json
+
+  2. Print replication configuration for 'sourcebucket' to STDOUT.
+     {{.Prompt}} {{.HelpName}} myminio/sourcebucket
+`,
+}
+
+type replicateExportMessage struct {
+	Status string             `json:"status"`
+	Target string             `json:"target"`
+	Config replication.Config `json:"config"`
+}
+
+func (r replicateExportMessage) String() string {
+	msgBytes, e := json.MarshalIndent(r.Config, "", " ")
+	fatalIf(probe.NewError(e), "Unable to export replication configuration")
+
+	return string(msgBytes)
+}
+
+func (r replicateExportMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal replication message")
+
+	return string(msgBytes)
+}
+
+// checkReplicateExportSyntax - validate arguments passed by user
+func checkReplicateExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", globalErrorExitStatus)
+	}
+}
+
+func mainReplicateExport(cliCtx *cli.Context) error {
+	ctx, cancelReplicateExport := context.WithCancel(globalContext)
+	defer cancelReplicateExport()
+
+	checkReplicateExportSyntax(cliCtx)
+	setReplicateDisplayColorScheme()
+
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(args...), "Unable to initialize client for "+urlStr+".")
+
+	cfg, err := client.GetReplication(ctx)
+	fatalIf(err.Trace(args...), "Unable to get replication configuration")
+	if cfg.Empty() {
+		fatalIf(probe.NewError(errors.New("replication configuration not set")).Trace(urlStr),
+			"Unable to export replication configuration")
+	}
+
+	printMsg(replicateExportMessage{
+		Status: "success",
+		Target: urlStr,
+		Config: cfg,
+	})
+
+	return nil
+}
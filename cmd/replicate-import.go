@@ -0,0 +1,112 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var replicateImportCmd = cli.Command{
+	Name:   "import",
+	Usage:  "import replication configuration in JSON format",
+	Action: mainReplicateImport,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+DESCRIPTION:
+  Import entire replication configuration from STDIN, input file is expected to be in JSON format.
+
+EXAMPLES:
+  1. Set replication configuration for the sourcebucket on alias 'myminio' to the rules imported from replication.json
+     {{.Prompt}} {{.HelpName}} myminio/sourcebucket < replication.json
+
+  2. Set replication configuration for the sourcebucket on alias 'myminio'. User is expected to enter the JSON contents on STDIN
+     {{.Prompt}} {{.HelpName}} myminio/sourcebucket
+`,
+}
+
+type replicateImportMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+}
+
+func (r replicateImportMessage) String() string {
+	return console.Colorize(replicateThemeResultSuccess, "Replication configuration imported successfully to `"+r.Target+"`.")
+}
+
+func (r replicateImportMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// readReplicationConfig reads the JSON replication configuration from stdin.
+func readReplicationConfig() (replication.Config, *probe.Error) {
+	var cfg replication.Config
+	dec := json.NewDecoder(os.Stdin)
+	if e := dec.Decode(&cfg); e != nil {
+		return cfg, probe.NewError(e)
+	}
+	if err := replication.Validate(cfg); err != nil {
+		return cfg, err.Trace()
+	}
+	return cfg, nil
+}
+
+// checkReplicateImportSyntax - validate arguments passed by user
+func checkReplicateImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", globalErrorExitStatus)
+	}
+}
+
+func mainReplicateImport(cliCtx *cli.Context) error {
+	ctx, cancelReplicateImport := context.WithCancel(globalContext)
+	defer cancelReplicateImport()
+
+	checkReplicateImportSyntax(cliCtx)
+	setReplicateDisplayColorScheme()
+
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	cfg, err := readReplicationConfig()
+	fatalIf(err.Trace(args...), "Unable to read replication configuration")
+
+	fatalIf(client.SetReplication(ctx, cfg).Trace(urlStr), "Unable to set replication configuration")
+
+	printMsg(replicateImportMessage{
+		Status: "success",
+		Target: urlStr,
+	})
+	return nil
+}
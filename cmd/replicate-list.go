@@ -0,0 +1,154 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var replicateListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "pretty print bucket replication configuration",
+	Action: mainReplicateList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the replication rules of 'sourcebucket' on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/sourcebucket
+
+  2. List the replication rules of 'sourcebucket' on alias 'myminio' in JSON format.
+     {{.Prompt}} {{.HelpName}} --json myminio/sourcebucket
+`,
+}
+
+type replicateListMessage struct {
+	Status string             `json:"status"`
+	Target string             `json:"target"`
+	Config replication.Config `json:"config"`
+}
+
+func (r replicateListMessage) String() string {
+	header := []string{"ID", "Priority", "Status", "Prefix", "Tags", "Destination", "StorageClass", "DeleteMarker"}
+	rows := [][]string{header}
+	for _, rule := range r.Config.Rules {
+		var tags []string
+		for _, tag := range rule.Tags() {
+			tags = append(tags, tag.Key+"="+tag.Value)
+		}
+		deleteMarker := replication.Disabled
+		if rule.DeleteMarkerReplication != nil {
+			deleteMarker = rule.DeleteMarkerReplication.Status
+		}
+		rows = append(rows, []string{
+			rule.ID,
+			strconv.Itoa(rule.Priority),
+			rule.Status,
+			rule.Prefix(),
+			strings.Join(tags, "&"),
+			rule.Destination.Bucket,
+			rule.Destination.StorageClass,
+			deleteMarker,
+		})
+	}
+
+	// Every column is as wide as its longest cell.
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var lines []string
+	for i, row := range rows {
+		theme := replicateThemeRow
+		if i == 0 {
+			theme = replicateThemeHeader
+		}
+		var fields []Field
+		for _, width := range widths {
+			fields = append(fields, Field{theme, width})
+		}
+		lines = append(lines, newPrettyTable(" "+tableSeperator+" ", fields...).buildRow(row...))
+	}
+	if r.Config.Role != "" {
+		lines = append([]string{console.Colorize(replicateThemeHeader, "Role: ") + r.Config.Role}, lines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r replicateListMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkReplicateListSyntax - validate arguments passed by user
+func checkReplicateListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", globalErrorExitStatus)
+	}
+}
+
+func mainReplicateList(cliCtx *cli.Context) error {
+	ctx, cancelReplicateList := context.WithCancel(globalContext)
+	defer cancelReplicateList()
+
+	checkReplicateListSyntax(cliCtx)
+	setReplicateDisplayColorScheme()
+
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(args...), "Unable to initialize client for "+urlStr+".")
+
+	cfg, err := client.GetReplication(ctx)
+	fatalIf(err.Trace(args...), "Unable to get replication configuration")
+	if cfg.Empty() {
+		fatalIf(probe.NewError(errors.New("replication configuration not set")).Trace(urlStr),
+			"Unable to list replication configuration")
+	}
+
+	printMsg(replicateListMessage{
+		Status: "success",
+		Target: urlStr,
+		Config: cfg,
+	})
+
+	return nil
+}
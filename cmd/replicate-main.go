@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var replicateCmd = cli.Command{
+	Name:            "replicate",
+	Usage:           "configure server side bucket replication",
+	Action:          mainReplicate,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		replicateAddCmd,
+		replicateListCmd,
+		replicateRemoveCmd,
+		replicateExportCmd,
+		replicateImportCmd,
+	},
+}
+
+const (
+	replicateThemeHeader        string = "Replicate-Header"
+	replicateThemeRow           string = "Replicate-Row"
+	replicateThemeResultSuccess string = "Replicate-Success"
+)
+
+func mainReplicate(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+}
+
+// Color scheme for the replicate commands.
+func setReplicateDisplayColorScheme() {
+	console.SetColor(replicateThemeHeader, color.New(color.Bold, color.FgHiGreen))
+	console.SetColor(replicateThemeRow, color.New(color.FgHiWhite))
+	console.SetColor(replicateThemeResultSuccess, color.New(color.FgGreen, color.Bold))
+}
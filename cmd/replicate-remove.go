@@ -0,0 +1,147 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/cmd/replication"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var replicateRemoveFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "id of the replication rule",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "force flag is to be used when deleting all replication configuration rules for the bucket",
+	},
+	cli.BoolFlag{
+		Name:  "all",
+		Usage: "delete all replication configuration rules of the bucket, force flag enforced",
+	},
+}
+
+var replicateRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove (if any) existing replication configuration rule with the id",
+	Action: mainReplicateRemove,
+	Before: setGlobalsFromContext,
+	Flags:  append(replicateRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Remove a replication configuration rule for the bucket by ID, optionally you can remove
+  all the replication rules on a bucket with '--all --force' option.
+
+EXAMPLES:
+  1. Remove the replication configuration rule given by ID "Apollo" for sourcebucket on alias 'myminio'. ID is case sensitive.
+     {{.Prompt}} {{.HelpName}} --id "Apollo" myminio/sourcebucket
+
+  2. Remove ALL the replication configuration rules for sourcebucket on alias 'myminio'.
+     Because the result is complete removal, the use of --force flag is enforced.
+     {{.Prompt}} {{.HelpName}} --all --force myminio/sourcebucket
+`,
+}
+
+type replicateRemoveMessage struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+	Target string `json:"target"`
+	All    bool   `json:"all"`
+}
+
+func (r replicateRemoveMessage) String() string {
+	msg := "Replication rule ID `" + r.ID + "` from target " + r.Target + " removed."
+	if r.All {
+		msg = "Replication rules for `" + r.Target + "` removed."
+	}
+	return console.Colorize(replicateThemeResultSuccess, msg)
+}
+
+func (r replicateRemoveMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func checkReplicateRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", globalErrorExitStatus)
+	}
+
+	removeAll := ctx.Bool("all")
+	force := ctx.Bool("force")
+	if removeAll != force {
+		fatalIf(errInvalidArgument(),
+			"It is mandatory to specify --all and --force flag together for mc "+ctx.Command.FullName()+".")
+	}
+	if removeAll {
+		return
+	}
+
+	if ctx.String("id") == "" {
+		fatalIf(errInvalidArgument(), "Replication rule ID cannot be empty")
+	}
+}
+
+func mainReplicateRemove(cliCtx *cli.Context) error {
+	ctx, cancelReplicateRemove := context.WithCancel(globalContext)
+	defer cancelReplicateRemove()
+
+	checkReplicateRemoveSyntax(cliCtx)
+	setReplicateDisplayColorScheme()
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(args...), "Unable to initialize client for "+urlStr+".")
+
+	removeAll := cliCtx.Bool("all")
+	if removeAll {
+		fatalIf(client.RemoveReplication(ctx).Trace(urlStr), "Unable to remove replication configuration")
+	} else {
+		cfg, err := client.GetReplication(ctx)
+		fatalIf(err.Trace(urlStr), "Unable to fetch replication rules")
+
+		cfg, err = replication.RemoveRule(cfg, cliCtx.String("id"))
+		fatalIf(err.Trace(urlStr, cliCtx.String("id")), "Unable to remove rule by id")
+
+		fatalIf(client.SetReplication(ctx, cfg).Trace(urlStr), "Unable to set replication rules")
+	}
+
+	printMsg(replicateRemoveMessage{
+		Status: "success",
+		ID:     cliCtx.String("id"),
+		All:    removeAll,
+		Target: urlStr,
+	})
+
+	return nil
+}
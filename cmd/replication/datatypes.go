@@ -0,0 +1,108 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package replication contains the bucket replication configuration types
+// along with their parsing and validation.
+package replication
+
+import (
+	"encoding/xml"
+)
+
+// Status of a replication rule or of the replication of delete markers.
+const (
+	Enabled  = "Enabled"
+	Disabled = "Disabled"
+)
+
+// Tag structure key/value pair representing an object tag to apply replication configuration
+type Tag struct {
+	XMLName xml.Name `xml:"Tag,omitempty" json:"-"`
+	Key     string   `xml:"Key,omitempty" json:"Key,omitempty"`
+	Value   string   `xml:"Value,omitempty" json:"Value,omitempty"`
+}
+
+// AndOperator combines a prefix and tags in a Filter.
+type AndOperator struct {
+	XMLName xml.Name `xml:"And,omitempty" json:"-"`
+	Prefix  string   `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
+	Tags    []Tag    `xml:"Tag,omitempty" json:"Tags,omitempty"`
+}
+
+// Filter selects the objects a replication rule applies to.
+type Filter struct {
+	XMLName xml.Name     `xml:"Filter" json:"-"`
+	Prefix  string       `xml:"Prefix,omitempty" json:"Prefix,omitempty"`
+	And     *AndOperator `xml:"And,omitempty" json:"And,omitempty"`
+	Tag     *Tag         `xml:"Tag,omitempty" json:"Tag,omitempty"`
+}
+
+// Destination is the bucket objects are replicated to.
+type Destination struct {
+	XMLName xml.Name `xml:"Destination" json:"-"`
+	// ARN of the destination bucket.
+	Bucket       string `xml:"Bucket" json:"Bucket"`
+	StorageClass string `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
+}
+
+// DeleteMarkerReplication tells if delete markers are replicated.
+type DeleteMarkerReplication struct {
+	XMLName xml.Name `xml:"DeleteMarkerReplication" json:"-"`
+	Status  string   `xml:"Status" json:"Status"`
+}
+
+// Rule represents a single rule in replication configuration
+type Rule struct {
+	XMLName                 xml.Name                 `xml:"Rule" json:"-"`
+	ID                      string                   `xml:"ID" json:"ID"`
+	Status                  string                   `xml:"Status" json:"Status"`
+	Priority                int                      `xml:"Priority" json:"Priority"`
+	DeleteMarkerReplication *DeleteMarkerReplication `xml:"DeleteMarkerReplication,omitempty" json:"DeleteMarkerReplication,omitempty"`
+	Destination             Destination              `xml:"Destination" json:"Destination"`
+	Filter                  Filter                   `xml:"Filter" json:"Filter"`
+}
+
+// Prefix returns the prefix the rule applies to.
+func (r Rule) Prefix() string {
+	if r.Filter.And != nil {
+		return r.Filter.And.Prefix
+	}
+	return r.Filter.Prefix
+}
+
+// Tags returns the tags the rule applies to.
+func (r Rule) Tags() []Tag {
+	if r.Filter.And != nil {
+		return r.Filter.And.Tags
+	}
+	if r.Filter.Tag != nil {
+		return []Tag{*r.Filter.Tag}
+	}
+	return nil
+}
+
+// Config is the replication configuration of a bucket, a collection of Rule objects.
+type Config struct {
+	XMLName xml.Name `xml:"ReplicationConfiguration" json:"-"`
+	// ARN of the role used to replicate objects, optional with MinIO.
+	Role  string `xml:"Role,omitempty" json:"Role,omitempty"`
+	Rules []Rule `xml:"Rule" json:"Rules"`
+}
+
+// Empty returns true if the configuration has no rules.
+func (c Config) Empty() bool {
+	return len(c.Rules) == 0
+}
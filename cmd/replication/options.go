@@ -0,0 +1,133 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// RemoveRule - Remove the replication rule (with ID) from the configuration.
+func RemoveRule(cfg Config, id string) (Config, *probe.Error) {
+	if len(cfg.Rules) == 0 {
+		return cfg, probe.NewError(fmt.Errorf("replication configuration not set"))
+	}
+	n := 0
+	for _, rule := range cfg.Rules {
+		if rule.ID != id {
+			cfg.Rules[n] = rule
+			n++
+		}
+	}
+	if n == len(cfg.Rules) {
+		return cfg, probe.NewError(fmt.Errorf("replication rule for id '%s' not found", id))
+	}
+	cfg.Rules = cfg.Rules[:n]
+	return cfg, nil
+}
+
+type replicationOptions struct {
+	ID                    string
+	Prefix                string
+	Tags                  string
+	Priority              int
+	Arn                   string
+	StorageClass          string
+	Status                bool
+	ReplicateDeleteMarker bool
+}
+
+func (opts replicationOptions) ToConfig(cfg Config) (Config, *probe.Error) {
+	filter := Filter{Prefix: opts.Prefix}
+	if tags := extractTags(opts.Tags); len(tags) > 0 {
+		filter.And = &AndOperator{
+			Prefix: opts.Prefix,
+			Tags:   tags,
+		}
+		filter.Prefix = ""
+	}
+
+	newRule := Rule{
+		ID: opts.ID,
+		Status: func() string {
+			if opts.Status {
+				return Enabled
+			}
+			return Disabled
+		}(),
+		Priority: opts.Priority,
+		DeleteMarkerReplication: &DeleteMarkerReplication{
+			Status: func() string {
+				if opts.ReplicateDeleteMarker {
+					return Enabled
+				}
+				return Disabled
+			}(),
+		},
+		Destination: Destination{
+			Bucket:       opts.Arn,
+			StorageClass: strings.ToUpper(opts.StorageClass),
+		},
+		Filter: filter,
+	}
+
+	if err := validateRule(newRule); err != nil {
+		return cfg, err.Trace(opts.ID)
+	}
+
+	ruleFound := false
+	for i, rule := range cfg.Rules {
+		if rule.ID != newRule.ID {
+			continue
+		}
+		cfg.Rules[i] = newRule
+		ruleFound = true
+		break
+	}
+
+	if !ruleFound {
+		cfg.Rules = append(cfg.Rules, newRule)
+	}
+
+	if err := Validate(cfg); err != nil {
+		return cfg, err.Trace(opts.ID)
+	}
+	return cfg, nil
+}
+
+func getReplicationOptions(ctx *cli.Context) replicationOptions {
+	return replicationOptions{
+		ID:                    ctx.String("id"),
+		Prefix:                ctx.String("prefix"),
+		Tags:                  ctx.String("tags"),
+		Priority:              ctx.Int("priority"),
+		Arn:                   ctx.String("arn"),
+		StorageClass:          ctx.String("storage-class"),
+		Status:                !ctx.Bool("disable"),
+		ReplicateDeleteMarker: ctx.Bool("replicate-delete-marker"),
+	}
+}
+
+// ApplyNewReplicationConfig adds the rule described by the command flags to
+// the existing replication configuration, a rule with the same ID is replaced.
+func ApplyNewReplicationConfig(ctx *cli.Context, cfg Config) (Config, *probe.Error) {
+	opts := getReplicationOptions(ctx)
+	return opts.ToConfig(cfg)
+}
@@ -0,0 +1,134 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	tagSeperator    string = "&"
+	keyValSeperator string = "="
+
+	// Maximum length of a rule ID, as enforced by Amazon S3.
+	maxRuleIDLength = 255
+)
+
+// Extracts the tags provided by user, in the '<key1>=<value1>&<key2>=<value2>' format.
+func extractTags(tagLabelVal string) []Tag {
+	var tags []Tag
+	for _, tag := range strings.Split(tagLabelVal, tagSeperator) {
+		if tag == "" {
+			// split returns empty for empty tagLabelVal, skip it.
+			continue
+		}
+		kvs := strings.SplitN(tag, keyValSeperator, 2)
+		t := Tag{Key: kvs[0]}
+		if len(kvs) == 2 {
+			t.Value = kvs[1]
+		}
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// validateARN checks arn looks like an ARN, such as 'arn:aws:s3:::bucket' or
+// 'arn:minio:replication:us-east-1:c5be6b16-769d-432a-9ef1-4567081f3566:bucket'.
+func validateARN(arn string) error {
+	fields := strings.Split(arn, ":")
+	if len(fields) < 6 || fields[0] != "arn" || fields[1] == "" || fields[2] == "" {
+		return fmt.Errorf("invalid ARN `%s`", arn)
+	}
+	if fields[len(fields)-1] == "" {
+		return fmt.Errorf("ARN `%s` does not contain a bucket name", arn)
+	}
+	return nil
+}
+
+// Check S3 compatibility for the rule.
+func validateRule(rule Rule) *probe.Error {
+	if rule.ID == "" {
+		return probe.NewError(errors.New("rule ID cannot be empty"))
+	}
+	if len(rule.ID) > maxRuleIDLength {
+		return probe.NewError(fmt.Errorf("rule ID `%s` is longer than %d characters", rule.ID, maxRuleIDLength))
+	}
+	if rule.Status != Enabled && rule.Status != Disabled {
+		return probe.NewError(fmt.Errorf("rule `%s` has an invalid status `%s`", rule.ID, rule.Status))
+	}
+	if rule.Priority < 0 {
+		return probe.NewError(fmt.Errorf("rule `%s` has a negative priority", rule.ID))
+	}
+	if rule.DeleteMarkerReplication != nil {
+		if s := rule.DeleteMarkerReplication.Status; s != Enabled && s != Disabled {
+			return probe.NewError(fmt.Errorf("rule `%s` has an invalid delete marker replication status `%s`", rule.ID, s))
+		}
+	}
+	if rule.Filter.And != nil && rule.Filter.Tag != nil {
+		return probe.NewError(fmt.Errorf("rule `%s` filter cannot have both a tag and an And operator", rule.ID))
+	}
+	for _, tag := range rule.Tags() {
+		if tag.Key == "" {
+			return probe.NewError(fmt.Errorf("rule `%s` has a tag with an empty key", rule.ID))
+		}
+	}
+	if e := validateARN(rule.Destination.Bucket); e != nil {
+		return probe.NewError(fmt.Errorf("rule `%s` destination: %v", rule.ID, e))
+	}
+	return nil
+}
+
+// Validate checks every rule of the configuration along with the
+// uniqueness of their IDs and priorities.
+func Validate(cfg Config) *probe.Error {
+	if cfg.Role != "" {
+		if e := validateARN(cfg.Role); e != nil {
+			return probe.NewError(fmt.Errorf("role: %v", e))
+		}
+	}
+	ids := make(map[string]struct{}, len(cfg.Rules))
+	priorities := make(map[int]string, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		if err := validateRule(rule); err != nil {
+			return err.Trace(rule.ID)
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return probe.NewError(fmt.Errorf("rule ID `%s` is not unique", rule.ID))
+		}
+		ids[rule.ID] = struct{}{}
+		if id, ok := priorities[rule.Priority]; ok {
+			return probe.NewError(fmt.Errorf("rules `%s` and `%s` have the same priority %d", id, rule.ID, rule.Priority))
+		}
+		priorities[rule.Priority] = rule.ID
+	}
+	return nil
+}
+
+// ParseXML parses the XML replication configuration returned by the server.
+func ParseXML(r io.Reader) (Config, *probe.Error) {
+	cfg := Config{}
+	if e := xml.NewDecoder(r).Decode(&cfg); e != nil && e != io.EOF {
+		return cfg, probe.NewError(e)
+	}
+	return cfg, nil
+}
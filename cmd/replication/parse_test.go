@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"strings"
+	"testing"
+)
+
+const testARN = "arn:minio:replication:us-east-1:c5be6b16-769d-432a-9ef1-4567081f3566:destbucket"
+
+func TestParseXML(t *testing.T) {
+	cfg, err := ParseXML(strings.NewReader(`<ReplicationConfiguration>
+  <Role>arn:aws:iam::123456789012:role/replication</Role>
+  <Rule>
+    <ID>docs</ID>
+    <Status>Enabled</Status>
+    <Priority>1</Priority>
+    <DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>
+    <Destination><Bucket>arn:aws:s3:::destbucket</Bucket></Destination>
+    <Filter><And><Prefix>docs/</Prefix><Tag><Key>project</Key><Value>apollo</Value></Tag></And></Filter>
+  </Rule>
+</ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(cfg.Rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(cfg.Rules))
+	}
+	rule := cfg.Rules[0]
+	if rule.ID != "docs" || rule.Prefix() != "docs/" || len(rule.Tags()) != 1 || rule.Tags()[0].Value != "apollo" {
+		t.Fatalf("Unexpected rule %+v", rule)
+	}
+	if err = Validate(cfg); err != nil {
+		t.Fatalf("Unexpected validation error: %s", err)
+	}
+}
+
+func TestToConfig(t *testing.T) {
+	testCases := []struct {
+		opts        replicationOptions
+		shouldPass  bool
+		expectRules int
+	}{
+		// Valid rule.
+		{replicationOptions{ID: "one", Arn: testARN, Priority: 1, Status: true}, true, 2},
+		// Existing rule is replaced.
+		{replicationOptions{ID: "existing", Arn: testARN, Priority: 1, Status: true}, true, 1},
+		// Missing ID.
+		{replicationOptions{Arn: testARN, Status: true}, false, 1},
+		// Invalid ARN.
+		{replicationOptions{ID: "one", Arn: "destbucket", Status: true}, false, 1},
+		// Duplicate priority.
+		{replicationOptions{ID: "one", Arn: testARN, Priority: 5, Status: true}, false, 1},
+	}
+
+	for i, testCase := range testCases {
+		cfg := Config{Rules: []Rule{{
+			ID:          "existing",
+			Status:      Enabled,
+			Priority:    5,
+			Destination: Destination{Bucket: testARN},
+		}}}
+		cfg, err := testCase.opts.ToConfig(cfg)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if testCase.shouldPass && len(cfg.Rules) != testCase.expectRules {
+			t.Fatalf("Test %d: expected %d rules, got %d", i+1, testCase.expectRules, len(cfg.Rules))
+		}
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	cfg := Config{Rules: []Rule{{ID: "one"}, {ID: "two"}}}
+	cfg, err := RemoveRule(cfg, "one")
+	if err != nil || len(cfg.Rules) != 1 || cfg.Rules[0].ID != "two" {
+		t.Fatalf("Test 1: unexpected result %+v, %v", cfg.Rules, err)
+	}
+	if _, err = RemoveRule(cfg, "three"); err == nil {
+		t.Fatalf("Test 2: expected an error for an unknown rule")
+	}
+}
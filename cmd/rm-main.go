@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -70,6 +71,18 @@ var (
 			Name:  bypass,
 			Usage: "bypass governance",
 		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "remove a specific object version",
+		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "remove all object versions and delete markers",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "with --versions, remove only versions created before a given time or duration",
+		},
 	}
 )
 
@@ -79,7 +92,7 @@ var rmCmd = cli.Command{
 	Usage:  "remove objects",
 	Action: mainRm,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(rmFlags, filterFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -125,18 +138,34 @@ EXAMPLES:
 
   11. Bypass object retention in governance mode and delete the object.
       {{.Prompt}} {{.HelpName}} --bypass s3/pop-songs/
+
+  12. Remove a specific version of an object.
+      {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" s3/jazz-songs/louis/file.mp3
+
+  13. Remove all versions and delete markers of all objects with the prefix 'louis'.
+      {{.Prompt}} {{.HelpName}} --versions --recursive --force s3/jazz-songs/louis/
+
+  14. Remove all versions of an object created more than 30 days ago.
+      {{.Prompt}} {{.HelpName}} --versions --rewind 30d s3/jazz-songs/louis/file.mp3
+
+  15. Remove all objects recursively from bucket 'jazz-songs' except the ones with a '.flac' extension.
+      {{.Prompt}} {{.HelpName}} --recursive --force --exclude "*.flac" s3/jazz-songs/
 `,
 }
 
 // Structured message depending on the type of console.
 type rmMessage struct {
-	Status string `json:"status"`
-	Key    string `json:"key"`
-	Size   int64  `json:"size"`
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"versionId,omitempty"`
 }
 
 // Colorized message for console printing.
 func (r rmMessage) String() string {
+	if r.VersionID != "" {
+		return console.Colorize("Remove", fmt.Sprintf("Removing `%s` (versionId=%s).", r.Key, r.VersionID))
+	}
 	return console.Colorize("Remove", fmt.Sprintf("Removing `%s`.", r.Key))
 }
 
@@ -157,6 +186,19 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	isDangerous := cliCtx.Bool("dangerous")
 	isNamespaceRemoval := false
 
+	versionID := cliCtx.String("version-id")
+	withVersions := cliCtx.Bool("versions")
+	if versionID != "" && (withVersions || isRecursive || isStdin || len(cliCtx.Args()) != 1) {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...),
+			"Flag `--version-id` requires a single target and cannot be used with `--versions`, `--recursive` or `--stdin`.")
+	}
+	if cliCtx.String("rewind") != "" && !withVersions {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Flag `--rewind` requires `--versions`.")
+	}
+	if (versionID != "" || withVersions) && cliCtx.Bool("incomplete") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Incomplete uploads are not versioned.")
+	}
+
 	for _, url := range cliCtx.Args() {
 		// clean path for aliases like s3/.
 		// Note: UNC path using / works properly in go 1.9.2 even though it breaks the UNC specification.
@@ -202,7 +244,7 @@ func removeSingle(url string, isIncomplete, isFake, isForce, isBypass bool, olde
 	defer cancelRemoveSingle()
 
 	isRecursive := false
-	contents, pErr := statURL(ctx, url, isIncomplete, isRecursive, encKeyDB)
+	contents, pErr := statURL(ctx, url, "", time.Time{}, false, isIncomplete, isRecursive, encKeyDB)
 	if pErr != nil {
 		errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
 		return exitStatus(globalErrorExitStatus)
@@ -263,7 +305,7 @@ func removeSingle(url string, isIncomplete, isFake, isForce, isBypass bool, olde
 	return nil
 }
 
-func removeRecursive(url string, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeRecursive(url string, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, filter *listFilter, encKeyDB map[string][]prefixSSEPair) error {
 	ctx, cancelRemoveRecursive := context.WithCancel(globalContext)
 	defer cancelRemoveRecursive()
 
@@ -279,7 +321,8 @@ func removeRecursive(url string, isIncomplete, isFake, isBypass bool, olderThan,
 	errorCh := clnt.Remove(ctx, isIncomplete, isRemoveBucket, isBypass, contentCh)
 
 	isRecursive := true
-	for content := range clnt.List(ctx, isRecursive, isIncomplete, false, DirNone) {
+	listCh := filterListing(ctx, filter, clnt.GetURL(), clnt.List(ctx, isRecursive, isIncomplete, false, DirNone))
+	for content := range listCh {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(url), "Failed to remove `"+url+"` recursively.")
 			switch content.Err.ToGoError().(type) {
@@ -346,6 +389,107 @@ func removeRecursive(url string, isIncomplete, isFake, isBypass bool, olderThan,
 	return nil
 }
 
+// removeVersions removes either the given version of url or, when versionID
+// is empty, all versions and delete markers of url created before timeRef.
+func removeVersions(url, versionID string, timeRef time.Time, isRecursive, isFake, isBypass bool, olderThan, newerThan string, filter *listFilter) error {
+	ctx, cancelRemoveVersions := context.WithCancel(globalContext)
+	defer cancelRemoveVersions()
+
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(url), "Failed to remove versions of `"+url+"`.")
+		return exitStatus(globalErrorExitStatus) // End of journey.
+	}
+
+	var versionsCh <-chan *ClientContent
+	if versionID != "" {
+		singleCh := make(chan *ClientContent, 1)
+		singleCh <- &ClientContent{URL: clnt.GetURL(), VersionID: versionID}
+		close(singleCh)
+		versionsCh = singleCh
+	} else {
+		withOlderVersions := true
+		versionsCh = filterListing(ctx, filter, clnt.GetURL(), clnt.ListVersions(ctx, isRecursive, timeRef, withOlderVersions))
+	}
+
+	contentCh := make(chan *ClientContent)
+	errorCh := clnt.RemoveVersions(ctx, isBypass, contentCh)
+
+	for content := range versionsCh {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(url), "Failed to remove versions of `"+url+"`.")
+			switch content.Err.ToGoError().(type) {
+			case PathInsufficientPermission:
+				// Ignore Permission error.
+				continue
+			}
+			close(contentCh)
+			return exitStatus(globalErrorExitStatus)
+		}
+
+		if content.Type.IsDir() {
+			// Skip prefix levels.
+			continue
+		}
+
+		// Without --recursive only the versions of the exact key qualify.
+		if !isRecursive && content.URL.Path != clnt.GetURL().Path {
+			continue
+		}
+
+		if !content.Time.IsZero() {
+			// Skip versions older than --older-than parameter, if specified
+			if olderThan != "" && isOlder(content.Time, olderThan) {
+				continue
+			}
+
+			// Skip versions newer than --newer-than parameter if specified
+			if newerThan != "" && isNewer(content.Time, newerThan) {
+				continue
+			}
+		}
+
+		printMsg(rmMessage{
+			Key:       targetAlias + content.URL.Path,
+			Size:      content.Size,
+			VersionID: content.VersionID,
+		})
+
+		if !isFake {
+			sent := false
+			for !sent {
+				select {
+				case contentCh <- content:
+					sent = true
+				case pErr := <-errorCh:
+					errorIf(pErr.Trace(content.URL.Path), "Failed to remove `"+content.URL.Path+"`.")
+					switch pErr.ToGoError().(type) {
+					case PathInsufficientPermission:
+						// Ignore Permission error.
+						continue
+					}
+					close(contentCh)
+					return exitStatus(globalErrorExitStatus)
+				}
+			}
+		}
+	}
+
+	close(contentCh)
+	for pErr := range errorCh {
+		errorIf(pErr.Trace(url), "Failed to remove versions of `"+url+"`.")
+		switch pErr.ToGoError().(type) {
+		case PathInsufficientPermission:
+			// Ignore Permission error.
+			continue
+		}
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	return nil
+}
+
 // main for rm command.
 func mainRm(cliCtx *cli.Context) error {
 	ctx, cancelRm := context.WithCancel(globalContext)
@@ -367,6 +511,13 @@ func mainRm(cliCtx *cli.Context) error {
 	olderThan := cliCtx.String("older-than")
 	newerThan := cliCtx.String("newer-than")
 	isForce := cliCtx.Bool("force")
+	versionID := cliCtx.String("version-id")
+	withVersions := cliCtx.Bool("versions")
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+
+	// Filter the objects listed by recursive removals.
+	filter, err := getListFilter(cliCtx)
+	fatalIf(err, "Unable to parse the filter options.")
 
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
@@ -375,8 +526,10 @@ func mainRm(cliCtx *cli.Context) error {
 	var e error
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+		if versionID != "" || withVersions {
+			e = removeVersions(url, versionID, timeRef, isRecursive, isFake, isBypass, olderThan, newerThan, filter)
+		} else if isRecursive {
+			e = removeRecursive(url, isIncomplete, isFake, isBypass, olderThan, newerThan, filter, encKeyDB)
 		} else {
 			e = removeSingle(url, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
 		}
@@ -393,8 +546,10 @@ func mainRm(cliCtx *cli.Context) error {
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		url := scanner.Text()
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+		if withVersions {
+			e = removeVersions(url, "", timeRef, isRecursive, isFake, isBypass, olderThan, newerThan, filter)
+		} else if isRecursive {
+			e = removeRecursive(url, isIncomplete, isFake, isBypass, olderThan, newerThan, filter, encKeyDB)
 		} else {
 			e = removeSingle(url, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
 		}
@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "clear an interrupted session",
+	Action: mainSessionClear,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID|all
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Clear the session 'cp-9f86d081...'.
+     {{.Prompt}} {{.HelpName}} cp-9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+
+  2. Clear all saved sessions.
+     {{.Prompt}} {{.HelpName}} all
+`,
+}
+
+// clearSessionMessage container for clearing a session.
+type clearSessionMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+}
+
+// String colorized clear session message.
+func (c clearSessionMessage) String() string {
+	return console.Colorize("SessionID", "Session `"+c.SessionID+"` cleared successfully.")
+}
+
+// JSON jsonified clear session message.
+func (c clearSessionMessage) JSON() string {
+	c.Status = "success"
+	clearSessionBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(clearSessionBytes)
+}
+
+// removeSessionFiles removes the files of a session which cannot be loaded.
+func removeSessionFiles(sid string) *probe.Error {
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	if e := os.Remove(sessionFile); e != nil {
+		return probe.NewError(e)
+	}
+	os.Remove(sessionFile + ".old")
+	if sessionDataFile, err := getSessionDataFile(sid); err == nil {
+		os.Remove(sessionDataFile)
+	}
+	if sessionIndexFile, err := getSessionIndexFile(sid); err == nil {
+		os.Remove(sessionIndexFile)
+	}
+	return nil
+}
+
+// clearSession removes all the files of a session.
+func clearSession(sid string) {
+	if !isSessionExists(sid) {
+		fatalIf(errDummy().Trace(sid), "Session `"+sid+"` not found.")
+	}
+
+	session, err := loadSessionV8(sid)
+	if err != nil {
+		// Remove whatever is left of a corrupted session.
+		fatalIf(removeSessionFiles(sid).Trace(sid), "Unable to remove session `"+sid+"`.")
+	} else {
+		fatalIf(session.Delete().Trace(sid), "Unable to remove session `"+sid+"`.")
+	}
+	printMsg(clearSessionMessage{SessionID: sid})
+}
+
+// checkSessionClearSyntax - validate arguments passed by user
+func checkSessionClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", globalErrorExitStatus)
+	}
+}
+
+func mainSessionClear(cliCtx *cli.Context) error {
+	checkSessionClearSyntax(cliCtx)
+	setSessionDisplayColorScheme()
+
+	sid := cliCtx.Args().Get(0)
+	if sid == "all" {
+		if !isSessionDirExists() {
+			return nil
+		}
+		for _, sid := range getSessionIDs() {
+			clearSession(sid)
+		}
+		return nil
+	}
+	clearSession(sid)
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list all saved sessions",
+	Action: mainSessionList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  List the sessions of interrupted copies, moves and mirrors. The progress shows bytes and
+  object(s) done versus the total known so far.
+
+EXAMPLES:
+  1. List all saved sessions with their progress.
+     {{.Prompt}} {{.HelpName}}
+
+  2. List all saved sessions in JSON format.
+     {{.Prompt}} {{.HelpName}} --json
+`,
+}
+
+// sessionListMessage container for a saved session and its progress.
+type sessionListMessage struct {
+	Status       string    `json:"status"`
+	SessionID    string    `json:"sessionId"`
+	Time         time.Time `json:"time"`
+	CommandType  string    `json:"commandType"`
+	CommandArgs  []string  `json:"commandArgs"`
+	DoneBytes    int64     `json:"doneBytes"`
+	DoneObjects  int64     `json:"doneObjects"`
+	TotalBytes   int64     `json:"totalBytes"`
+	TotalObjects int64     `json:"totalObjects"`
+}
+
+// String colorized session list message.
+func (s sessionListMessage) String() string {
+	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", s.SessionID))
+	message += console.Colorize("SessionTime", fmt.Sprintf("[%s]", s.Time.Local().Format(printDate)))
+	message += console.Colorize("Command", fmt.Sprintf(" %s %s", s.CommandType, strings.Join(s.CommandArgs, " ")))
+	message += console.Colorize("SessionProgress", fmt.Sprintf(" (%s / %s, %d / %d object(s))",
+		humanize.IBytes(uint64(s.DoneBytes)), humanize.IBytes(uint64(s.TotalBytes)), s.DoneObjects, s.TotalObjects))
+	return message
+}
+
+// JSON jsonified session list message.
+func (s sessionListMessage) JSON() string {
+	s.Status = "success"
+	sessionBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(sessionBytes)
+}
+
+// checkSessionListSyntax - validate arguments passed by user
+func checkSessionListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", globalErrorExitStatus)
+	}
+}
+
+func mainSessionList(cliCtx *cli.Context) error {
+	checkSessionListSyntax(cliCtx)
+	setSessionDisplayColorScheme()
+
+	if !isSessionDirExists() {
+		return nil
+	}
+
+	var messages []sessionListMessage
+	for _, sid := range getSessionIDs() {
+		session, err := loadSessionV8(sid)
+		if err != nil {
+			errorIf(err.Trace(sid), "Unable to load session `"+sid+"`.")
+			continue
+		}
+		messages = append(messages, sessionListMessage{
+			SessionID:    session.SessionID,
+			Time:         session.Header.When,
+			CommandType:  session.Header.CommandType,
+			CommandArgs:  session.Header.CommandArgs,
+			DoneBytes:    session.Header.DoneBytes,
+			DoneObjects:  session.Header.DoneObjects,
+			TotalBytes:   session.Header.TotalBytes,
+			TotalObjects: session.Header.TotalObjects,
+		})
+		session.DataFP.Close()
+	}
+
+	// Oldest sessions first.
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Time.Before(messages[j].Time)
+	})
+	for _, message := range messages {
+		printMsg(message)
+	}
+	return nil
+}
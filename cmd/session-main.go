@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionCmd = cli.Command{
+	Name:            "session",
+	Usage:           "list, resume and clear interrupted operations",
+	Action:          mainSession,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		sessionListCmd,
+		sessionResumeCmd,
+		sessionClearCmd,
+	},
+}
+
+func mainSession(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+}
+
+// Color scheme for the session commands.
+func setSessionDisplayColorScheme() {
+	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
+	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SessionTime", color.New(color.FgGreen))
+	console.SetColor("SessionProgress", color.New(color.FgCyan))
+}
+
+// loadSessionForResume loads the session and restores the state it was
+// started with.
+func loadSessionForResume(sid string) *sessionV8 {
+	if !isSessionExists(sid) {
+		fatalIf(errDummy().Trace(sid), "Session `"+sid+"` not found.")
+	}
+
+	session, err := loadSessionV8(sid)
+	fatalIf(err.Trace(sid), "Unable to load session `"+sid+"`.")
+
+	session.restoreGlobals()
+	if session.Header.RootPath != "" {
+		if e := os.Chdir(session.Header.RootPath); e != nil {
+			session.Close()
+			fatalIf(probe.NewError(e).Trace(session.Header.RootPath),
+				"Unable to change to the working folder of session `"+sid+"`.")
+		}
+	}
+	return session
+}
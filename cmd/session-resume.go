@@ -0,0 +1,126 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionResumeCmd = cli.Command{
+	Name:   "resume",
+	Usage:  "resume an interrupted session",
+	Action: mainSessionResume,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Resume a copy or move started with '--continue', or a mirror started with '--session'.
+  Object(s) already transferred are skipped, the global and command flags of the original
+  command are restored.
+
+EXAMPLES:
+  1. Resume the mirror session 'mirror-2d1b9e5d...'.
+     {{.Prompt}} {{.HelpName}} mirror-2d1b9e5d1e4f5a4c1e43f8bc0b1c5df2e3a0d4f6c1c8e9b0a7f6e5d4c3b2a190
+
+  2. Resume the copy session 'cp-9f86d081...' in JSON format.
+     {{.Prompt}} {{.HelpName}} --json cp-9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+`,
+}
+
+// checkSessionResumeSyntax - validate arguments passed by user
+func checkSessionResumeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "resume", globalErrorExitStatus)
+	}
+}
+
+// resumeCopySession resumes a cp or mv session.
+func resumeCopySession(cliCtx *cli.Context, session *sessionV8) error {
+	ctx, cancelCopy := context.WithCancel(globalContext)
+	defer cancelCopy()
+
+	flags := sessionFlagValues{session.Header}
+	encKeyDB, err := parseAndValidateEncryptionKeys(flags.String("encrypt-key"), flags.String("encrypt"))
+	fatalIf(err.Trace(session.SessionID), "Unable to parse encryption keys.")
+
+	filter, err := newListFilter(flags.StringSlice("include"), flags.StringSlice("exclude"),
+		flags.StringSlice("include-regex"), flags.String("exclude-from"))
+	fatalIf(err.Trace(session.SessionID), "Unable to parse the filter options.")
+
+	symlinks, err := getSymlinkPolicy(flags)
+	fatalIf(err.Trace(session.SessionID), "Unable to parse the symlink options.")
+	var targetURL string
+	if args := session.Header.CommandArgs; len(args) > 0 {
+		targetURL = args[len(args)-1]
+	}
+
+	// Additional command specific theme customization.
+	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+
+	isMvCmd := session.Header.CommandType == "mv"
+	e := doCopySession(withSymlinkPolicy(ctx, symlinks, targetURL), cancelCopy, cliCtx, session, filter, encKeyDB, isMvCmd)
+	session.Delete()
+
+	if isMvCmd {
+		// Wait for the removal of the moved objects.
+		rmManager.close()
+	}
+	return e
+}
+
+// resumeMirrorSession resumes a mirror session.
+func resumeMirrorSession(session *sessionV8) error {
+	ctx, cancelMirror := context.WithCancel(globalContext)
+	defer cancelMirror()
+
+	// Additional command specific theme customization.
+	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+
+	if errorDetected := runMirrorSession(ctx, cancelMirror, session); errorDetected {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+func mainSessionResume(cliCtx *cli.Context) error {
+	checkSessionResumeSyntax(cliCtx)
+
+	sid := cliCtx.Args().Get(0)
+	session := loadSessionForResume(sid)
+	switch session.Header.CommandType {
+	case "cp", "mv":
+		return resumeCopySession(cliCtx, session)
+	case "mirror":
+		return resumeMirrorSession(session)
+	}
+	session.Close()
+	fatalIf(errInvalidArgument().Trace(sid, session.Header.CommandType),
+		"Session `"+sid+"` of command `"+session.Header.CommandType+"` cannot be resumed.")
+	return nil
+}
@@ -50,6 +50,8 @@ type sessionV8Header struct {
 	LastRemoved        string            `json:"lastRemoved"`
 	TotalBytes         int64             `json:"totalBytes"`
 	TotalObjects       int64             `json:"totalObjects"`
+	DoneBytes          int64             `json:"doneBytes,omitempty"`
+	DoneObjects        int64             `json:"doneObjects,omitempty"`
 	UserMetaData       map[string]string `json:"metaData"`
 }
 
@@ -251,6 +253,15 @@ func (s *sessionV8) setGlobals() {
 	s.Header.GlobalBoolFlags["json"] = globalJSON
 	s.Header.GlobalBoolFlags["noColor"] = globalNoColor
 	s.Header.GlobalBoolFlags["insecure"] = globalInsecure
+	s.Header.GlobalBoolFlags["archive"] = globalArchive
+}
+
+// restoreGlobals restores the state of global variables captured in
+// session header. Used by session resume.
+func (s *sessionV8) restoreGlobals() {
+	setGlobals(s.Header.GlobalBoolFlags["quiet"], s.Header.GlobalBoolFlags["debug"],
+		s.Header.GlobalBoolFlags["json"], s.Header.GlobalBoolFlags["noColor"],
+		s.Header.GlobalBoolFlags["insecure"], s.Header.GlobalBoolFlags["archive"])
 }
 
 // IsModified - returns if in memory session header has changed from
@@ -355,6 +366,11 @@ func (s *sessionV8) Delete() *probe.Error {
 	// Remove session backup file if any, ignore any error.
 	os.Remove(sessionFile + ".old")
 
+	// Remove session index file if any, ignore any error.
+	if sessionIndexFile, err := getSessionIndexFile(s.SessionID); err == nil {
+		os.Remove(sessionIndexFile)
+	}
+
 	return nil
 }
 
@@ -107,6 +107,18 @@ func getSessionDataFile(sid string) (string, *probe.Error) {
 	return sessionDataFile, nil
 }
 
+// getSessionIndexFile - get the index file of already mirrored objects
+// for a given session.
+func getSessionIndexFile(sid string) (string, *probe.Error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+
+	sessionIndexFile := filepath.Join(sessionDir, sid+".index")
+	return sessionIndexFile, nil
+}
+
 // getSessionIDs - get all active sessions.
 func getSessionIDs() (sids []string) {
 	sessionDir, err := getSessionDir()
@@ -121,6 +133,40 @@ func getSessionIDs() (sids []string) {
 	return sids
 }
 
+// flagValues provides the flags of a command, either from the command
+// line or from a saved session.
+type flagValues interface {
+	Bool(name string) bool
+	Int(name string) int
+	String(name string) string
+	StringSlice(name string) []string
+}
+
+// sessionFlagValues provides the command flags saved in a session
+// header. Multiple values of a flag are saved separated by newlines.
+type sessionFlagValues struct {
+	header *sessionV8Header
+}
+
+func (f sessionFlagValues) Bool(name string) bool {
+	return f.header.CommandBoolFlags[name]
+}
+
+func (f sessionFlagValues) Int(name string) int {
+	return f.header.CommandIntFlags[name]
+}
+
+func (f sessionFlagValues) String(name string) string {
+	return f.header.CommandStringFlags[name]
+}
+
+func (f sessionFlagValues) StringSlice(name string) []string {
+	if value := f.header.CommandStringFlags[name]; value != "" {
+		return strings.Split(value, "\n")
+	}
+	return nil
+}
+
 func getHash(prefix string, args []string) string {
 	hasher := sha256.New()
 	for _, arg := range args {
@@ -58,3 +58,18 @@ func (s *TestSuite) TestSession(c *C) {
 	_, e = os.Stat(session.DataFP.Name())
 	c.Assert(e, NotNil)
 }
+
+func (s *TestSuite) TestSessionFlagValues(c *C) {
+	session := newSessionV8(getHash("cp", []string{"mybucket", "myminio/mybucket"}))
+	defer session.Delete()
+
+	session.Header.CommandBoolFlags["recursive"] = true
+	session.Header.CommandIntFlags["max-workers"] = 16
+	session.Header.CommandStringFlags["include"] = "*.txt\n*.csv"
+
+	var flags flagValues = sessionFlagValues{session.Header}
+	c.Assert(flags.Bool("recursive"), Equals, true)
+	c.Assert(flags.Int("max-workers"), Equals, 16)
+	c.Assert(flags.StringSlice("include"), DeepEquals, []string{"*.txt", "*.csv"})
+	c.Assert(flags.StringSlice("exclude"), IsNil)
+}
@@ -32,6 +32,18 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.StringFlag{
+			Name:  "version-id, vid",
+			Usage: "stat a specific object version",
+		},
+		cli.StringFlag{
+			Name:  "rewind",
+			Usage: "stat objects as they were at a given time or duration ago",
+		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "stat all object versions",
+		},
 	}
 )
 
@@ -70,6 +82,12 @@ EXAMPLES:
   5. Stat encrypted files on Amazon S3 cloud storage. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      {{.Prompt}} {{.HelpName}} --encrypt-key "s3/personal-document/=MzJieXRlc2xvbmdzZWNyZWFiY2RlZmcJZ2l2ZW5uMjE=" s3/personal-document/2019-account_report.docx
+
+  6. Stat a specific object version.
+     {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" s3/personal-docs/2018-account_report.docx
+
+  7. Stat all versions of an object.
+     {{.Prompt}} {{.HelpName}} --versions s3/personal-docs/2018-account_report.docx
 `,
 }
 
@@ -85,12 +103,23 @@ func checkStatSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 			fatalIf(errInvalidArgument().Trace(args...), "Unable to validate empty argument.")
 		}
 	}
+	versionID := cliCtx.String("version-id")
+	if versionID != "" {
+		if len(args) != 1 || cliCtx.Bool("recursive") {
+			fatalIf(errInvalidArgument().Trace(args...), "Flag `--version-id` requires a single non recursive target.")
+		}
+		if cliCtx.String("rewind") != "" || cliCtx.Bool("versions") {
+			fatalIf(errInvalidArgument().Trace(args...), "Flag `--version-id` cannot be used with `--rewind` or `--versions`.")
+		}
+	}
+
 	// extract URLs.
 	URLs := cliCtx.Args()
 	isIncomplete := false
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
 
 	for _, url := range URLs {
-		_, _, err := url2Stat(ctx, url, false, encKeyDB)
+		_, _, err := url2StatWithVersion(ctx, url, versionID, timeRef, false, encKeyDB)
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
 			fatalIf(err.Trace(url), "Unable to stat `"+url+"`.")
 		}
@@ -118,6 +147,9 @@ func mainStat(cliCtx *cli.Context) error {
 
 	// Set command flags from context.
 	isRecursive := cliCtx.Bool("recursive")
+	versionID := cliCtx.String("version-id")
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+	withOlderVersions := cliCtx.Bool("versions")
 
 	args := cliCtx.Args()
 	// mimic operating system tool behavior.
@@ -127,7 +159,7 @@ func mainStat(cliCtx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		stats, err := statURL(ctx, targetURL, false, isRecursive, encKeyDB)
+		stats, err := statURL(ctx, targetURL, versionID, timeRef, withOlderVersions, false, isRecursive, encKeyDB)
 		if err != nil {
 			fatalIf(err, "Unable to stat `"+targetURL+"`.")
 		}
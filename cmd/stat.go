@@ -39,6 +39,10 @@ type statMessage struct {
 	Type     string            `json:"type"`
 	Expires  time.Time         `json:"expires"`
 	Metadata map[string]string `json:"metadata"`
+
+	VersionID         string `json:"versionId,omitempty"`
+	IsDeleteMarker    bool   `json:"isDeleteMarker,omitempty"`
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
 }
 
 // String colorized string message.
@@ -51,7 +55,16 @@ func printStat(stat statMessage) {
 	if stat.ETag != "" {
 		console.Println(fmt.Sprintf("%-10s: %s ", "ETag", stat.ETag))
 	}
+	if stat.VersionID != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "VersionID", stat.VersionID))
+	}
+	if stat.IsDeleteMarker {
+		console.Println(fmt.Sprintf("%-10s: %t ", "DeleteMark", stat.IsDeleteMarker))
+	}
 	console.Println(fmt.Sprintf("%-10s: %s ", "Type", stat.Type))
+	if stat.ReplicationStatus != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "Replicated", stat.ReplicationStatus))
+	}
 	if !stat.Expires.IsZero() {
 		console.Println(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)))
 	}
@@ -119,6 +132,9 @@ func parseStat(c *ClientContent) statMessage {
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
 	content.ETag = strings.TrimSuffix(content.ETag, "\"")
 	content.Expires = c.Expires
+	content.VersionID = c.VersionID
+	content.IsDeleteMarker = c.IsDeleteMarker
+	content.ReplicationStatus = c.ReplicationStatus
 	return content
 }
 
@@ -127,8 +143,9 @@ func getStandardizedURL(targetURL string) string {
 	return filepath.FromSlash(targetURL)
 }
 
-// statURL - simple or recursive listing
-func statURL(ctx context.Context, targetURL string, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, *probe.Error) {
+// statURL - simple or recursive listing, versionID, timeRef and
+// withOlderVersions select object versions instead of objects.
+func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, withOlderVersions, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, *probe.Error) {
 	var stats []*ClientContent
 	var clnt Client
 	clnt, err := newClient(targetURL)
@@ -136,6 +153,18 @@ func statURL(ctx context.Context, targetURL string, isIncomplete, isRecursive bo
 		return nil, err
 	}
 
+	if versionID != "" {
+		alias, _ := url2Alias(targetURL)
+		stat, err := clnt.StatVersion(ctx, versionID, getSSE(targetURL, encKeyDB[alias]))
+		if err != nil {
+			return nil, err.Trace(targetURL, versionID)
+		}
+		stat.URL.Path = filepath.ToSlash(filepath.Base(stat.URL.Path))
+		return append(stats, stat), nil
+	}
+
+	isVersioned := withOlderVersions || !timeRef.IsZero()
+
 	targetAlias, _, _ := mustExpandAlias(targetURL)
 
 	prefixPath := clnt.GetURL().Path
@@ -143,8 +172,15 @@ func statURL(ctx context.Context, targetURL string, isIncomplete, isRecursive bo
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
+	var contentCh <-chan *ClientContent
+	if isVersioned {
+		contentCh = clnt.ListVersions(ctx, isRecursive, timeRef, withOlderVersions)
+	} else {
+		contentCh = clnt.List(ctx, isRecursive, isIncomplete, false, DirNone)
+	}
+
 	var cErr error
-	for content := range clnt.List(ctx, isRecursive, isIncomplete, false, DirNone) {
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -177,7 +213,17 @@ func statURL(ctx context.Context, targetURL string, isIncomplete, isRecursive bo
 			return nil, errTargetNotFound(targetURL).Trace(url, standardizedURL)
 		}
 
-		_, stat, err := url2Stat(ctx, url, true, encKeyDB)
+		var stat *ClientContent
+		var err *probe.Error
+		switch {
+		case content.IsDeleteMarker:
+			// Delete markers have no metadata to show.
+			stat = content
+		case isVersioned:
+			_, stat, err = url2StatWithVersion(ctx, url, content.VersionID, time.Time{}, true, encKeyDB)
+		default:
+			_, stat, err = url2Stat(ctx, url, true, encKeyDB)
+		}
 		if err != nil {
 			stat = content
 		}
@@ -50,9 +50,9 @@ type Status interface {
 }
 
 // NewQuietStatus returns a quiet status object
-func NewQuietStatus(hook io.Reader) Status {
+func NewQuietStatus(hook io.Reader, limits bandwidthLimits) Status {
 	return &QuietStatus{
-		accounter: newAccounter(0),
+		accounter: newAccounter(0).SetLimits(limits),
 		hook:      hook,
 	}
 }
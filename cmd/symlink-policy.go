@@ -0,0 +1,254 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Symlink flags shared by all commands listing local folders.
+var symlinkFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "follow-symlinks",
+		Usage: "follow symlinks, including links to folders",
+	},
+	cli.BoolFlag{
+		Name:  "skip-symlinks",
+		Usage: "skip symlinks",
+	},
+	cli.BoolFlag{
+		Name:  "preserve-symlinks",
+		Usage: "store the target of symlinks in object metadata and recreate them on local targets",
+	},
+}
+
+// symlinkPolicy tells how local listings handle symlinks.
+type symlinkPolicy int
+
+const (
+	// Links to files are followed, links to folders are listed but
+	// never descended.
+	symlinkDefault symlinkPolicy = iota
+	// Links to files and folders are followed.
+	symlinkFollow
+	// Links are not listed.
+	symlinkSkip
+	// Links are listed as empty objects holding their target.
+	symlinkPreserve
+)
+
+// getSymlinkPolicy returns the policy requested by the symlink flags.
+func getSymlinkPolicy(flags flagValues) (symlinkPolicy, *probe.Error) {
+	policy, policyFlag := symlinkDefault, ""
+	// The flags are checked in a fixed order so that a conflict is
+	// always reported the same way.
+	for _, f := range []struct {
+		name   string
+		policy symlinkPolicy
+	}{
+		{"follow-symlinks", symlinkFollow},
+		{"skip-symlinks", symlinkSkip},
+		{"preserve-symlinks", symlinkPreserve},
+	} {
+		if !flags.Bool(f.name) {
+			continue
+		}
+		if policy != symlinkDefault {
+			return symlinkDefault, errInvalidArgument().Trace(policyFlag, f.name)
+		}
+		policy, policyFlag = f.policy, f.name
+	}
+	return policy, nil
+}
+
+type symlinkPolicyKey struct{}
+
+// symlinkScope is the symlink policy of a command. Preserved symlinks
+// are only restored below root, the local target of the command, and
+// the links restored are never written through.
+type symlinkScope struct {
+	policy symlinkPolicy
+	root   string
+
+	mu      sync.Mutex
+	created map[string]bool
+}
+
+// withSymlinkPolicy returns a context applying policy to all local
+// listings, stats, reads and writes done with it. targetURL is the
+// target of the command, empty if it writes nothing.
+func withSymlinkPolicy(ctx context.Context, policy symlinkPolicy, targetURL string) context.Context {
+	if policy == symlinkDefault {
+		return ctx
+	}
+	return context.WithValue(ctx, symlinkPolicyKey{}, &symlinkScope{
+		policy:  policy,
+		root:    symlinkTargetRoot(targetURL),
+		created: map[string]bool{},
+	})
+}
+
+// symlinkTargetRoot returns the absolute path of the folder a local
+// targetURL writes to, empty for remote targets.
+func symlinkTargetRoot(targetURL string) string {
+	if targetURL == "" {
+		return ""
+	}
+	if alias, _, _ := mustExpandAlias(targetURL); alias != "" {
+		return ""
+	}
+	root := targetURL
+	if st, e := os.Stat(root); !strings.HasSuffix(root, string(filepath.Separator)) && (e != nil || !st.IsDir()) {
+		// A file target is written in its folder.
+		root = filepath.Dir(root)
+	}
+	root, e := filepath.Abs(root)
+	if e != nil {
+		return ""
+	}
+	return root
+}
+
+// contextSymlinkScope returns the symlink scope of ctx, nil if links
+// are handled by default.
+func contextSymlinkScope(ctx context.Context) *symlinkScope {
+	scope, _ := ctx.Value(symlinkPolicyKey{}).(*symlinkScope)
+	return scope
+}
+
+// contextSymlinkPolicy returns the symlink policy of ctx.
+func contextSymlinkPolicy(ctx context.Context) symlinkPolicy {
+	if scope := contextSymlinkScope(ctx); scope != nil {
+		return scope.policy
+	}
+	return symlinkDefault
+}
+
+// checkRestore verifies that a symlink to target can be restored at
+// linkPath: the target must be relative and resolve below the root.
+func (s *symlinkScope) checkRestore(linkPath, target string) *probe.Error {
+	unsafe := probe.NewError(UnsafeSymlink{Path: linkPath, Target: target})
+	if s.root == "" || filepath.IsAbs(target) || filepath.VolumeName(target) != "" {
+		return unsafe
+	}
+	resolved := filepath.Join(filepath.Dir(linkPath), target)
+	if !isPathBelow(s.root, resolved) {
+		return unsafe
+	}
+	// Links already present in the target may point elsewhere.
+	if real, e := filepath.EvalSymlinks(resolved); e == nil {
+		if realRoot, e := filepath.EvalSymlinks(s.root); e == nil && !isPathBelow(realRoot, real) {
+			return unsafe
+		}
+	}
+	return s.checkWrite(linkPath)
+}
+
+// checkWrite refuses to write at path through a symlink restored by
+// the same command.
+func (s *symlinkScope) checkWrite(path string) *probe.Error {
+	if s == nil || s.policy != symlinkPreserve {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.created) == 0 {
+		return nil
+	}
+	for dir := filepath.Dir(path); isPathBelow(s.root, dir); dir = filepath.Dir(dir) {
+		if s.created[dir] {
+			return probe.NewError(SymlinkWrite{Path: path, Link: dir})
+		}
+		if dir == s.root {
+			break
+		}
+	}
+	return nil
+}
+
+// restored records a symlink restored at linkPath.
+func (s *symlinkScope) restored(linkPath string) {
+	s.mu.Lock()
+	s.created[linkPath] = true
+	s.mu.Unlock()
+}
+
+// isPathBelow tells if the absolute path p is root or below it.
+func isPathBelow(root, p string) bool {
+	rel, e := filepath.Rel(root, p)
+	if e != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// symlinkFileInfo presents a preserved symlink as an empty regular file.
+type symlinkFileInfo struct {
+	os.FileInfo
+}
+
+func (fi symlinkFileInfo) Size() int64       { return 0 }
+func (fi symlinkFileInfo) Mode() os.FileMode { return fi.FileInfo.Mode().Perm() }
+func (fi symlinkFileInfo) IsDir() bool       { return false }
+
+// resolveSymlink returns the file info to list for the symlink fp with
+// its metadata, nil if the link is not listed.
+func resolveSymlink(fp string, fi os.FileInfo, policy symlinkPolicy) (os.FileInfo, map[string]string) {
+	switch policy {
+	case symlinkSkip:
+		return nil, nil
+	case symlinkPreserve:
+		target, e := os.Readlink(fp)
+		if e != nil {
+			return nil, nil
+		}
+		return symlinkFileInfo{fi}, map[string]string{symlinkMetaDataKey: target}
+	}
+	st, e := os.Stat(fp)
+	if e != nil {
+		// Ignore any errors on symlink
+		return nil, nil
+	}
+	return st, nil
+}
+
+// isSymlinkLoop tells if following the folder link fp loops back to
+// the folder containing it or to one of the folders in parents, the
+// real paths of the folders already entered by the listing.
+func isSymlinkLoop(fp string, parents []string) (string, bool) {
+	target, e := filepath.EvalSymlinks(fp)
+	if e != nil {
+		return "", true
+	}
+	parent, e := filepath.EvalSymlinks(filepath.Dir(fp))
+	if e != nil {
+		return "", true
+	}
+	for _, p := range append(parents, parent) {
+		if p == target || strings.HasPrefix(p, strings.TrimSuffix(target, string(filepath.Separator))+string(filepath.Separator)) {
+			return target, true
+		}
+	}
+	return target, false
+}
@@ -0,0 +1,242 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+// listSymlinkTree lists root recursively with policy, returning the
+// relative paths in listing order and the number of loops reported.
+func listSymlinkTree(c *C, root string, policy symlinkPolicy) ([]string, []*ClientContent, int) {
+	clnt, err := fsNew(root + string(filepath.Separator))
+	c.Assert(err, IsNil)
+	var names []string
+	var contents []*ClientContent
+	loops := 0
+	for content := range clnt.List(withSymlinkPolicy(context.Background(), policy, ""), true, false, false, DirNone) {
+		if content.Err != nil {
+			_, ok := content.Err.ToGoError().(TooManyLevelsSymlink)
+			c.Assert(ok, Equals, true, Commentf("unexpected error %s", content.Err))
+			loops++
+			continue
+		}
+		names = append(names, filepath.ToSlash(strings.TrimPrefix(content.URL.Path, root+string(filepath.Separator))))
+		contents = append(contents, content)
+	}
+	return names, contents, loops
+}
+
+func (s *TestSuite) TestSymlinkPolicyList(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "symlinks-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+	root, e = filepath.EvalSymlinks(root)
+	c.Assert(e, IsNil)
+
+	c.Assert(os.MkdirAll(filepath.Join(root, "d"), 0700), IsNil)
+	for _, name := range []string{"a.txt", "dl.txt", filepath.Join("d", "x")} {
+		c.Assert(ioutil.WriteFile(filepath.Join(root, name), []byte("data"), 0600), IsNil)
+	}
+	c.Assert(os.Symlink("a.txt", filepath.Join(root, "b")), IsNil)
+	// A link to a folder with a sibling sorting before 'dl/'.
+	c.Assert(os.Symlink("d", filepath.Join(root, "dl")), IsNil)
+	// Loops back to the listed folder.
+	c.Assert(os.Symlink("..", filepath.Join(root, "d", "up")), IsNil)
+
+	testCases := []struct {
+		policy  symlinkPolicy
+		entries []string
+		targets map[string]string
+		loops   int
+	}{
+		{symlinkDefault, []string{"a.txt", "b", "d/x", "dl.txt"}, nil, 0},
+		{symlinkFollow, []string{"a.txt", "b", "d/x", "dl.txt", "dl/x"}, nil, 2},
+		{symlinkSkip, []string{"a.txt", "d/x", "dl.txt"}, nil, 0},
+		{symlinkPreserve, []string{"a.txt", "b", "d/up", "d/x", "dl", "dl.txt"},
+			map[string]string{"b": "a.txt", "d/up": "..", "dl": "d"}, 0},
+	}
+
+	for i, testCase := range testCases {
+		entries, contents, loops := listSymlinkTree(c, root, testCase.policy)
+		// Listings are in lexical order, which difference() relies on.
+		c.Assert(sort.StringsAreSorted(entries), Equals, true, Commentf("Test %d: %v", i+1, entries))
+		c.Assert(entries, DeepEquals, testCase.entries, Commentf("Test %d", i+1))
+		c.Assert(loops, Equals, testCase.loops, Commentf("Test %d", i+1))
+		for j, name := range entries {
+			if target, ok := testCase.targets[name]; ok {
+				c.Assert(contents[j].Size, Equals, int64(0), Commentf("Test %d: %s", i+1, name))
+				c.Assert(contents[j].Metadata[symlinkMetaDataKey], Equals, target, Commentf("Test %d: %s", i+1, name))
+			}
+		}
+	}
+}
+
+func (s *TestSuite) TestSymlinkLoopToRoot(c *C) {
+	tmp, e := ioutil.TempDir(os.TempDir(), "symlinks-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(tmp)
+	tmp, e = filepath.EvalSymlinks(tmp)
+	c.Assert(e, IsNil)
+
+	// root/ext links to a folder outside of root, linking back to root.
+	root := filepath.Join(tmp, "root")
+	outside := filepath.Join(tmp, "outside")
+	c.Assert(os.MkdirAll(root, 0700), IsNil)
+	c.Assert(os.MkdirAll(outside, 0700), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(root, "a.txt"), []byte("data"), 0600), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(outside, "y"), []byte("data"), 0600), IsNil)
+	c.Assert(os.Symlink(outside, filepath.Join(root, "ext")), IsNil)
+	c.Assert(os.Symlink(root, filepath.Join(outside, "back")), IsNil)
+
+	entries, _, loops := listSymlinkTree(c, root, symlinkFollow)
+	c.Assert(entries, DeepEquals, []string{"a.txt", "ext/y"})
+	c.Assert(loops, Equals, 1)
+}
+
+func (s *TestSuite) TestSymlinkPreserveRoundTrip(c *C) {
+	tmp, e := ioutil.TempDir(os.TempDir(), "symlinks-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(tmp)
+	tmp, e = filepath.EvalSymlinks(tmp)
+	c.Assert(e, IsNil)
+
+	source := filepath.Join(tmp, "source")
+	target := filepath.Join(tmp, "target")
+	c.Assert(os.MkdirAll(filepath.Join(source, "sub"), 0700), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(source, "f.txt"), []byte("data"), 0600), IsNil)
+	c.Assert(os.Symlink("f.txt", filepath.Join(source, "l")), IsNil)
+	c.Assert(os.Symlink(filepath.Join("..", "f.txt"), filepath.Join(source, "sub", "up")), IsNil)
+	c.Assert(os.Symlink("missing", filepath.Join(source, "dangling")), IsNil)
+
+	ctx := withSymlinkPolicy(context.Background(), symlinkPreserve, target+string(filepath.Separator))
+	entries, contents, _ := listSymlinkTree(c, source, symlinkPreserve)
+	c.Assert(entries, DeepEquals, []string{"dangling", "f.txt", "l", "sub/up"})
+	for i, name := range entries {
+		sourceClnt, err := fsNew(filepath.Join(source, name))
+		c.Assert(err, IsNil)
+		reader, err := sourceClnt.Get(ctx, nil)
+		c.Assert(err, IsNil)
+		targetClnt, err := fsNew(filepath.Join(target, name))
+		c.Assert(err, IsNil)
+		_, err = targetClnt.Put(ctx, reader, contents[i].Size, contents[i].Metadata, nil, nil, false, false)
+		c.Assert(err, IsNil, Commentf("%s", name))
+	}
+
+	data, e := ioutil.ReadFile(filepath.Join(target, "f.txt"))
+	c.Assert(e, IsNil)
+	c.Assert(string(data), Equals, "data")
+	for name, link := range map[string]string{
+		"l":        "f.txt",
+		"sub/up":   filepath.Join("..", "f.txt"),
+		"dangling": "missing",
+	} {
+		restored, e := os.Readlink(filepath.Join(target, filepath.FromSlash(name)))
+		c.Assert(e, IsNil)
+		c.Assert(restored, Equals, link)
+	}
+
+	// A dangling link is described by its target and read as empty.
+	clnt, err := fsNew(filepath.Join(target, "dangling"))
+	c.Assert(err, IsNil)
+	content, err := clnt.Stat(ctx, false, false, nil)
+	c.Assert(err, IsNil)
+	c.Assert(content.Metadata[symlinkMetaDataKey], Equals, "missing")
+	c.Assert(content.Type.IsRegular(), Equals, true)
+	reader, err := clnt.Get(ctx, nil)
+	c.Assert(err, IsNil)
+	data, e = ioutil.ReadAll(reader)
+	c.Assert(e, IsNil)
+	c.Assert(data, HasLen, 0)
+}
+
+func (s *TestSuite) TestSymlinkPreserveUnsafe(c *C) {
+	target, e := ioutil.TempDir(os.TempDir(), "symlinks-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(target)
+	target, e = filepath.EvalSymlinks(target)
+	c.Assert(e, IsNil)
+
+	ctx := withSymlinkPolicy(context.Background(), symlinkPreserve, target+string(filepath.Separator))
+	put := func(name, link string) error {
+		clnt, err := fsNew(filepath.Join(target, name))
+		c.Assert(err, IsNil)
+		metadata := map[string]string{}
+		if link != "" {
+			metadata[symlinkMetaDataKey] = link
+		}
+		_, err = clnt.Put(ctx, bytes.NewReader(nil), 0, metadata, nil, nil, false, false)
+		if err != nil {
+			return err.ToGoError()
+		}
+		return nil
+	}
+
+	// Absolute targets and targets outside of the target folder.
+	for _, link := range []string{"/etc", filepath.Join("..", "outside"), filepath.Join("sub", "..", "..", "x")} {
+		_, ok := put("a", link).(UnsafeSymlink)
+		c.Assert(ok, Equals, true, Commentf("%s", link))
+	}
+
+	// Restored links are never written through.
+	c.Assert(os.MkdirAll(filepath.Join(target, "sub"), 0700), IsNil)
+	c.Assert(put("a", "sub"), IsNil)
+	_, ok := put(filepath.Join("a", "passwd"), "").(SymlinkWrite)
+	c.Assert(ok, Equals, true)
+	_, ok = put(filepath.Join("a", "link"), "x").(SymlinkWrite)
+	c.Assert(ok, Equals, true)
+	_, e = os.Lstat(filepath.Join(target, "sub", "passwd"))
+	c.Assert(os.IsNotExist(e), Equals, true)
+
+	// Neither are local copies of files or links.
+	source := filepath.Join(target, "source.txt")
+	c.Assert(ioutil.WriteFile(source, []byte("data"), 0600), IsNil)
+	sourceLink := filepath.Join(target, "source-link")
+	c.Assert(os.Symlink("source.txt", sourceLink), IsNil)
+	for _, src := range []string{source, sourceLink} {
+		clnt, err := fsNew(filepath.Join(target, "a", "copied"))
+		c.Assert(err, IsNil)
+		err = clnt.Copy(ctx, src, 4, nil, nil, nil, nil, false)
+		c.Assert(err, NotNil)
+		_, ok = err.ToGoError().(SymlinkWrite)
+		c.Assert(ok, Equals, true, Commentf("%s", src))
+	}
+	_, e = os.Lstat(filepath.Join(target, "sub", "copied"))
+	c.Assert(os.IsNotExist(e), Equals, true)
+}
+
+func (s *TestSuite) TestGetSymlinkPolicyConflict(c *C) {
+	flags := sessionFlagValues{&sessionV8Header{CommandBoolFlags: map[string]bool{
+		"follow-symlinks":   true,
+		"skip-symlinks":     true,
+		"preserve-symlinks": true,
+	}}}
+	// The conflict is always reported for the same flags.
+	for i := 0; i < 10; i++ {
+		_, err := getSymlinkPolicy(flags)
+		c.Assert(err, NotNil)
+		c.Assert(strings.Join(err.CallTrace[0].Env["Tags"], ","), Equals, "follow-symlinks,skip-symlinks")
+	}
+}
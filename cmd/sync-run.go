@@ -0,0 +1,415 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Exit statuses of 'mc sync run' when jobs fail, errors preventing
+// all jobs from running exit with globalErrorExitStatus.
+const (
+	syncExitStatusPartialFailure = 2
+	syncExitStatusFailure        = 3
+)
+
+var syncRunFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "number of jobs run at the same time, overrides the spec file",
+	},
+	cli.StringFlag{
+		Name:  "report",
+		Usage: "write a consolidated JSON report of all jobs to a file",
+	},
+	cli.BoolFlag{
+		Name:  "schedule",
+		Usage: "keep running and repeat jobs according to their schedule",
+	},
+}
+
+var syncRunCmd = cli.Command{
+	Name:   "run",
+	Usage:  "run all mirror jobs of a spec file",
+	Action: mainSyncRun,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(syncRunFlags, limitFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SPECFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+SPECFILE:
+  A YAML file, or a JSON file when its name ends with '.json', listing the jobs:
+
+    concurrency: 2
+    jobs:
+      - name: photos
+        source: play/photos
+        target: s3/backup-photos
+        include: ["*.jpg", "*.png"]
+        exclude: ["*.tmp"]
+        excludeFrom: /etc/mc/photos.mcignore
+        remove: true
+        overwrite: true
+        storageClass: STANDARD_IA
+        encrypt: s3/backup-photos
+        schedule: 6h
+
+  'include', 'exclude', 'includeRegex' and 'excludeFrom' filter objects as the
+  '--include', '--exclude', '--include-regex' and '--exclude-from' flags of 'mirror'.
+  'encrypt' lists prefixes encrypted with SSE-S3 and 'encryptKey' lists prefix=key pairs
+  encrypted with SSE-C, as '--encrypt' and '--encrypt-key' of 'mirror'. 'schedule' is the
+  interval between two runs of a job, used with '--schedule'.
+
+EXIT STATUS:
+  0 when all jobs succeeded, 1 when no job could be started, 2 when some jobs failed
+  and 3 when all jobs failed. Failed jobs are listed in the report.
+
+EXAMPLES:
+  1. Run all jobs of a spec file once.
+     {{.Prompt}} {{.HelpName}} jobs.yaml
+
+  2. Run all jobs, four at a time, and write a report.
+     {{.Prompt}} {{.HelpName}} --concurrency 4 --report /var/log/mc/sync-report.json jobs.yaml
+
+  3. Keep running and repeat scheduled jobs, limiting the uploads of each job to 20MiB/s.
+     {{.Prompt}} {{.HelpName}} --schedule --limit-upload 20MiB jobs.yaml
+`,
+}
+
+// syncJobResult is the outcome of the last run of a sync job.
+type syncJobResult struct {
+	Status     string    `json:"status"`
+	Name       string    `json:"name"`
+	Source     string    `json:"source"`
+	Target     string    `json:"target"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	TotalCount int64     `json:"totalCount"`
+	TotalSize  int64     `json:"totalSize"`
+	Runs       int       `json:"runs"`
+	RunsFailed int       `json:"runsFailed"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (r syncJobResult) String() string {
+	switch r.Status {
+	case "success":
+		return console.Colorize("SyncJob", fmt.Sprintf("Job `%s`: `%s` -> `%s`, %d object(s), %s in %s.",
+			r.Name, r.Source, r.Target, r.TotalCount, humanize.IBytes(uint64(r.TotalSize)),
+			r.EndTime.Sub(r.StartTime).Round(time.Second)))
+	case "error":
+		if r.Error != "" {
+			return console.Colorize("SyncJobFailed", fmt.Sprintf("Job `%s` failed: `%s` -> `%s`: %s",
+				r.Name, r.Source, r.Target, r.Error))
+		}
+		return console.Colorize("SyncJobFailed", fmt.Sprintf("Job `%s` failed: `%s` -> `%s`.",
+			r.Name, r.Source, r.Target))
+	default:
+		return console.Colorize("SyncJobFailed", fmt.Sprintf("Job `%s` did not run.", r.Name))
+	}
+}
+
+func (r syncJobResult) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// syncReport is the consolidated report of all jobs of a spec.
+type syncReport struct {
+	Status     string          `json:"status"`
+	Spec       string          `json:"spec"`
+	Jobs       []syncJobResult `json:"jobs"`
+	FailedJobs []string        `json:"failedJobs,omitempty"`
+}
+
+func (r syncReport) String() string {
+	if len(r.FailedJobs) == 0 {
+		return console.Colorize("SyncJob", fmt.Sprintf("All %d job(s) of `%s` succeeded.", len(r.Jobs), r.Spec))
+	}
+	return console.Colorize("SyncJobFailed", fmt.Sprintf("%d of %d job(s) of `%s` failed: %s.",
+		len(r.FailedJobs), len(r.Jobs), r.Spec, strings.Join(r.FailedJobs, ", ")))
+}
+
+func (r syncReport) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// syncJob is a job of the spec ready to run.
+type syncJob struct {
+	spec     syncJobSpec
+	source   string
+	opts     mirrorOptions
+	interval time.Duration
+}
+
+// newSyncJob verifies the URLs and options of spec and prepares the job,
+// limits are not shared with other jobs.
+func newSyncJob(spec syncJobSpec, limits bandwidthLimits) (*syncJob, *probe.Error) {
+	var err *probe.Error
+	sseKeys := spec.EncryptKey
+	if sseKeys != "" {
+		if sseKeys, err = getDecodedKey(sseKeys); err != nil {
+			return nil, err.Trace(spec.Name)
+		}
+	}
+	encKeyDB, err := parseAndValidateEncryptionKeys(sseKeys, spec.Encrypt)
+	if err != nil {
+		return nil, err.Trace(spec.Name)
+	}
+	filter, err := newListFilter(spec.Include, spec.Exclude, spec.IncludeRegex, spec.ExcludeFrom)
+	if err != nil {
+		return nil, err.Trace(spec.Name)
+	}
+
+	source := spec.Source
+	if fi, e := os.Stat(source); e == nil && fi.IsDir() && !filepath.IsAbs(source) {
+		// Mirror expects local directories as absolute paths.
+		if absSource, e := filepath.Abs(source); e == nil {
+			source = absSource
+		}
+	}
+	for _, url := range []string{source, spec.Target} {
+		if _, err = newClient(url); err != nil {
+			return nil, err.Trace(spec.Name, url)
+		}
+	}
+
+	return &syncJob{
+		spec:   spec,
+		source: source,
+		opts: mirrorOptions{
+			isRemove:     spec.Remove,
+			isOverwrite:  spec.Overwrite,
+			isQuiet:      true,
+			filter:       filter,
+			storageClass: spec.StorageClass,
+			encKeyDB:     encKeyDB,
+			limits:       limits,
+		},
+		interval: spec.interval(),
+	}, nil
+}
+
+// run mirrors the job once and updates result.
+func (j *syncJob) run(ctx context.Context, result *syncJobResult) {
+	jobCtx, cancelJob := context.WithCancel(ctx)
+	defer cancelJob()
+
+	result.StartTime = UTCNow()
+	mj, errorDetected, failure := mirrorWithOptions(jobCtx, cancelJob, j.source, j.spec.Target, "", j.opts)
+	result.EndTime = UTCNow()
+	result.TotalCount, result.TotalSize = 0, 0
+	if mj != nil {
+		result.TotalCount = mj.status.GetCounts()
+		result.TotalSize = mj.status.Get()
+	}
+	result.Runs++
+	result.Status = "success"
+	result.Error = ""
+	if errorDetected {
+		result.Status = "error"
+		result.RunsFailed++
+	}
+	if failure != nil {
+		// The failure only stops this job.
+		result.Error = failure.String()
+	}
+}
+
+// syncRunner runs the jobs of a spec and keeps track of their results.
+type syncRunner struct {
+	mu         sync.Mutex
+	specFile   string
+	reportFile string
+	jobs       []*syncJob
+	results    []syncJobResult
+}
+
+// report builds the consolidated report from the last results.
+func (r *syncRunner) report() syncReport {
+	report := syncReport{
+		Status: "success",
+		Spec:   r.specFile,
+		Jobs:   make([]syncJobResult, len(r.results)),
+	}
+	copy(report.Jobs, r.results)
+	for _, result := range r.results {
+		if result.Status != "success" {
+			report.Status = "error"
+			report.FailedJobs = append(report.FailedJobs, result.Name)
+		}
+	}
+	return report
+}
+
+// saveReport writes the report to the report file, if any.
+func (r *syncRunner) saveReport(report syncReport) *probe.Error {
+	if r.reportFile == "" {
+		return nil
+	}
+	data, e := json.MarshalIndent(report, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if e = ioutil.WriteFile(r.reportFile, data, 0644); e != nil {
+		return probe.NewError(e).Trace(r.reportFile)
+	}
+	return nil
+}
+
+// runJob runs job i, repeating it according to its schedule when schedule is set.
+func (r *syncRunner) runJob(ctx context.Context, i int, sem chan struct{}, schedule bool) {
+	job := r.jobs[i]
+	for {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		r.mu.Lock()
+		result := r.results[i]
+		r.mu.Unlock()
+
+		job.run(ctx, &result)
+		<-sem
+
+		r.mu.Lock()
+		r.results[i] = result
+		printMsg(result)
+		errorIf(r.saveReport(r.report()), "Unable to write sync report.")
+		r.mu.Unlock()
+
+		if !schedule || job.interval == 0 {
+			return
+		}
+		select {
+		case <-time.After(job.interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run runs all jobs with at most concurrency jobs at the same time.
+func (r *syncRunner) run(ctx context.Context, concurrency int, schedule bool) syncReport {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range r.jobs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.runJob(ctx, i, sem, schedule)
+		}(i)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.report()
+}
+
+// checkSyncRunSyntax - validate all the passed arguments
+func checkSyncRunSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "run", 1) // last argument is exit code
+	}
+	if ctx.Int("concurrency") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "Concurrency cannot be negative.")
+	}
+}
+
+// mainSyncRun is the handle for "mc sync run" command.
+func mainSyncRun(cliCtx *cli.Context) error {
+	ctx, cancelSync := context.WithCancel(globalContext)
+	defer cancelSync()
+
+	checkSyncRunSyntax(cliCtx)
+
+	console.SetColor("SyncJob", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SyncJobFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+
+	specFile := cliCtx.Args().Get(0)
+	spec, err := loadSyncSpec(specFile)
+	fatalIf(err, "Unable to load sync spec `"+specFile+"`.")
+
+	limits, err := parseBandwidthLimits(cliCtx.String("limit-upload"), cliCtx.String("limit-download"))
+	fatalIf(err, "Unable to parse bandwidth limits.")
+
+	concurrency := cliCtx.Int("concurrency")
+	if concurrency == 0 {
+		concurrency = spec.Concurrency
+	}
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
+	runner := &syncRunner{
+		specFile:   specFile,
+		reportFile: cliCtx.String("report"),
+		jobs:       make([]*syncJob, len(spec.Jobs)),
+		results:    make([]syncJobResult, len(spec.Jobs)),
+	}
+	for i, jobSpec := range spec.Jobs {
+		// Each job has its own limiters, limits apply per job.
+		runner.jobs[i], err = newSyncJob(jobSpec, limits.clone())
+		fatalIf(err, "Unable to prepare job `"+jobSpec.Name+"`.")
+		runner.results[i] = syncJobResult{
+			Status: "pending",
+			Name:   jobSpec.Name,
+			Source: jobSpec.Source,
+			Target: jobSpec.Target,
+		}
+	}
+
+	report := runner.run(ctx, concurrency, cliCtx.Bool("schedule"))
+	printMsg(report)
+
+	switch len(report.FailedJobs) {
+	case 0:
+		return nil
+	case len(report.Jobs):
+		return exitStatus(syncExitStatusFailure)
+	default:
+		return exitStatus(syncExitStatusPartialFailure)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestSyncJobFailure(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "sync-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	source := filepath.Join(root, "source")
+	c.Assert(os.Mkdir(source, 0755), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(source, "a.txt"), []byte("a"), 0644), IsNil)
+	// The target cannot be created under a file.
+	blocker := filepath.Join(root, "blocker")
+	c.Assert(ioutil.WriteFile(blocker, []byte("b"), 0644), IsNil)
+
+	limits, err := parseBandwidthLimits("1MiB", "")
+	c.Assert(err, IsNil)
+	job, err := newSyncJob(syncJobSpec{Name: "blocked", Source: source, Target: filepath.Join(blocker, "target")}, limits)
+	c.Assert(err, IsNil)
+
+	// The failure is recorded in the result of the job instead of
+	// exiting.
+	result := syncJobResult{Name: "blocked"}
+	job.run(context.Background(), &result)
+	c.Assert(result.Status, Equals, "error")
+	c.Assert(result.RunsFailed, Equals, 1)
+	c.Assert(result.Error, Not(Equals), "")
+}
@@ -0,0 +1,124 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// syncJobSpec describes one mirror job of a sync spec file.
+type syncJobSpec struct {
+	Name         string   `yaml:"name" json:"name"`
+	Source       string   `yaml:"source" json:"source"`
+	Target       string   `yaml:"target" json:"target"`
+	Include      []string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude      []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	IncludeRegex []string `yaml:"includeRegex,omitempty" json:"includeRegex,omitempty"`
+	ExcludeFrom  string   `yaml:"excludeFrom,omitempty" json:"excludeFrom,omitempty"`
+	Remove       bool     `yaml:"remove,omitempty" json:"remove,omitempty"`
+	Overwrite    bool     `yaml:"overwrite,omitempty" json:"overwrite,omitempty"`
+	StorageClass string   `yaml:"storageClass,omitempty" json:"storageClass,omitempty"`
+	// Comma separated prefixes encrypted with SSE-S3.
+	Encrypt string `yaml:"encrypt,omitempty" json:"encrypt,omitempty"`
+	// Comma separated prefix=key pairs encrypted with SSE-C.
+	EncryptKey string `yaml:"encryptKey,omitempty" json:"encryptKey,omitempty"`
+	// Interval between two runs of the job, such as '6h'.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+}
+
+// syncSpec is the content of a sync spec file.
+type syncSpec struct {
+	// Number of jobs run at the same time.
+	Concurrency int           `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	Jobs        []syncJobSpec `yaml:"jobs" json:"jobs"`
+}
+
+// interval returns the interval between two runs of the job, zero
+// if the job is not scheduled.
+func (j syncJobSpec) interval() time.Duration {
+	if j.Schedule == "" {
+		return 0
+	}
+	d, _ := time.ParseDuration(j.Schedule)
+	return d
+}
+
+// validate verifies the spec is complete and consistent.
+func (s *syncSpec) validate() *probe.Error {
+	if len(s.Jobs) == 0 {
+		return probe.NewError(fmt.Errorf("no jobs found"))
+	}
+	if s.Concurrency < 0 {
+		return probe.NewError(fmt.Errorf("invalid concurrency %d", s.Concurrency))
+	}
+	names := make(map[string]struct{}, len(s.Jobs))
+	for i, job := range s.Jobs {
+		if job.Name == "" {
+			return probe.NewError(fmt.Errorf("job %d has no name", i+1))
+		}
+		if _, ok := names[job.Name]; ok {
+			return probe.NewError(fmt.Errorf("job name `%s` is not unique", job.Name))
+		}
+		names[job.Name] = struct{}{}
+		if job.Source == "" || job.Target == "" {
+			return probe.NewError(fmt.Errorf("job `%s` requires a source and a target", job.Name))
+		}
+		if job.Schedule != "" {
+			if d, e := time.ParseDuration(job.Schedule); e != nil || d <= 0 {
+				return probe.NewError(fmt.Errorf("job `%s` has an invalid schedule `%s`", job.Name, job.Schedule))
+			}
+		}
+	}
+	return nil
+}
+
+// parseSyncSpec parses a YAML or JSON spec, JSON is expected when
+// the file name ends with '.json'.
+func parseSyncSpec(fileName string, data []byte) (*syncSpec, *probe.Error) {
+	spec := &syncSpec{}
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if e := decoder.Decode(spec); e != nil {
+			return nil, probe.NewError(e).Trace(fileName)
+		}
+	} else if e := yaml.UnmarshalStrict(data, spec); e != nil {
+		return nil, probe.NewError(e).Trace(fileName)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err.Trace(fileName)
+	}
+	return spec, nil
+}
+
+// loadSyncSpec reads and parses the spec file.
+func loadSyncSpec(fileName string) (*syncSpec, *probe.Error) {
+	data, e := ioutil.ReadFile(fileName)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(fileName)
+	}
+	return parseSyncSpec(fileName, data)
+}
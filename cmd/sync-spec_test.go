@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSyncSpec(t *testing.T) {
+	testCases := []struct {
+		fileName string
+		data     string
+		jobs     int
+		success  bool
+	}{
+		// Valid YAML spec.
+		{"jobs.yaml", `
+concurrency: 2
+jobs:
+  - name: photos
+    source: play/photos
+    target: s3/backup-photos
+    include: ["*.jpg"]
+    exclude: ["*.tmp"]
+    remove: true
+    storageClass: STANDARD_IA
+    schedule: 6h
+  - name: logs
+    source: /var/log
+    target: s3/logs
+`, 2, true},
+		// Valid JSON spec.
+		{"jobs.json", `{"jobs": [{"name": "photos", "source": "play/photos", "target": "s3/photos"}]}`, 1, true},
+		// Unknown field.
+		{"jobs.yaml", "jobs:\n  - name: photos\n    source: a\n    target: b\n    delete: true\n", 0, false},
+		{"jobs.json", `{"jobs": [{"name": "photos", "source": "a", "target": "b", "delete": true}]}`, 0, false},
+		// No jobs.
+		{"jobs.yaml", "concurrency: 2\n", 0, false},
+		// Missing name.
+		{"jobs.yaml", "jobs:\n  - source: a\n    target: b\n", 0, false},
+		// Duplicate names.
+		{"jobs.yaml", "jobs:\n  - name: a\n    source: a\n    target: b\n  - name: a\n    source: c\n    target: d\n", 0, false},
+		// Missing target.
+		{"jobs.yaml", "jobs:\n  - name: a\n    source: a\n", 0, false},
+		// Invalid schedule.
+		{"jobs.yaml", "jobs:\n  - name: a\n    source: a\n    target: b\n    schedule: daily\n", 0, false},
+		// Negative concurrency.
+		{"jobs.yaml", "concurrency: -1\njobs:\n  - name: a\n    source: a\n    target: b\n", 0, false},
+	}
+
+	for i, testCase := range testCases {
+		spec, err := parseSyncSpec(testCase.fileName, []byte(testCase.data))
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: expected to pass, but failed with %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected to fail, but passed", i+1)
+		}
+		if err == nil && len(spec.Jobs) != testCase.jobs {
+			t.Fatalf("Test %d: expected %d jobs, got %d", i+1, testCase.jobs, len(spec.Jobs))
+		}
+	}
+}
+
+func TestSyncJobSpecInterval(t *testing.T) {
+	testCases := []struct {
+		schedule string
+		interval time.Duration
+	}{
+		{"", 0},
+		{"6h", 6 * time.Hour},
+		{"90m", 90 * time.Minute},
+	}
+
+	for i, testCase := range testCases {
+		if interval := (syncJobSpec{Schedule: testCase.schedule}).interval(); interval != testCase.interval {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.interval, interval)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Flags tuning the multipart uploads of commands copying data.
+var multipartFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "part-size",
+		Usage: "size of multipart upload parts in MiB, GiB, or 'auto' to tune it from object size and throughput",
+	},
+	cli.IntFlag{
+		Name:  "parallel-parts",
+		Usage: "number of parts of an object uploaded in parallel (default: 4)",
+	},
+}
+
+// Flags tuning the number of objects copied in parallel.
+var workerFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "max-workers",
+		Usage: "maximum number of object(s) transferred in parallel (default: 128)",
+	},
+}
+
+const (
+	// Limits of multipart uploads.
+	minPartSize            = 5 * humanize.MiByte
+	maxPartSize            = 5 * humanize.GiByte
+	maxPartsCount          = 10000
+	maxMultipartObjectSize = 5 * humanize.TiByte
+
+	// Part size of auto sized uploads until throughput is measured.
+	defaultAutoPartSize = 64 * humanize.MiByte
+	// Smallest part size of auto sized uploads, smaller parts spend
+	// more time in round trips than in transfers.
+	minAutoPartSize = 16 * humanize.MiByte
+	// Auto sized parts take about this long to upload.
+	autoPartDuration = 10 * time.Second
+)
+
+// transferOptions tunes multipart uploads.
+type transferOptions struct {
+	// Part size, zero leaves it to the client library.
+	partSize uint64
+	// Part size is tuned from object size and throughput.
+	autoPartSize bool
+	// Parts of an object uploaded in parallel, zero is the default.
+	parallelParts uint
+
+	// Measures the throughput of auto sized uploads, nil if unknown.
+	throughput *ParallelManager
+}
+
+// parseTransferOptions parses --part-size and --parallel-parts values.
+func parseTransferOptions(partSize string, parallelParts int) (opts transferOptions, err *probe.Error) {
+	switch {
+	case strings.EqualFold(partSize, "auto"):
+		opts.autoPartSize = true
+	case partSize != "":
+		size, e := humanize.ParseBytes(partSize)
+		if e != nil {
+			return opts, probe.NewError(e).Trace(partSize)
+		}
+		if size < minPartSize || size > maxPartSize {
+			return opts, errInvalidArgument().Trace(partSize)
+		}
+		opts.partSize = size
+	}
+	if parallelParts < 0 {
+		return opts, errInvalidArgument().Trace(strconv.Itoa(parallelParts))
+	}
+	opts.parallelParts = uint(parallelParts)
+	return opts, nil
+}
+
+// getTransferOptions returns the transfer options requested by the
+// multipart flags.
+func getTransferOptions(ctx flagValues) (transferOptions, *probe.Error) {
+	return parseTransferOptions(ctx.String("part-size"), ctx.Int("parallel-parts"))
+}
+
+// getMaxWorkers returns the --max-workers value.
+func getMaxWorkers(ctx flagValues) (int, *probe.Error) {
+	maxWorkers := ctx.Int("max-workers")
+	if maxWorkers < 0 {
+		return 0, errInvalidArgument().Trace(strconv.Itoa(maxWorkers))
+	}
+	return maxWorkers, nil
+}
+
+// numThreads returns the number of parts uploaded in parallel.
+func (t transferOptions) numThreads() uint {
+	if t.parallelParts > 0 {
+		return t.parallelParts
+	}
+	return defaultMultipartThreadsNum
+}
+
+// partSizeFor returns the part size of an upload of size bytes, -1 if
+// unknown. Parts are made large enough for the object to fit in the
+// maximum number of parts.
+func (t transferOptions) partSizeFor(size int64) uint64 {
+	partSize := t.partSize
+	if t.autoPartSize {
+		if size < 0 {
+			// Leave it to the client library, which sizes the parts
+			// for the largest object.
+			return 0
+		}
+		partSize = defaultAutoPartSize
+		if t.throughput != nil {
+			// Bandwidth of a single part, each worker uploads
+			// its parts in parallel.
+			if bandwidth := t.throughput.workerBandwidth() / int64(t.numThreads()); bandwidth > 0 {
+				partSize = uint64(bandwidth) * uint64(autoPartDuration/time.Second)
+			}
+		}
+		if partSize < minAutoPartSize {
+			partSize = minAutoPartSize
+		}
+	}
+	if partSize == 0 {
+		return 0
+	}
+
+	if size < 0 {
+		size = maxMultipartObjectSize
+	}
+	if minSize := (uint64(size) + maxPartsCount - 1) / maxPartsCount; partSize < minSize {
+		partSize = minSize
+	}
+	// Round up to a MiB.
+	partSize = (partSize + humanize.MiByte - 1) / humanize.MiByte * humanize.MiByte
+	if partSize > maxPartSize {
+		partSize = maxPartSize
+	}
+	return partSize
+}
+
+// warnRaisedPartSize is done once the user is told that --part-size was
+// raised for uploads of unknown size.
+var warnRaisedPartSize sync.Once
+
+// uploadPartSize returns the part size of an upload of size bytes, as
+// partSizeFor. Parts of uploads of unknown size are sized for the largest
+// object, the user is warned when it raises --part-size.
+func (t transferOptions) uploadPartSize(size int64) uint64 {
+	partSize := t.partSizeFor(size)
+	if size < 0 && t.partSize > 0 && partSize > t.partSize {
+		warnRaisedPartSize.Do(func() {
+			errorIf(errInvalidArgument().Trace(humanize.IBytes(t.partSize)),
+				"--part-size is raised to "+humanize.IBytes(partSize)+" for uploads of unknown size, to fit the largest object in "+strconv.Itoa(maxPartsCount)+" parts.")
+		})
+	}
+	return partSize
+}
+
+type transferOptionsKey struct{}
+
+// withTransferOptions returns a copy of ctx whose uploads use opts.
+func withTransferOptions(ctx context.Context, opts transferOptions) context.Context {
+	return context.WithValue(ctx, transferOptionsKey{}, opts)
+}
+
+// getTransferOptionsFromContext returns the transfer options of ctx, the
+// defaults if none were set.
+func getTransferOptionsFromContext(ctx context.Context) transferOptions {
+	opts, _ := ctx.Value(transferOptionsKey{}).(transferOptions)
+	return opts
+}
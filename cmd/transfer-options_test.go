@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestParseTransferOptions(t *testing.T) {
+	testCases := []struct {
+		partSize      string
+		parallelParts int
+		shouldPass    bool
+		expected      transferOptions
+	}{
+		{"", 0, true, transferOptions{}},
+		{"64MiB", 8, true, transferOptions{partSize: 64 * humanize.MiByte, parallelParts: 8}},
+		{"auto", 0, true, transferOptions{autoPartSize: true}},
+		{"AUTO", 2, true, transferOptions{autoPartSize: true, parallelParts: 2}},
+		// Below the minimum part size.
+		{"1MiB", 0, false, transferOptions{}},
+		// Above the maximum part size.
+		{"6GiB", 0, false, transferOptions{}},
+		{"big", 0, false, transferOptions{}},
+		{"", -1, false, transferOptions{}},
+	}
+
+	for i, testCase := range testCases {
+		opts, err := parseTransferOptions(testCase.partSize, testCase.parallelParts)
+		if testCase.shouldPass && err != nil {
+			t.Fatalf("Test %d: unexpected error: %s", i+1, err)
+		}
+		if !testCase.shouldPass && err == nil {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if testCase.shouldPass && opts != testCase.expected {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, opts)
+		}
+	}
+}
+
+func TestPartSizeFor(t *testing.T) {
+	sampled := &ParallelManager{bandwidth: 80 * humanize.MiByte, workersNum: 2}
+
+	testCases := []struct {
+		opts     transferOptions
+		size     int64
+		expected uint64
+	}{
+		// Defaults are left to the client library.
+		{transferOptions{}, 100 * humanize.GiByte, 0},
+		{transferOptions{partSize: 16 * humanize.MiByte}, humanize.GiByte, 16 * humanize.MiByte},
+		// Parts are enlarged to fit the object in 10000 parts.
+		{transferOptions{partSize: 16 * humanize.MiByte}, humanize.TiByte, 105 * humanize.MiByte},
+		{transferOptions{partSize: 16 * humanize.MiByte}, -1, 525 * humanize.MiByte},
+		// Auto mode before the throughput is sampled.
+		{transferOptions{autoPartSize: true}, humanize.GiByte, 64 * humanize.MiByte},
+		{transferOptions{autoPartSize: true}, -1, 0},
+		// 40MiB/s per worker and 4 parts in parallel, 10 seconds per part.
+		{transferOptions{autoPartSize: true, throughput: sampled}, humanize.GiByte, 100 * humanize.MiByte},
+		// 40MiB/s per worker and 32 parts in parallel, at least 16MiB.
+		{transferOptions{autoPartSize: true, parallelParts: 32, throughput: sampled}, humanize.GiByte, 16 * humanize.MiByte},
+	}
+
+	for i, testCase := range testCases {
+		if partSize := testCase.opts.partSizeFor(testCase.size); partSize != testCase.expected {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.expected, partSize)
+		}
+	}
+}
@@ -75,6 +75,13 @@ var errInvalidAPISignature = func(api, url string) *probe.Error {
 	return probe.NewError(invalidAPISignatureErr(errors.New(msg)))
 }
 
+var errCredentialsSourceSignature = func(api, url, source string) *probe.Error {
+	msg := fmt.Sprintf(
+		"API signature %s for host %s is not supported with credentials source `%s`, use `S3v4`",
+		api, url, source)
+	return probe.NewError(invalidAPISignatureErr(errors.New(msg)))
+}
+
 type noMatchingHostErr error
 
 var errNoMatchingHost = func(URL string) *probe.Error {
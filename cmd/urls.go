@@ -30,6 +30,7 @@ type URLs struct {
 	TotalSize        int64
 	MD5              bool
 	DisableMultipart bool
+	Verify           checksumAlgorithm `json:",omitempty"`
 	encKeyDB         map[string][]prefixSSEPair
 	Error            *probe.Error `json:"-"`
 }
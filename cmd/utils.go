@@ -125,7 +125,7 @@ func splitStr(path, sep string, n int) []string {
 
 // NewS3Config simply creates a new Config struct using the passed
 // parameters.
-func NewS3Config(urlStr string, hostCfg *hostConfigV9) *Config {
+func NewS3Config(urlStr string, hostCfg *hostConfigV10) *Config {
 	// We have a valid alias and hostConfig. We populate the
 	// credentials from the match found in the config file.
 	s3Config := new(Config)
@@ -142,6 +142,7 @@ func NewS3Config(urlStr string, hostCfg *hostConfigV9) *Config {
 		s3Config.SecretKey = hostCfg.SecretKey
 		s3Config.SessionToken = hostCfg.SessionToken
 		s3Config.Signature = hostCfg.API
+		s3Config.Credentials = hostCfg.Credentials
 	}
 	s3Config.Lookup = getLookupType(hostCfg.Lookup)
 	return s3Config
@@ -184,6 +185,20 @@ func isNewer(ti time.Time, newerRef string) bool {
 	return objectAge >= newerThan
 }
 
+// parseRewindFlag parses the value of --rewind which is either an
+// absolute RFC3339 time or a duration relative to the current time.
+func parseRewindFlag(rewind string) (timeRef time.Time) {
+	if rewind == "" {
+		return timeRef
+	}
+	if t, e := time.Parse(time.RFC3339, rewind); e == nil {
+		return t.UTC()
+	}
+	duration, e := ioutils.ParseDurationTime(rewind)
+	fatalIf(probe.NewError(e), "Unable to parse rewind=`"+rewind+"`.")
+	return UTCNow().Add(-duration)
+}
+
 // getLookupType returns the minio.BucketLookupType for lookup
 // option entered on the command line
 func getLookupType(l string) minio.BucketLookupType {
@@ -0,0 +1,98 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var versionEnableCmd = cli.Command{
+	Name:   "enable",
+	Usage:  "enable bucket versioning",
+	Action: mainVersionEnable,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Enable versioning on bucket "mybucket" for alias "myminio".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+`,
+}
+
+// versionEnableMessage is container for enable versioning command on success message.
+type versionEnableMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+func (v versionEnableMessage) JSON() string {
+	v.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (v versionEnableMessage) String() string {
+	return console.Colorize("VersionEnable", fmt.Sprintf("%s versioning is enabled successfully.", v.URL))
+}
+
+// checkVersionEnableSyntax - validate all the passed arguments
+func checkVersionEnableSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "enable", 1) // last argument is exit code
+	}
+}
+
+// mainVersionEnable is the handle for "mc version enable" command.
+func mainVersionEnable(cliCtx *cli.Context) error {
+	ctx, cancelVersionEnable := context.WithCancel(globalContext)
+	defer cancelVersionEnable()
+
+	console.SetColor("VersionEnable", color.New(color.FgGreen))
+
+	checkVersionEnableSyntax(cliCtx)
+
+	// Get the alias parameter from cli
+	aliasedURL := cliCtx.Args().Get(0)
+
+	// Create a new Client
+	client, err := newClient(aliasedURL)
+	fatalIf(err, "Unable to initialize connection.")
+
+	fatalIf(client.SetVersioning(ctx, versioningEnabled).Trace(aliasedURL), "Unable to enable versioning")
+
+	printMsg(versionEnableMessage{
+		Status: "success",
+		URL:    aliasedURL,
+	})
+	return nil
+}
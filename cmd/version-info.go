@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var versionInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show bucket versioning status",
+	Action: mainVersionInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Display bucket versioning status for bucket "mybucket".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+`,
+}
+
+// versionInfoMessage is container for versioning info command on success message.
+type versionInfoMessage struct {
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+	Versioning string `json:"versioning"`
+}
+
+func (v versionInfoMessage) JSON() string {
+	v.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (v versionInfoMessage) String() string {
+	if v.Versioning == "" {
+		return console.Colorize("VersionInfo", fmt.Sprintf("%s is un-versioned", v.URL))
+	}
+	return console.Colorize("VersionInfo", fmt.Sprintf("%s versioning is %s", v.URL, strings.ToLower(v.Versioning)))
+}
+
+// checkVersionInfoSyntax - validate all the passed arguments
+func checkVersionInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// mainVersionInfo is the handle for "mc version info" command.
+func mainVersionInfo(cliCtx *cli.Context) error {
+	ctx, cancelVersionInfo := context.WithCancel(globalContext)
+	defer cancelVersionInfo()
+
+	console.SetColor("VersionInfo", color.New(color.FgGreen))
+
+	checkVersionInfoSyntax(cliCtx)
+
+	// Get the alias parameter from cli
+	aliasedURL := cliCtx.Args().Get(0)
+
+	// Create a new Client
+	client, err := newClient(aliasedURL)
+	fatalIf(err, "Unable to initialize connection.")
+
+	status, err := client.GetVersioning(ctx)
+	fatalIf(err.Trace(aliasedURL), "Unable to get versioning info")
+
+	printMsg(versionInfoMessage{
+		Status:     "success",
+		URL:        aliasedURL,
+		Versioning: status,
+	})
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var versionSuspendCmd = cli.Command{
+	Name:   "suspend",
+	Usage:  "suspend bucket versioning",
+	Action: mainVersionSuspend,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Suspend versioning on bucket "mybucket" for alias "myminio".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+`,
+}
+
+// versionSuspendMessage is container for suspend versioning command on success message.
+type versionSuspendMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+func (v versionSuspendMessage) JSON() string {
+	v.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (v versionSuspendMessage) String() string {
+	return console.Colorize("VersionSuspend", fmt.Sprintf("%s versioning is suspended successfully.", v.URL))
+}
+
+// checkVersionSuspendSyntax - validate all the passed arguments
+func checkVersionSuspendSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "suspend", 1) // last argument is exit code
+	}
+}
+
+// mainVersionSuspend is the handle for "mc version suspend" command.
+func mainVersionSuspend(cliCtx *cli.Context) error {
+	ctx, cancelVersionSuspend := context.WithCancel(globalContext)
+	defer cancelVersionSuspend()
+
+	console.SetColor("VersionSuspend", color.New(color.FgGreen))
+
+	checkVersionSuspendSyntax(cliCtx)
+
+	// Get the alias parameter from cli
+	aliasedURL := cliCtx.Args().Get(0)
+
+	// Create a new Client
+	client, err := newClient(aliasedURL)
+	fatalIf(err, "Unable to initialize connection.")
+
+	fatalIf(client.SetVersioning(ctx, versioningSuspended).Trace(aliasedURL), "Unable to suspend versioning")
+
+	printMsg(versionSuspendMessage{
+		Status: "success",
+		URL:    aliasedURL,
+	})
+	return nil
+}
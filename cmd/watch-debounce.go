@@ -0,0 +1,243 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/rjeczalik/notify"
+)
+
+// fsWatchDebounceBuffer is the number of notifications buffered while
+// debouncing, notifications are dropped when the buffer is full.
+const fsWatchDebounceBuffer = 10000
+
+// fsPendingEvent is the event of a path waiting for the end of the
+// debounce window.
+type fsPendingEvent struct {
+	eventType EventType
+	// Whether the file of a put was created within the window.
+	created bool
+	// Time of the last notification for the path.
+	last time.Time
+	// Size and modification time of a created file at the last
+	// check, the file is stable when they did not change since.
+	checked bool
+	size    int64
+	modTime time.Time
+}
+
+// fsWatchDebouncer merges the notifications of each path until no
+// notification arrived for a window. Repeated writes become a single
+// put, a file created and deleted within a window is dropped altogether
+// and puts are reported once the size and modification time of the file
+// are stable for a window.
+type fsWatchDebouncer struct {
+	window  time.Duration
+	pending map[string]*fsPendingEvent
+	// Number of puts dropped since the last flush.
+	dropped int
+	stat    func(name string) (os.FileInfo, error)
+}
+
+func newFSWatchDebouncer(window time.Duration) *fsWatchDebouncer {
+	return &fsWatchDebouncer{
+		window:  window,
+		pending: make(map[string]*fsPendingEvent),
+		stat:    os.Stat,
+	}
+}
+
+// add records a notification of eventType for path, created is set
+// when the notification reports the creation of the file.
+func (d *fsWatchDebouncer) add(path string, eventType EventType, created bool, now time.Time) {
+	p, ok := d.pending[path]
+	switch eventType {
+	case EventCreate:
+		if !ok {
+			p = &fsPendingEvent{eventType: EventCreate, created: created}
+			d.pending[path] = p
+		} else if p.eventType != EventCreate {
+			// A file deleted and written again existed before the
+			// window, its delete must not be lost.
+			p = &fsPendingEvent{eventType: EventCreate}
+			d.pending[path] = p
+		}
+		p.checked = false
+	case EventRemove:
+		if ok && p.eventType == EventCreate && p.created {
+			// The file went away before it was reported.
+			delete(d.pending, path)
+			return
+		}
+		p = &fsPendingEvent{eventType: EventRemove}
+		d.pending[path] = p
+	default:
+		// Reads do not replace a pending put or delete.
+		if ok && p.eventType != eventType {
+			return
+		}
+		if !ok {
+			p = &fsPendingEvent{eventType: eventType}
+			d.pending[path] = p
+		}
+	}
+	p.last = now
+}
+
+// flush returns the events whose window ended at now, sorted by path.
+func (d *fsWatchDebouncer) flush(now time.Time) (events []EventInfo, errs []*probe.Error) {
+	for path, p := range d.pending {
+		if now.Sub(p.last) < d.window {
+			continue
+		}
+		if p.eventType != EventCreate {
+			events = append(events, EventInfo{
+				Time: now.UTC().Format(timeFormatFS),
+				Path: path,
+				Type: p.eventType,
+			})
+			delete(d.pending, path)
+			continue
+		}
+
+		st, e := d.stat(path)
+		if e != nil {
+			delete(d.pending, path)
+			if os.IsNotExist(e) {
+				d.dropped++
+				continue
+			}
+			errs = append(errs, probe.NewError(e).Trace(path))
+			continue
+		}
+		if st.IsDir() {
+			// we want files
+			delete(d.pending, path)
+			continue
+		}
+		if !p.checked || st.Size() != p.size || !st.ModTime().Equal(p.modTime) {
+			// Check again after another window.
+			p.checked = true
+			p.size = st.Size()
+			p.modTime = st.ModTime()
+			p.last = now
+			continue
+		}
+		events = append(events, EventInfo{
+			Time: now.UTC().Format(timeFormatFS),
+			Size: st.Size(),
+			Path: path,
+			Type: EventCreate,
+		})
+		delete(d.pending, path)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	return events, errs
+}
+
+// queueFSEvents forwards the notifications of in to the returned
+// buffered channel until doneChan is closed. Notifications which do not
+// fit in the buffer are counted in overflows instead of blocking notify.
+func queueFSEvents(in chan notify.EventInfo, overflows *int64, doneChan chan struct{}) <-chan notify.EventInfo {
+	queue := make(chan notify.EventInfo, fsWatchDebounceBuffer)
+	go func() {
+		for {
+			select {
+			case <-doneChan:
+				return
+			case event := <-in:
+				select {
+				case queue <- event:
+				default:
+					atomic.AddInt64(overflows, 1)
+				}
+			}
+		}
+	}()
+	return queue
+}
+
+// debounceFSEvents reads the notifications of in and sends the merged
+// events to eventChan until doneChan is closed, then it stops the
+// notifications and closes eventChan and errorChan.
+func debounceFSEvents(in chan notify.EventInfo, window time.Duration, eventChan chan []EventInfo, errorChan chan *probe.Error, doneChan chan struct{}) {
+	defer func() {
+		notify.Stop(in)
+		close(eventChan)
+		close(errorChan)
+	}()
+
+	var overflows int64
+	queue := queueFSEvents(in, &overflows, doneChan)
+
+	d := newFSWatchDebouncer(window)
+	ticker := time.NewTicker(window / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-doneChan:
+			return
+		case event := <-queue:
+			if isIgnoredFile(event.Path()) {
+				continue
+			}
+			created := event.Event()&notify.Create != 0
+			switch {
+			case IsPutEvent(event.Event()):
+				d.add(event.Path(), EventCreate, created, time.Now())
+			case IsDeleteEvent(event.Event()):
+				d.add(event.Path(), EventRemove, false, time.Now())
+			case IsGetEvent(event.Event()):
+				d.add(event.Path(), EventAccessed, false, time.Now())
+			}
+			continue
+		case <-ticker.C:
+		}
+
+		events, errs := d.flush(time.Now())
+		if atomic.SwapInt64(&overflows, 0) > 0 {
+			errs = append(errs, probe.NewError(WatchEventsDropped{Overflow: true}))
+		}
+		if d.dropped > 0 {
+			errs = append(errs, probe.NewError(WatchEventsDropped{Count: d.dropped}))
+			d.dropped = 0
+		}
+		for _, err := range errs {
+			select {
+			case errorChan <- err:
+			case <-doneChan:
+				return
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		select {
+		case eventChan <- events:
+		case <-doneChan:
+			return
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/rjeczalik/notify"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestFSWatchDebouncer(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "watch-debounce-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	stable := filepath.Join(root, "stable.txt")
+	c.Assert(ioutil.WriteFile(stable, []byte("hello"), 0644), IsNil)
+	temp := filepath.Join(root, "temp.txt")
+	missing := filepath.Join(root, "missing.txt")
+	removed := filepath.Join(root, "removed.txt")
+	modified := filepath.Join(root, "modified.txt")
+
+	window := time.Second
+	now := time.Now()
+	d := newFSWatchDebouncer(window)
+
+	// Repeated writes are merged, a file created and deleted within
+	// the window is dropped altogether while an existing file written
+	// and deleted is reported deleted.
+	for i := 0; i < 50; i++ {
+		d.add(stable, EventCreate, i == 0, now)
+	}
+	d.add(temp, EventCreate, true, now)
+	d.add(temp, EventRemove, false, now)
+	d.add(missing, EventCreate, true, now)
+	d.add(removed, EventRemove, false, now)
+	d.add(modified, EventCreate, false, now)
+	d.add(modified, EventRemove, false, now)
+
+	// Nothing is reported within the window.
+	events, errs := d.flush(now.Add(window / 2))
+	c.Assert(events, IsNil)
+	c.Assert(errs, IsNil)
+
+	// The deletes are reported, the put waits for the file to be stable
+	// and the put of a missing file is dropped.
+	now = now.Add(window)
+	events, errs = d.flush(now)
+	c.Assert(errs, IsNil)
+	c.Assert(len(events), Equals, 2)
+	c.Assert(events[0].Path, Equals, modified)
+	c.Assert(events[0].Type, Equals, EventType(EventRemove))
+	c.Assert(events[1].Path, Equals, removed)
+	c.Assert(events[1].Type, Equals, EventType(EventRemove))
+	c.Assert(d.dropped, Equals, 1)
+
+	// The unchanged file is reported after another window.
+	now = now.Add(window)
+	events, errs = d.flush(now)
+	c.Assert(errs, IsNil)
+	c.Assert(len(events), Equals, 1)
+	c.Assert(events[0].Path, Equals, stable)
+	c.Assert(events[0].Type, Equals, EventCreate)
+	c.Assert(events[0].Size, Equals, int64(len("hello")))
+	c.Assert(len(d.pending), Equals, 0)
+}
+
+// testNotifyEvent is a notification of a write to a path.
+type testNotifyEvent string
+
+func (e testNotifyEvent) Event() notify.Event { return notify.Write }
+func (e testNotifyEvent) Path() string        { return string(e) }
+func (e testNotifyEvent) Sys() interface{}    { return nil }
+
+func (s *TestSuite) TestQueueFSEventsOverflow(c *C) {
+	in := make(chan notify.EventInfo, fsWatchDebounceBuffer+5)
+	for i := 0; i < fsWatchDebounceBuffer+5; i++ {
+		in <- testNotifyEvent("/tmp/file")
+	}
+
+	doneChan := make(chan struct{})
+	defer close(doneChan)
+	var overflows int64
+	queue := queueFSEvents(in, &overflows, doneChan)
+
+	// Notifications which do not fit in the queue are counted.
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt64(&overflows) < 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(atomic.LoadInt64(&overflows), Equals, int64(5))
+	c.Assert(len(queue), Equals, fsWatchDebounceBuffer)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -49,6 +50,10 @@ var (
 			Name:  "recursive",
 			Usage: "recursively watch for events",
 		},
+		cli.StringFlag{
+			Name:  "poll-interval",
+			Usage: "list the path at this interval to detect put and delete events, for endpoints without notifications",
+		},
 	}
 )
 
@@ -85,6 +90,9 @@ EXAMPLES:
 
   6. Watch for events on local directory.
      {{.Prompt}} {{.HelpName}} /usr/share
+
+  7. Watch for new and removed objects on Amazon S3 cloud storage by listing the bucket every minute.
+     {{.Prompt}} {{.HelpName}} --recursive --poll-interval 1m s3/testbucket
 `,
 }
 
@@ -93,6 +101,24 @@ func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		cli.ShowCommandHelpAndExit(ctx, "watch", 1) // last argument is exit code
 	}
+	_, err := parseWatchDuration(ctx.String("poll-interval"))
+	fatalIf(err, "Unable to parse the poll interval.")
+}
+
+// parseWatchDuration parses the value of --poll-interval or --debounce,
+// an empty value disables the option.
+func parseWatchDuration(value string) (time.Duration, *probe.Error) {
+	if value == "" {
+		return 0, nil
+	}
+	interval, e := time.ParseDuration(value)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(value)
+	}
+	if interval <= 0 {
+		return 0, errInvalidArgument().Trace(value)
+	}
+	return interval, nil
 }
 
 // watchMessage container to hold one event notification
@@ -145,6 +171,7 @@ func mainWatch(cliCtx *cli.Context) error {
 	suffix := cliCtx.String("suffix")
 	events := strings.Split(cliCtx.String("events"), ",")
 	recursive := cliCtx.Bool("recursive")
+	pollInterval, _ := parseWatchDuration(cliCtx.String("poll-interval"))
 
 	s3Client, pErr := newClient(path)
 	if pErr != nil {
@@ -152,10 +179,11 @@ func mainWatch(cliCtx *cli.Context) error {
 	}
 
 	options := WatchOptions{
-		Recursive: recursive,
-		Events:    events,
-		Prefix:    prefix,
-		Suffix:    suffix,
+		Recursive:    recursive,
+		Events:       events,
+		Prefix:       prefix,
+		Suffix:       suffix,
+		PollInterval: pollInterval,
 	}
 
 	ctx, cancelWatch := context.WithCancel(globalContext)
@@ -0,0 +1,189 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// pollWatchEntry is the state of an object in a polled listing.
+type pollWatchEntry struct {
+	etag    string
+	size    int64
+	modTime time.Time
+}
+
+// pollWatchSnapshot maps the event path of each listed object to its state.
+type pollWatchSnapshot map[string]pollWatchEntry
+
+// pollWatchEventPath returns the path of the events of content, local
+// paths are made absolute like the paths of filesystem notifications.
+func pollWatchEventPath(content *ClientContent) string {
+	if content.URL.Type == fileSystem {
+		if absPath, e := filepath.Abs(content.URL.Path); e == nil {
+			return absPath
+		}
+	}
+	return content.URL.String()
+}
+
+// listPollWatchSnapshot lists clnt and returns the state of the objects
+// matching the prefix and suffix of options.
+func listPollWatchSnapshot(ctx context.Context, clnt Client, options WatchOptions) (pollWatchSnapshot, *probe.Error) {
+	baseURL := clnt.GetURL()
+	snapshot := make(pollWatchSnapshot)
+	for content := range clnt.List(ctx, options.Recursive, false, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(baseURL.String())
+		}
+		if !content.Type.IsRegular() {
+			continue
+		}
+		relPath := listRelativePath(baseURL.Path, content.URL.Path, baseURL.Separator)
+		if !strings.HasPrefix(relPath, options.Prefix) || !strings.HasSuffix(relPath, options.Suffix) {
+			continue
+		}
+		snapshot[pollWatchEventPath(content)] = pollWatchEntry{
+			etag:    content.ETag,
+			size:    content.Size,
+			modTime: content.Time,
+		}
+	}
+	return snapshot, nil
+}
+
+// diffPollWatchSnapshots returns the events turning prev into cur, new and
+// modified objects are reported as created, missing objects as removed.
+// The events are sorted by path.
+func diffPollWatchSnapshots(prev, cur pollWatchSnapshot, put, remove bool, now time.Time) []EventInfo {
+	var events []EventInfo
+	if put {
+		for eventPath, entry := range cur {
+			if old, ok := prev[eventPath]; ok && old.etag == entry.etag && old.size == entry.size && old.modTime.Equal(entry.modTime) {
+				continue
+			}
+			events = append(events, EventInfo{
+				Time: entry.modTime.UTC().Format(time.RFC3339Nano),
+				Size: entry.size,
+				Path: eventPath,
+				Type: EventCreate,
+			})
+		}
+	}
+	if remove {
+		for eventPath, entry := range prev {
+			if _, ok := cur[eventPath]; ok {
+				continue
+			}
+			events = append(events, EventInfo{
+				Time: now.UTC().Format(time.RFC3339Nano),
+				Size: entry.size,
+				Path: eventPath,
+				Type: EventRemove,
+			})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	return events
+}
+
+// pollWatch watches clnt by listing it every options.PollInterval and
+// comparing the listing with the previous one, for endpoints which
+// cannot notify about events. Only put and delete events are reported,
+// the prefix and suffix are relative to the URL of clnt.
+func pollWatch(ctx context.Context, clnt Client, options WatchOptions) (*WatchObject, *probe.Error) {
+	var put, remove bool
+	for _, event := range options.Events {
+		switch event {
+		case "put":
+			put = true
+		case "delete":
+			remove = true
+		case "get":
+			// Reads do not change the listing.
+		default:
+			return nil, errInvalidArgument().Trace(event)
+		}
+	}
+
+	// Changes are reported against the objects present when the
+	// watch starts.
+	snapshot, err := listPollWatchSnapshot(ctx, clnt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	wo := &WatchObject{
+		EventInfoChan: make(chan []EventInfo),
+		ErrorChan:     make(chan *probe.Error),
+		DoneChan:      make(chan struct{}),
+	}
+
+	go func() {
+		defer close(wo.EventInfoChan)
+		defer close(wo.ErrorChan)
+
+		ticker := time.NewTicker(options.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-wo.DoneChan:
+				return
+			case <-ticker.C:
+			}
+
+			cur, err := listPollWatchSnapshot(ctx, clnt, options)
+			if err != nil {
+				// A failed listing is not compared, it would
+				// report every object as removed.
+				select {
+				case wo.ErrorChan <- err:
+				case <-ctx.Done():
+					return
+				case <-wo.DoneChan:
+					return
+				}
+				continue
+			}
+
+			events := diffPollWatchSnapshots(snapshot, cur, put, remove, UTCNow())
+			snapshot = cur
+			if len(events) == 0 {
+				continue
+			}
+			select {
+			case wo.EventInfoChan <- events:
+			case <-ctx.Done():
+				return
+			case <-wo.DoneChan:
+				return
+			}
+		}
+	}()
+
+	return wo, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestDiffPollWatchSnapshots(c *C) {
+	modTime := time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC)
+	now := modTime.Add(time.Hour)
+	prev := pollWatchSnapshot{
+		"s3/bucket/same.txt":    {etag: "1", size: 1, modTime: modTime},
+		"s3/bucket/changed.txt": {etag: "2", size: 2, modTime: modTime},
+		"s3/bucket/removed.txt": {etag: "3", size: 3, modTime: modTime},
+	}
+	cur := pollWatchSnapshot{
+		"s3/bucket/same.txt":    {etag: "1", size: 1, modTime: modTime},
+		"s3/bucket/changed.txt": {etag: "4", size: 2, modTime: now},
+		"s3/bucket/added.txt":   {etag: "5", size: 5, modTime: now},
+	}
+
+	testCases := []struct {
+		put, remove bool
+		expected    []EventInfo
+	}{
+		{true, true, []EventInfo{
+			{Time: now.Format(time.RFC3339Nano), Size: 5, Path: "s3/bucket/added.txt", Type: EventCreate},
+			{Time: now.Format(time.RFC3339Nano), Size: 2, Path: "s3/bucket/changed.txt", Type: EventCreate},
+			{Time: now.Format(time.RFC3339Nano), Size: 3, Path: "s3/bucket/removed.txt", Type: EventRemove},
+		}},
+		{false, true, []EventInfo{
+			{Time: now.Format(time.RFC3339Nano), Size: 3, Path: "s3/bucket/removed.txt", Type: EventRemove},
+		}},
+		{false, false, nil},
+	}
+	for _, testCase := range testCases {
+		events := diffPollWatchSnapshots(prev, cur, testCase.put, testCase.remove, now)
+		c.Assert(events, DeepEquals, testCase.expected)
+	}
+
+	// An unchanged listing reports no event.
+	c.Assert(diffPollWatchSnapshots(cur, cur, true, true, now), IsNil)
+}
@@ -72,6 +72,12 @@ type WatchOptions struct {
 	Suffix    string
 	Events    []string
 	Recursive bool
+	// PollInterval, if set, watches by listing at this interval
+	// instead of listening for notifications.
+	PollInterval time.Duration
+	// Debounce, if set, merges the events of a path on a filesystem
+	// until none arrived for this window.
+	Debounce time.Duration
 }
 
 // WatchObject captures watch channels to read and listen on.
@@ -141,11 +147,8 @@ func (w *Watcher) Wait() {
 }
 
 // Join the watcher with client
-func (w *Watcher) Join(ctx context.Context, client Client, recursive bool) *probe.Error {
-	wo, err := client.Watch(ctx, WatchOptions{
-		Recursive: recursive,
-		Events:    []string{"put", "delete"},
-	})
+func (w *Watcher) Join(ctx context.Context, client Client, options WatchOptions) *probe.Error {
+	wo, err := client.Watch(ctx, options)
 	if err != nil {
 		return err
 	}
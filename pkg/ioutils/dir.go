@@ -0,0 +1,188 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ioutils
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DirChunkSize is the number of entries of a directory sorted in memory
+// at once, larger directories are sorted in chunks spilled to temporary
+// files and merged.
+var DirChunkSize = 50000
+
+// DirReader streams the entries of a directory in lexical order, the
+// name of a directory sorting as if it ended with a separator. Memory
+// use is bounded by DirChunkSize whatever the size of the directory.
+type DirReader struct {
+	entries []dirItem
+	sorted  *ExternalSort
+}
+
+// dirItem is an entry of a directory with its sort key.
+type dirItem struct {
+	fi  os.FileInfo
+	key string
+}
+
+// OpenDir reads the directory named by dirname and returns a reader of
+// its sorted entries, which must be closed once done. With followLinks
+// symlinks to directories sort as directories, as they are walked.
+func OpenDir(dirname string, followLinks bool) (*DirReader, error) {
+	f, err := os.Open(dirname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d := &DirReader{}
+	for {
+		fis, err := f.Readdir(DirChunkSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			d.Close()
+			return nil, err
+		}
+		// Keep the first chunk in memory until the directory is
+		// known to be larger.
+		if d.entries != nil {
+			d.sorted = NewExternalSort(DirChunkSize)
+			for _, item := range d.entries {
+				if err = d.sorted.Add(item.key, encodeDirEntry(item.fi)); err != nil {
+					d.Close()
+					return nil, err
+				}
+			}
+			d.entries = nil
+		}
+		if d.sorted != nil {
+			for _, fi := range fis {
+				if err = d.sorted.Add(dirEntryKey(dirname, fi, followLinks), encodeDirEntry(fi)); err != nil {
+					d.Close()
+					return nil, err
+				}
+			}
+			continue
+		}
+		d.entries = make([]dirItem, len(fis))
+		for i, fi := range fis {
+			d.entries[i] = dirItem{fi: fi, key: dirEntryKey(dirname, fi, followLinks)}
+		}
+	}
+	if d.sorted == nil {
+		sort.Slice(d.entries, func(i, j int) bool { return d.entries[i].key < d.entries[j].key })
+	}
+	return d, nil
+}
+
+// Next returns the next entry of the directory, io.EOF once all
+// entries were returned.
+func (d *DirReader) Next() (os.FileInfo, error) {
+	if d.sorted == nil {
+		if len(d.entries) == 0 {
+			return nil, io.EOF
+		}
+		fi := d.entries[0].fi
+		d.entries = d.entries[1:]
+		return fi, nil
+	}
+	key, value, err := d.sorted.Next()
+	if err != nil {
+		return nil, err
+	}
+	return decodeDirEntry(key, value)
+}
+
+// Close removes the temporary files of the reader.
+func (d *DirReader) Close() error {
+	if d.sorted != nil {
+		d.sorted.Close()
+	}
+	d.entries = nil
+	return nil
+}
+
+// dirEntry is an entry of a directory read back from a spilled chunk.
+type dirEntry struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (e *dirEntry) Name() string       { return e.name }
+func (e *dirEntry) Size() int64        { return e.size }
+func (e *dirEntry) Mode() os.FileMode  { return e.mode }
+func (e *dirEntry) ModTime() time.Time { return e.modTime }
+func (e *dirEntry) IsDir() bool        { return e.mode.IsDir() }
+func (e *dirEntry) Sys() interface{}   { return nil }
+
+// dirEntryKey returns the sort key of an entry of dirname, the name of
+// a directory is followed by a separator.
+func dirEntryKey(dirname string, fi os.FileInfo, followLinks bool) string {
+	isDir := fi.IsDir()
+	if !isDir && followLinks && fi.Mode()&os.ModeSymlink == os.ModeSymlink {
+		st, err := os.Stat(filepath.Join(dirname, fi.Name()))
+		isDir = err == nil && st.IsDir()
+	}
+	if isDir {
+		return fi.Name() + string(os.PathSeparator)
+	}
+	return fi.Name()
+}
+
+// encodeDirEntry encodes the mode, size and modification time of an
+// entry spilled to a temporary file, its name is its sort key.
+func encodeDirEntry(fi os.FileInfo) []byte {
+	return []byte(strconv.FormatUint(uint64(fi.Mode()), 10) + " " +
+		strconv.FormatInt(fi.Size(), 10) + " " +
+		strconv.FormatInt(fi.ModTime().UnixNano(), 10))
+}
+
+// decodeDirEntry decodes an entry encoded by encodeDirEntry.
+func decodeDirEntry(key string, value []byte) (os.FileInfo, error) {
+	fields := strings.Fields(string(value))
+	if len(fields) != 3 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	mode, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	modTime, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &dirEntry{
+		name:    strings.TrimSuffix(key, string(os.PathSeparator)),
+		size:    size,
+		mode:    os.FileMode(mode),
+		modTime: time.Unix(0, modTime),
+	}, nil
+}
@@ -22,7 +22,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
 )
 
 // IsDirEmpty Check if a directory is empty
@@ -46,40 +45,17 @@ func FTW(root string, walkFn FTWFunc) error {
 	if err != nil {
 		return walkFn(root, nil, err)
 	}
-	return walk(root, info, walkFn)
+	return walk(root, info, walkFn, false)
 }
 
-// byName implements sort.Interface for sorting os.FileInfo list.
-type byName []os.FileInfo
-
-func (f byName) Len() int      { return len(f) }
-func (f byName) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
-func (f byName) Less(i, j int) bool {
-	n1 := f[i].Name()
-	if f[i].IsDir() {
-		n1 = n1 + string(os.PathSeparator)
-	}
-
-	n2 := f[j].Name()
-	if f[j].IsDir() {
-		n2 = n2 + string(os.PathSeparator)
-	}
-
-	return n1 < n2
-}
-
-// readDir reads the directory named by dirname and returns
-// a sorted list of directory entries.
-func readDir(dirname string) (fi []os.FileInfo, err error) {
-	f, err := os.Open(dirname)
-	if err == nil {
-		defer f.Close()
-		if fi, err = f.Readdir(-1); fi != nil {
-			sort.Sort(byName(fi))
-		}
+// FTWFollow walks the file tree like FTW, sorting symlinks to
+// directories as directories for walkFn to walk them in lexical order.
+func FTWFollow(root string, walkFn FTWFunc) error {
+	info, err := os.Lstat(root)
+	if err != nil {
+		return walkFn(root, nil, err)
 	}
-
-	return
+	return walk(root, info, walkFn, true)
 }
 
 // FTWFunc is the type of the function called for each file or directory
@@ -104,7 +80,7 @@ var ErrSkipFile = errors.New("skip this file")
 var ErrDirNotEmpty = errors.New("directory not empty")
 
 // walk recursively descends path, calling walkFn.
-func walk(path string, info os.FileInfo, walkFn FTWFunc) error {
+func walk(path string, info os.FileInfo, walkFn FTWFunc, followLinks bool) error {
 	err := walkFn(path, info, nil)
 	if err != nil {
 		if info.Mode().IsDir() && err == ErrSkipDir {
@@ -120,25 +96,27 @@ func walk(path string, info os.FileInfo, walkFn FTWFunc) error {
 		return nil
 	}
 
-	fis, err := readDir(path)
+	// Stream the entries, huge directories are never held in memory.
+	dir, err := OpenDir(path, followLinks)
 	if err != nil {
 		return walkFn(path, info, err)
 	}
-	for _, fileInfo := range fis {
+	defer dir.Close()
+	for {
+		fileInfo, err := dir.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return walkFn(path, info, err)
+		}
 		filename := filepath.Join(path, fileInfo.Name())
+		err = walk(filename, fileInfo, walkFn, followLinks)
 		if err != nil {
-			if err = walkFn(filename, fileInfo, err); err != nil && err != ErrSkipDir && err != ErrSkipFile {
-				return err
-			}
-		} else {
-			err = walk(filename, fileInfo, walkFn)
-			if err != nil {
-				if err == ErrSkipDir || err == ErrSkipFile {
-					return nil
-				}
-				return err
+			if err == ErrSkipDir || err == ErrSkipFile {
+				return nil
 			}
+			return err
 		}
 	}
-	return nil
 }
@@ -17,8 +17,12 @@
 package ioutils_test
 
 import (
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"testing"
 	"time"
 
@@ -43,6 +47,95 @@ func (s *MySuite) TestIoutils(c *C) {
 	c.Assert(status, Equals, true)
 }
 
+func (s *MySuite) TestOpenDir(c *C) {
+	path, err := ioutil.TempDir(os.TempDir(), "minio-ioutils_test")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(path)
+
+	var expected []string
+	for i := 0; i < 500; i++ {
+		name := fmt.Sprintf("entry%d", i*7%500)
+		if i%10 == 0 {
+			c.Assert(os.Mkdir(filepath.Join(path, name), 0700), IsNil)
+			name += string(os.PathSeparator)
+		} else {
+			c.Assert(ioutil.WriteFile(filepath.Join(path, name), []byte("data"), 0600), IsNil)
+		}
+		expected = append(expected, name)
+	}
+	// A folder sorts after a sibling whose name continues with a
+	// character below the separator.
+	c.Assert(os.Mkdir(filepath.Join(path, "a"), 0700), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(path, "a.b"), []byte("data"), 0600), IsNil)
+	expected = append(expected, "a"+string(os.PathSeparator), "a.b")
+	sort.Strings(expected)
+	c.Assert(expected[0], Equals, "a.b")
+
+	defer func(chunkSize int) { ioutils.DirChunkSize = chunkSize }(ioutils.DirChunkSize)
+	// Sorted in memory, then spilled to temporary files.
+	for _, chunkSize := range []int{1000, 3} {
+		ioutils.DirChunkSize = chunkSize
+		dir, err := ioutils.OpenDir(path, false)
+		c.Assert(err, IsNil)
+		var names []string
+		for {
+			fi, err := dir.Next()
+			if err == io.EOF {
+				break
+			}
+			c.Assert(err, IsNil)
+			name := fi.Name()
+			if fi.IsDir() {
+				name += string(os.PathSeparator)
+			} else {
+				c.Assert(fi.Size(), Equals, int64(4))
+			}
+			names = append(names, name)
+		}
+		c.Assert(dir.Close(), IsNil)
+		c.Assert(names, DeepEquals, expected)
+	}
+}
+
+func (s *MySuite) TestExternalSort(c *C) {
+	// Sorted in memory, spilled to runs merged once, then to runs merged
+	// over several levels.
+	for _, testCase := range []struct {
+		chunkSize, count int
+	}{
+		{10000, 1000},
+		{3, 1000},
+		{1, 4095},
+		{1, 5000},
+	} {
+		sorted := ioutils.NewExternalSort(testCase.chunkSize)
+		for i := 0; i < testCase.count; i++ {
+			c.Assert(sorted.Add(fmt.Sprintf("key%03d", i*7%100), []byte(fmt.Sprint(i))), IsNil)
+		}
+		var keys []string
+		last := map[string]int{}
+		for {
+			key, value, err := sorted.Next()
+			if err == io.EOF {
+				break
+			}
+			c.Assert(err, IsNil)
+			// Records with equal keys keep the order they were added in.
+			var i int
+			_, err = fmt.Sscan(string(value), &i)
+			c.Assert(err, IsNil)
+			if prev, ok := last[key]; ok {
+				c.Assert(i > prev, Equals, true)
+			}
+			last[key] = i
+			keys = append(keys, key)
+		}
+		c.Assert(sorted.Close(), IsNil)
+		c.Assert(len(keys), Equals, testCase.count)
+		c.Assert(sort.StringsAreSorted(keys), Equals, true)
+	}
+}
+
 // Test for ParseDurationTime. Validates the returned value
 // for given time value in days, hours and minute format.
 func TestParseDurationTime(t *testing.T) {
@@ -0,0 +1,343 @@
+/*
+ * MinIO Client (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ioutils
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// sortMergeFanIn is the number of spilled runs merged at once, bounding
+// the number of open temporary files.
+var sortMergeFanIn = 64
+
+// ExternalSort sorts records by key, holding at most chunkSize records
+// in memory: larger inputs are sorted in chunks spilled to temporary
+// files, merged hierarchically so that each record is rewritten once
+// per level of sortMergeFanIn runs. Records with equal keys are
+// returned in the order they were added.
+type ExternalSort struct {
+	chunkSize int
+	chunk     []sortRecord
+	// levels[i] holds the runs of up to chunkSize*sortMergeFanIn^i records.
+	levels   []sortRuns
+	runs     sortRuns
+	spilled  bool
+	finished bool
+	nextRun  int
+}
+
+// sortRecord is a record with its sort key.
+type sortRecord struct {
+	key   string
+	value []byte
+}
+
+// NewExternalSort returns a sort holding at most chunkSize records in
+// memory, it must be closed once done.
+func NewExternalSort(chunkSize int) *ExternalSort {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	return &ExternalSort{chunkSize: chunkSize}
+}
+
+// Add adds a record, records cannot be added once Next was called.
+func (s *ExternalSort) Add(key string, value []byte) error {
+	if len(s.chunk) == s.chunkSize {
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	s.chunk = append(s.chunk, sortRecord{key: key, value: value})
+	return nil
+}
+
+// spill writes the chunk held in memory to a new run, merging the runs
+// of a level into a run of the next level once the level is full.
+func (s *ExternalSort) spill() error {
+	sort.SliceStable(s.chunk, func(i, j int) bool { return s.chunk[i].key < s.chunk[j].key })
+	run, err := newSortRun(s.nextRun)
+	if err != nil {
+		return err
+	}
+	s.nextRun++
+	for _, record := range s.chunk {
+		if err = run.write(record.key, record.value); err != nil {
+			run.close()
+			return err
+		}
+	}
+	s.chunk = nil
+	s.spilled = true
+	if err = run.rewind(); err != nil {
+		run.close()
+		return err
+	}
+
+	if len(s.levels) == 0 {
+		s.levels = append(s.levels, nil)
+	}
+	s.levels[0] = append(s.levels[0], run)
+	for i := 0; len(s.levels[i]) == sortMergeFanIn; i++ {
+		merged, err := mergeSortRuns(s.levels[i])
+		s.levels[i] = nil
+		if err != nil {
+			return err
+		}
+		if i+1 == len(s.levels) {
+			s.levels = append(s.levels, nil)
+		}
+		s.levels[i+1] = append(s.levels[i+1], merged)
+	}
+	return nil
+}
+
+// finish sorts the records added, merging the newest runs, which are
+// the smallest, until at most sortMergeFanIn runs are left to be merged
+// while reading.
+func (s *ExternalSort) finish() error {
+	s.finished = true
+	if !s.spilled {
+		sort.SliceStable(s.chunk, func(i, j int) bool { return s.chunk[i].key < s.chunk[j].key })
+		return nil
+	}
+	if len(s.chunk) > 0 {
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	// The runs of the highest level are the oldest and the largest.
+	for i := len(s.levels) - 1; i >= 0; i-- {
+		s.runs = append(s.runs, s.levels[i]...)
+	}
+	s.levels = nil
+	for len(s.runs) > sortMergeFanIn {
+		last := len(s.runs) - sortMergeFanIn
+		merged, err := mergeSortRuns(append(sortRuns(nil), s.runs[last:]...))
+		s.runs = s.runs[:last]
+		if err != nil {
+			return err
+		}
+		s.runs = append(s.runs, merged)
+	}
+	heap.Init(&s.runs)
+	return nil
+}
+
+// Next returns the following record in the order of the keys, io.EOF
+// once all records were returned.
+func (s *ExternalSort) Next() (string, []byte, error) {
+	if !s.finished {
+		if err := s.finish(); err != nil {
+			return "", nil, err
+		}
+	}
+	if !s.spilled {
+		if len(s.chunk) == 0 {
+			return "", nil, io.EOF
+		}
+		record := s.chunk[0]
+		s.chunk = s.chunk[1:]
+		return record.key, record.value, nil
+	}
+	if len(s.runs) == 0 {
+		return "", nil, io.EOF
+	}
+	run := s.runs[0]
+	key, value := run.key, run.value
+	if err := s.runs.advance(); err != nil {
+		return "", nil, err
+	}
+	return key, value, nil
+}
+
+// Close removes the temporary files of the sort.
+func (s *ExternalSort) Close() error {
+	for _, level := range s.levels {
+		level.close()
+	}
+	s.runs.close()
+	s.levels = nil
+	s.runs = nil
+	s.chunk = nil
+	return nil
+}
+
+// mergeSortRuns merges sorted runs into a single one, closing them.
+// The merged run keeps the order of the first run merged.
+func mergeSortRuns(runs sortRuns) (*sortRun, error) {
+	order := runs[0].order
+	for _, run := range runs {
+		if run.order < order {
+			order = run.order
+		}
+	}
+	out, err := newSortRun(order)
+	if err != nil {
+		runs.close()
+		return nil, err
+	}
+	heap.Init(&runs)
+	for len(runs) > 0 {
+		if err = out.write(runs[0].key, runs[0].value); err == nil {
+			err = runs.advance()
+		}
+		if err != nil {
+			runs.close()
+			out.close()
+			return nil, err
+		}
+	}
+	if err = out.rewind(); err != nil {
+		out.close()
+		return nil, err
+	}
+	return out, nil
+}
+
+// sortRun is a sorted chunk of records spilled to a temporary file,
+// each record is saved as the length of its key, its key, the length
+// of its value and its value. The order of a run breaks ties between
+// equal keys of different runs.
+type sortRun struct {
+	file  *os.File
+	w     *bufio.Writer
+	r     *bufio.Reader
+	order int
+	key   string
+	value []byte
+}
+
+func newSortRun(order int) (*sortRun, error) {
+	file, err := ioutil.TempFile("", "mc-sort-")
+	if err != nil {
+		return nil, err
+	}
+	return &sortRun{file: file, w: bufio.NewWriter(file), order: order}, nil
+}
+
+func (r *sortRun) write(key string, value []byte) error {
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(key)))
+	if _, err := r.w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	if _, err := r.w.WriteString(key); err != nil {
+		return err
+	}
+	n = binary.PutUvarint(lenBuf[:], uint64(len(value)))
+	if _, err := r.w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	_, err := r.w.Write(value)
+	return err
+}
+
+// rewind switches the run from writing to reading its first record.
+func (r *sortRun) rewind() error {
+	if err := r.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	r.r = bufio.NewReader(r.file)
+	return r.next()
+}
+
+// next reads the following record of the run, io.EOF at its end.
+func (r *sortRun) next() error {
+	keyLen, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return err
+	}
+	key := make([]byte, keyLen)
+	if _, err = io.ReadFull(r.r, key); err != nil {
+		return unexpectedEOF(err)
+	}
+	valueLen, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	value := make([]byte, valueLen)
+	if _, err = io.ReadFull(r.r, value); err != nil {
+		return unexpectedEOF(err)
+	}
+	r.key, r.value = string(key), value
+	return nil
+}
+
+// unexpectedEOF reports the end of a run in the middle of a record.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (r *sortRun) close() {
+	r.file.Close()
+	os.Remove(r.file.Name())
+}
+
+// sortRuns is a min-heap of runs ordered by their head record.
+type sortRuns []*sortRun
+
+func (h sortRuns) Len() int { return len(h) }
+func (h sortRuns) Less(i, j int) bool {
+	if h[i].key != h[j].key {
+		return h[i].key < h[j].key
+	}
+	return h[i].order < h[j].order
+}
+func (h sortRuns) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *sortRuns) Push(x interface{}) { *h = append(*h, x.(*sortRun)) }
+func (h *sortRuns) Pop() interface{} {
+	old := *h
+	run := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return run
+}
+
+// advance moves the first run of the heap to its next record, dropping
+// it once exhausted.
+func (h *sortRuns) advance() error {
+	run := (*h)[0]
+	err := run.next()
+	if err == io.EOF {
+		heap.Pop(h)
+		run.close()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	heap.Fix(h, 0)
+	return nil
+}
+
+func (h sortRuns) close() {
+	for _, run := range h {
+		run.close()
+	}
+}